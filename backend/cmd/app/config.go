@@ -0,0 +1,25 @@
+package main
+
+import (
+	"os"
+
+	"github.com/shirloin/stockhub/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const configUsage = `usage: server config <command>
+
+  print  show the effective configuration as YAML, after the config file and
+         environment overrides, with the database password redacted`
+
+// runConfig implements the config subcommand; main has already loaded and validated cfg
+func runConfig(cfg *config.Config, args []string) {
+	if len(args) != 1 || args[0] != "print" {
+		usage(configUsage)
+	}
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg.Redacted()); err != nil {
+		fatal("Error printing configuration", err)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/shirloin/stockhub/internal/config"
+	"github.com/shirloin/stockhub/internal/database"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+  up             apply all pending migrations
+  down [N]       revert the last N applied migrations (default 1)
+  status         list migrations and whether they are applied
+  create <name>  add an empty migration to ` + database.MigrationsDir
+
+// usage prints the usage of a subcommand and exits
+func usage(text string) {
+	fmt.Fprintln(os.Stderr, text)
+	os.Exit(2)
+}
+
+// runMigrate implements the migrate subcommand
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		usage(migrateUsage)
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			usage(migrateUsage)
+		}
+		up, down, err := database.CreateMigration(database.MigrationsDir, args[1])
+		if err != nil {
+			fatal("Error creating migration", err)
+		}
+		fmt.Printf("Created %s\nCreated %s\n", up, down)
+		return
+	}
+	if args[0] != "up" && args[0] != "down" && args[0] != "status" {
+		usage(migrateUsage)
+	}
+
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		fatal("Error opening database", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		fatal("Error getting database instance", err)
+	}
+	migrator, err := database.NewMigrator(sqlDB)
+	if err != nil {
+		fatal("Error loading migrations", err)
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			fatal("Error applying migrations", err)
+		}
+		fmt.Printf("Applied %d migrations\n", count)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				usage(migrateUsage)
+			}
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fatal("Error reverting migrations", err)
+		}
+		fmt.Printf("Reverted %d migrations\n", count)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("Error reading migration status", err)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "-"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(tw, "%06d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+		}
+		tw.Flush()
+	}
+}
@@ -1,65 +1,307 @@
 package main
 
 import (
-	"log"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 
-	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/shirloin/stockhub/internal/config"
 	"github.com/shirloin/stockhub/internal/database"
+	grpcHandler "github.com/shirloin/stockhub/internal/delivery/grpc/handler"
+	"github.com/shirloin/stockhub/internal/delivery/http/handler"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/logging"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/tenant"
+	"github.com/shirloin/stockhub/internal/tracing"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	cfg := config.Load()
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		// One problem per line, before logging is configured
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n  %s\n", strings.ReplaceAll(err.Error(), "\n", "\n  "))
+		os.Exit(1)
+	}
+	setupLogging(cfg)
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			runMigrate(cfg, os.Args[2:])
+			return
+		case "config":
+			runConfig(cfg, os.Args[2:])
+			return
+		}
+	}
+
+	var tlsConfig *tls.Config
+	var certificates *config.CertificateReloader
+	if cfg.TLS.Enabled() {
+		certificates, err = config.NewCertificateReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			fatal("Error loading TLS certificate", err)
+		}
+		tlsConfig = certificates.TLSConfig()
+	}
+	single := cfg.Server.Mode == config.ServerModeSingle
+
 	mux := config.NewMux()
 	corsConfig := config.NewCORSConfig(cfg)
-	grpcServer := config.NewGRPCServer()
-	db, err := database.GetInstance()
+	grpcTLSConfig := tlsConfig
+	if single {
+		// The shared HTTP server terminates TLS before requests reach gRPC
+		grpcTLSConfig = nil
+	}
+	limiter := config.NewRateLimiter(cfg.RateLimit)
+	apiKeyAuth := &grpcHandler.APIKeyAuth{Required: cfg.Auth.RequireAPIKey}
+	grpcServer := config.NewGRPCServer(cfg, grpcTLSConfig, limiter, apiKeyAuth)
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		fatal("Error opening database", err)
+	}
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatalf("Error getting database instance: %v", err)
-		panic(err)
+		fatal("Error getting database instance", err)
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.ServiceName)
+	if err != nil {
+		fatal("Error setting up tracing", err)
+	}
+	if err := metrics.InstrumentDB(db); err != nil {
+		fatal("Error instrumenting database", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		fatal("Error instrumenting database", err)
+	}
+	if err := db.Use(&tenant.GormPlugin{}); err != nil {
+		fatal("Error scoping database to organisations", err)
+	}
+	migrator, err := database.NewMigrator(sqlDB)
+	if err != nil {
+		fatal("Error loading migrations", err)
+	}
+	if cfg.Database.MigrateOnStart {
+		count, err := migrator.Up(context.Background())
+		if err != nil {
+			fatal("Error migrating database", err)
+		}
+		if count > 0 {
+			slog.Info("Applied database migrations", "count", count)
+		}
 	}
-	database.Migrate()
 
 	bootstrapConfig := config.BootstrapConfig{
+		Config:     cfg,
 		DB:         db,
 		Mux:        mux,
 		CORSConfig: corsConfig,
-		GRPCServer: grpcServer,
+		ReadinessChecks: map[string]handler.HealthCheck{
+			"database":   sqlDB.PingContext,
+			"migrations": migrator.Verify,
+		},
+		GRPCServer:  grpcServer,
+		RateLimiter: limiter,
+		APIKeyAuth:  apiKeyAuth,
 	}
 
 	config.Bootstrap(&bootstrapConfig)
 
-	wrappedGrpc := corsConfig.SetupGRPCCORS(grpcServer)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var services []string
+	for service := range grpcServer.GetServiceInfo() {
+		services = append(services, service)
+	}
+	go config.WatchDatabaseHealth(ctx, db, bootstrapConfig.HealthServer, services, cfg.GRPC.HealthCheckInterval)
+	if limiter != nil {
+		go limiter.Cleanup(ctx, cfg.RateLimit.ClientIdleTimeout)
+	}
+	sinks := append([]domain.EventSink{bootstrapConfig.EventBus, bootstrapConfig.WebhookUseCase}, config.NotificationSinks(cfg.Notifications, bootstrapConfig.NotificationUseCase)...)
+	sinks = append(sinks, bootstrapConfig.AlertUseCase.Sink())
+	go config.RunOutboxDispatcher(ctx, cfg.Outbox, bootstrapConfig.OutboxUseCase, sinks...)
+	go config.RunWebhookDispatcher(ctx, cfg.Webhooks, bootstrapConfig.WebhookUseCase)
+	go config.RunNotificationDispatcher(ctx, cfg.Notifications, bootstrapConfig.NotificationUseCase)
+	go config.RunAlertEvaluator(ctx, cfg.Alerts, bootstrapConfig.AlertUseCase)
+	config.WatchForChanges(ctx, cfg.Streams.PollInterval, bootstrapConfig.Repositories, bootstrapConfig.EventBus)
+	if certificates != nil && cfg.TLS.ReloadInterval > 0 {
+		go certificates.Watch(ctx, cfg.TLS.ReloadInterval)
+	}
 
-	go startHTTPServer(cfg.PORT, bootstrapConfig.Handler)
-	go startGRPCServer(cfg.GRPC_PORT, wrappedGrpc)
+	var httpServers []namedServer
+	if single {
+		httpServers = []namedServer{
+			{"HTTP server (REST, Connect, gRPC-Web and gRPC)", newHTTP2Server(cfg.Server.HTTPAddr, bootstrapConfig.SinglePort, tlsConfig)},
+		}
+	} else {
+		httpServers = []namedServer{
+			{"HTTP server", newHTTPServer(cfg.Server.HTTPAddr, bootstrapConfig.Handler, tlsConfig)},
+			{"Connect/gRPC-Web server", newHTTP2Server(cfg.Server.ConnectAddr, bootstrapConfig.GRPCWeb, tlsConfig)},
+		}
+	}
+	serverErrors := make(chan error, 3)
+	for _, server := range httpServers {
+		go serve(server.name, server.Addr, listenAndServe(server.Server), serverErrors)
+	}
+	if !single {
+		go serve("gRPC server", cfg.Server.GRPCAddr, func() error {
+			listener, err := net.Listen("tcp", cfg.Server.GRPCAddr)
+			if err != nil {
+				return err
+			}
+			return grpcServer.Serve(listener)
+		}, serverErrors)
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	exitCode := 0
+	select {
+	case sig := <-quit:
+		slog.Info("Shutting down", "signal", sig.String())
+	case err := <-serverErrors:
+		slog.Error("Shutting down after server failure", "error", err)
+		exitCode = 1
+	}
+
+	// Fail readiness and gRPC health first so load balancers stop sending new work, and keep
+	// serving until they have noticed
+	bootstrapConfig.HealthHandler.Drain()
+	bootstrapConfig.HealthServer.Shutdown()
+	if cfg.Server.DrainDelay > 0 {
+		slog.Info("Draining", "delay", cfg.Server.DrainDelay)
+		// A second signal stops waiting
+		select {
+		case <-time.After(cfg.Server.DrainDelay):
+		case <-quit:
+		}
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelShutdown()
+
+	// Watch streams never end on their own; close them so draining can finish
+	bootstrapConfig.GRPCHandler.Shutdown()
+
+	var wg sync.WaitGroup
+	for _, server := range httpServers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				slog.Warn("Server shutdown", "server", server.name, "error", err)
+			}
+		}()
+	}
+	if single {
+		// gRPC calls ran inside the HTTP server, which has drained them; GracefulStop does not
+		// support connections handed over by ServeHTTP
+		wg.Wait()
+		grpcServer.Stop()
+	} else {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-shutdownCtx.Done():
+				slog.Warn("gRPC server shutdown", "error", shutdownCtx.Err())
+				grpcServer.Stop()
+			}
+		}()
+		wg.Wait()
+	}
+
+	// Nothing is serving any more; stop the pollers and dispatchers, flush buffered spans and release the database
+	cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("Flushing traces", "error", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		slog.Warn("Closing database", "error", err)
+	}
+	slog.Info("Shutdown complete")
+	os.Exit(exitCode)
+}
+
+// setupLogging installs the slog default logger configured by log.level and log.format;
+// it also receives anything written with the standard log package
+func setupLogging(cfg *config.Config) {
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		fatal("Invalid log level", err)
+	}
+	logger, err := logging.New(os.Stderr, level, cfg.Log.Format)
+	if err != nil {
+		fatal("Invalid log format", err)
+	}
+	slog.SetDefault(logger)
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
-func startHTTPServer(port string, handler http.Handler) {
-	server := &http.Server{
-		Addr:    port,
-		Handler: handler,
+// serve runs a server until it stops, reporting any failure other than a requested shutdown
+func serve(name string, port string, run func() error, errs chan<- error) {
+	slog.Info("Server running", "server", name, "port", port)
+	if err := run(); err != nil && err != http.ErrServerClosed && err != grpc.ErrServerStopped {
+		errs <- fmt.Errorf("%s error: %w", name, err)
 	}
-	log.Printf("Server is running on port %s", server.Addr)
-	server.ListenAndServe()
 }
 
-func startGRPCServer(port string, wrappedGrpc *grpcweb.WrappedGrpcServer) {
-	server := &http.Server{
-		Addr:    port,
-		Handler: wrappedGrpc,
+// namedServer labels an http.Server in logs
+type namedServer struct {
+	name string
+	*http.Server
+}
+
+// listenAndServe serves HTTPS when the server has a TLS configuration and plain HTTP otherwise
+func listenAndServe(server *http.Server) func() error {
+	if server.TLSConfig != nil {
+		// The certificate comes from TLSConfig.GetCertificate
+		return func() error { return server.ListenAndServeTLS("", "") }
+	}
+	return server.ListenAndServe
+}
+
+func newHTTPServer(addr string, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	return &http.Server{
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: tlsConfig,
 	}
+}
 
-	log.Printf("gRPC-Web server running on %s", port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("gRPC-Web server error: %v", err)
+// newHTTP2Server serves handlers that speak gRPC, which needs HTTP/2: negotiated over TLS,
+// or unencrypted (h2c) otherwise so gRPC clients can connect without TLS
+func newHTTP2Server(addr string, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+	return &http.Server{
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: tlsConfig,
+		Protocols: protocols,
 	}
 }
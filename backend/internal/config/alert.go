@@ -0,0 +1,16 @@
+package config
+
+import (
+	"context"
+
+	"github.com/shirloin/stockhub/internal/usecase"
+)
+
+// RunAlertEvaluator evaluates every alert rule and wakes ended snoozes until ctx is cancelled, or
+// returns at once when disabled on this replica. Rules are still evaluated as stock moves.
+func RunAlertEvaluator(ctx context.Context, cfg AlertsConfig, alerts *usecase.AlertUseCase) {
+	if !cfg.Enabled {
+		return
+	}
+	alerts.Run(ctx, usecase.AlertPolicy{EvaluateInterval: cfg.EvaluateInterval})
+}
@@ -1,48 +1,133 @@
 package config
 
 import (
+	"log/slog"
 	"net/http"
+	"os"
 
+	"connectrpc.com/connect"
 	"github.com/gorilla/mux"
 	grpcHandler "github.com/shirloin/stockhub/internal/delivery/grpc/handler"
 	"github.com/shirloin/stockhub/internal/delivery/http/handler"
+	"github.com/shirloin/stockhub/internal/delivery/http/openapi"
 	"github.com/shirloin/stockhub/internal/delivery/http/route"
+	"github.com/shirloin/stockhub/internal/eventbus"
+	"github.com/shirloin/stockhub/internal/logging"
+	"github.com/shirloin/stockhub/internal/ratelimit"
 	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/internal/tracing"
 	"github.com/shirloin/stockhub/internal/usecase"
+	pbAlert "github.com/shirloin/stockhub/proto/alert"
+	"github.com/shirloin/stockhub/proto/alert/alertconnect"
 	pbMovement "github.com/shirloin/stockhub/proto/movement"
+	"github.com/shirloin/stockhub/proto/movement/movementconnect"
 	pb "github.com/shirloin/stockhub/proto/product"
+	"github.com/shirloin/stockhub/proto/product/productconnect"
 	pbWarehouse "github.com/shirloin/stockhub/proto/warehouse"
+	"github.com/shirloin/stockhub/proto/warehouse/warehouseconnect"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"gorm.io/gorm"
 )
 
 type BootstrapConfig struct {
-	DB          *gorm.DB
-	Mux         *mux.Router
-	CORSConfig  *CORSConfig
-	Handler     http.Handler
-	GRPCServer  *grpc.Server
-	GRPCHandler *grpcHandler.GRPCHandler
+	Config              *Config
+	DB                  *gorm.DB
+	Mux                 *mux.Router
+	CORSConfig          *CORSConfig
+	ReadinessChecks     map[string]handler.HealthCheck
+	Handler             http.Handler
+	GRPCServer          *grpc.Server
+	RateLimiter         *ratelimit.Limiter // nil when rate limiting is disabled
+	APIKeyAuth          *grpcHandler.APIKeyAuth
+	GRPCWeb             http.Handler // Connect, gRPC-Web and gRPC over HTTP/1.1 and h2c
+	SinglePort          http.Handler // REST, Connect, gRPC-Web and native gRPC together, for server.mode single
+	GRPCHandler         *grpcHandler.GRPCHandler
+	HealthServer        *health.Server
+	HealthHandler       *handler.HealthHandler
+	Repositories        *repository.Repositories
+	WebhookUseCase      *usecase.WebhookUseCase
+	OutboxUseCase       *usecase.OutboxUseCase
+	NotificationUseCase *usecase.NotificationUseCase
+	AlertUseCase        *usecase.AlertUseCase
+	EventBus            *eventbus.Bus // Wakes Watch streams; an outbox sink
 }
 
 func Bootstrap(config *BootstrapConfig) {
 
 	repositories := repository.InitRepositories(config.DB)
 	usecases := usecase.InitUsecases(repositories)
-	handlers := handler.InitHandlers(usecases)
-	grpcHandlers := grpcHandler.InitGRPCHandler(repositories, usecases)
+	pagination := config.Config.Pagination
+	auth := config.Config.Auth
+	handlers := handler.InitHandlers(usecases, handler.Pagination{DefaultLimit: pagination.DefaultPageSize, MaxLimit: pagination.MaxPageSize}, handler.Auth{AdminToken: auth.AdminToken, RequireAPIKey: auth.RequireAPIKey})
+	handlers.HealthHandler = handler.NewHealthHandler(config.ReadinessChecks)
+	bus := eventbus.New()
+	grpcHandlers := grpcHandler.InitGRPCHandler(repositories, usecases, bus, pagination.DefaultPageSize, pagination.MaxPageSize)
+	config.APIKeyAuth.APIKeys = usecases.APIKeyUseCase
 
 	pb.RegisterProductServiceServer(config.GRPCServer, grpcHandlers.ProductGRPCHandler)
 	pbWarehouse.RegisterWarehouseServiceServer(config.GRPCServer, grpcHandlers.WarehouseGRPCHandler)
 	pbMovement.RegisterMovementServiceServer(config.GRPCServer, grpcHandlers.MovementGRPCHandler)
+	pbAlert.RegisterAlertServiceServer(config.GRPCServer, grpcHandlers.AlertGRPCHandler)
+
+	// Standard grpc.health.v1 service and server reflection for grpcurl and other tooling
+	config.HealthServer = health.NewServer()
+	healthpb.RegisterHealthServer(config.GRPCServer, config.HealthServer)
+	reflection.Register(config.GRPCServer)
+
+	connectMux := http.NewServeMux()
+	// Rate limits follow authentication so calls are charged to the key that made them
+	interceptors := []connect.Interceptor{grpcHandler.ConnectMetricsInterceptor(), config.APIKeyAuth.ConnectInterceptor()}
+	restMiddlewares := []mux.MiddlewareFunc{ratelimit.BodyLimitMiddleware(int64(config.Config.Server.MaxBodyBytes), "/api/imports/", handler.MaxImportFileSize)}
+	if config.RateLimiter != nil {
+		trustForwardedFor := config.Config.RateLimit.TrustForwardedFor
+		interceptors = append(interceptors, grpcHandler.ConnectRateLimitInterceptor(config.RateLimiter, trustForwardedFor))
+		restMiddlewares = append([]mux.MiddlewareFunc{ratelimit.HTTPMiddleware(config.RateLimiter, trustForwardedFor)}, restMiddlewares...)
+	}
+	interceptors = append(interceptors, grpcHandler.ConnectErrorInterceptor())
+	connectOptions := connect.WithHandlerOptions(
+		connect.WithInterceptors(interceptors...),
+		connect.WithReadMaxBytes(config.Config.Server.MaxBodyBytes),
+	)
+	connectMux.Handle(productconnect.NewProductServiceHandler(&grpcHandler.ProductConnectHandler{ProductGRPCHandler: grpcHandlers.ProductGRPCHandler}, connectOptions))
+	connectMux.Handle(warehouseconnect.NewWarehouseServiceHandler(&grpcHandler.WarehouseConnectHandler{WarehouseGRPCHandler: grpcHandlers.WarehouseGRPCHandler}, connectOptions))
+	connectMux.Handle(movementconnect.NewMovementServiceHandler(&grpcHandler.MovementConnectHandler{MovementGRPCHandler: grpcHandlers.MovementGRPCHandler}, connectOptions))
+	connectMux.Handle(alertconnect.NewAlertServiceHandler(&grpcHandler.AlertConnectHandler{AlertGRPCHandler: grpcHandlers.AlertGRPCHandler}, connectOptions))
+	// Spans start outermost so request logs can carry their trace ID
+	config.GRPCWeb = tracing.HTTPMiddleware(logging.HTTPMiddleware(config.CORSConfig.SetupConnectCORS(connectMux)))
+	connectPaths := []string{
+		"/" + productconnect.ProductServiceName + "/",
+		"/" + warehouseconnect.WarehouseServiceName + "/",
+		"/" + movementconnect.MovementServiceName + "/",
+		"/" + alertconnect.AlertServiceName + "/",
+	}
+
+	spec, err := openapi.NewSpec()
+	if err != nil {
+		slog.Error("Error loading OpenAPI spec", "error", err)
+		os.Exit(1)
+	}
 
 	routeConfig := route.RouteConfig{
-		Router:   config.Mux,
-		Handlers: handlers,
+		Router:      config.Mux,
+		Handlers:    handlers,
+		OpenAPI:     spec,
+		Middlewares: restMiddlewares,
 	}
 
 	routeConfig.Setup(config.Mux)
-	config.Handler = config.CORSConfig.SetupCORS(routeConfig.Router)
+	config.Handler = tracing.HTTPMiddleware(logging.HTTPMiddleware(config.CORSConfig.SetupCORS(routeConfig.Router)))
+	config.SinglePort = NewSinglePortHandler(config.Handler, config.GRPCWeb, config.GRPCServer, connectPaths)
 	config.GRPCHandler = grpcHandlers
+	config.HealthHandler = handlers.HealthHandler
+	config.Repositories = repositories
+	config.WebhookUseCase = usecases.WebhookUseCase
+	config.WebhookUseCase.AllowPrivateURLs = config.Config.Webhooks.AllowPrivateURLs
+	config.OutboxUseCase = usecases.OutboxUseCase
+	config.NotificationUseCase = usecases.NotificationUseCase
+	config.AlertUseCase = usecases.AlertUseCase
+	config.EventBus = bus
 
 }
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadLayersFileAndEnvironment(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  http_addr: ":9000"
+database:
+  max_open_conns: 40
+  statement_timeout: 5s
+`)
+	t.Setenv("DB_MAX_OPEN_CONNS", "50")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.HTTPAddr != ":9000" {
+		t.Errorf("http_addr from the file was not applied: %q", cfg.Server.HTTPAddr)
+	}
+	if cfg.Database.StatementTimeout != 5*time.Second {
+		t.Errorf("statement_timeout from the file was not applied: %v", cfg.Database.StatementTimeout)
+	}
+	if cfg.Database.MaxOpenConns != 50 {
+		t.Errorf("DB_MAX_OPEN_CONNS did not override the file: %d", cfg.Database.MaxOpenConns)
+	}
+	if cfg.Server.ConnectAddr != ":50051" {
+		t.Errorf("connect_addr lost its default: %q", cfg.Server.ConnectAddr)
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[database]
+max_open_conns = 5
+max_idle_conns = 9
+
+[log]
+level = "loud"
+`)
+	t.Setenv("GRPC_KEEPALIVE_TIME", "soon")
+
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"GRPC_KEEPALIVE_TIME", "database.max_idle_conns", "log.level"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	path := writeFile(t, "config.yaml", "database:\n  max_open_connections: 5\n")
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "max_open_connections") {
+		t.Fatalf("expected an unknown key error, got %v", err)
+	}
+}
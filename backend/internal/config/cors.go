@@ -4,9 +4,8 @@ import (
 	"net/http"
 	"strings"
 
+	connectcors "connectrpc.com/cors"
 	"github.com/gorilla/handlers"
-	"github.com/improbable-eng/grpc-web/go/grpcweb"
-	"google.golang.org/grpc"
 )
 
 type CORSConfig struct {
@@ -16,28 +15,13 @@ type CORSConfig struct {
 }
 
 func NewCORSConfig(cfg *Config) *CORSConfig {
-	var allowedOrigins []string
-	if cfg.CORSAllowedOrigins == "*" {
-		allowedOrigins = []string{"*"}
-	} else {
-		origins := strings.Split(cfg.CORSAllowedOrigins, ",")
-		allowedOrigins = make([]string, 0, len(origins))
-		for _, origin := range origins {
-			trimmed := strings.TrimSpace(origin)
-			if trimmed != "" {
-				allowedOrigins = append(allowedOrigins, trimmed)
-			}
-		}
-		if len(allowedOrigins) == 0 {
-			allowedOrigins = []string{"*"}
-		}
-	}
+	allowedOrigins := cfg.Server.CORSAllowedOrigins
 
 	// Use default allowed methods
 	allowedMethods := []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
 
-	// Use default allowed headers
-	allowedHeaders := []string{"Content-Type", "Authorization"}
+	// Use default allowed headers; traceparent and tracestate let browsers continue a trace
+	allowedHeaders := []string{"Content-Type", "Authorization", "X-Request-ID", "traceparent", "tracestate"}
 
 	return &CORSConfig{
 		AllowedOrigins: allowedOrigins,
@@ -51,53 +35,33 @@ func (c *CORSConfig) SetupCORS(handler http.Handler) http.Handler {
 		handlers.AllowedOrigins(c.AllowedOrigins),
 		handlers.AllowedMethods(c.AllowedMethods),
 		handlers.AllowedHeaders(c.AllowedHeaders),
+		handlers.ExposedHeaders([]string{"X-Request-ID"}),
 	)(handler)
 }
 
-// SetupGRPCCORS configures CORS for gRPC-Web server
-func (c *CORSConfig) SetupGRPCCORS(grpcServer *grpc.Server) *grpcweb.WrappedGrpcServer {
-	// Check if all origins are allowed
-	allowAllOrigins := false
-	for _, origin := range c.AllowedOrigins {
-		if origin == "*" {
-			allowAllOrigins = true
-			break
-		}
-	}
-
-	// Create origin function
-	originFunc := func(origin string) bool {
-		if allowAllOrigins {
+// AllowsOrigin reports whether origin is on the allow-list; entries like *.example.com match subdomains
+func (c *CORSConfig) AllowsOrigin(origin string) bool {
+	for _, allowedOrigin := range c.AllowedOrigins {
+		if allowedOrigin == "*" || origin == allowedOrigin {
 			return true
 		}
-		for _, allowedOrigin := range c.AllowedOrigins {
-			if origin == allowedOrigin {
+		// Support wildcard subdomain matching (e.g., *.example.com)
+		if strings.HasPrefix(allowedOrigin, "*.") {
+			domain := strings.TrimPrefix(allowedOrigin, "*.")
+			if strings.HasSuffix(origin, domain) {
 				return true
 			}
-			// Support wildcard subdomain matching (e.g., *.example.com)
-			if strings.HasPrefix(allowedOrigin, "*.") {
-				domain := strings.TrimPrefix(allowedOrigin, "*.")
-				if strings.HasSuffix(origin, domain) {
-					return true
-				}
-			}
-		}
-		return false
-	}
-
-	// Create websocket origin function
-	websocketOriginFunc := func(req *http.Request) bool {
-		origin := req.Header.Get("Origin")
-		if origin == "" {
-			return true // Allow requests without Origin header
 		}
-		return originFunc(origin)
 	}
+	return false
+}
 
-	return grpcweb.WrapServer(
-		grpcServer,
-		grpcweb.WithOriginFunc(originFunc),
-		grpcweb.WithWebsockets(true),
-		grpcweb.WithWebsocketOriginFunc(websocketOriginFunc),
-	)
+// SetupConnectCORS configures CORS for the Connect, gRPC-Web and gRPC handlers
+func (c *CORSConfig) SetupConnectCORS(handler http.Handler) http.Handler {
+	return handlers.CORS(
+		handlers.AllowedOriginValidator(c.AllowsOrigin),
+		handlers.AllowedMethods(connectcors.AllowedMethods()),
+		handlers.AllowedHeaders(append(connectcors.AllowedHeaders(), c.AllowedHeaders...)),
+		handlers.ExposedHeaders(append(connectcors.ExposedHeaders(), "X-Request-ID")),
+	)(handler)
 }
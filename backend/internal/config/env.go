@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envOverrides copies set environment variables over config fields, collecting an error for
+// every value that does not parse instead of falling back to the default
+type envOverrides struct {
+	errs []error
+}
+
+func (e *envOverrides) lookup(key string) (string, bool) {
+	val, ok := os.LookupEnv(key)
+	return val, ok && val != ""
+}
+
+func (e *envOverrides) fail(key string, val string, want string) {
+	e.errs = append(e.errs, fmt.Errorf("%s: %q is not %s", key, val, want))
+}
+
+func (e *envOverrides) string(key string, dst *string) {
+	if val, ok := e.lookup(key); ok {
+		*dst = val
+	}
+}
+
+// list reads a comma-separated list, dropping empty entries
+func (e *envOverrides) list(key string, dst *[]string) {
+	val, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(val, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	*dst = items
+}
+
+func (e *envOverrides) int(key string, dst *int) {
+	val, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		e.fail(key, val, "an integer")
+		return
+	}
+	*dst = n
+}
+
+func (e *envOverrides) uint32(key string, dst *uint32) {
+	val, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.ParseUint(val, 10, 32)
+	if err != nil {
+		e.fail(key, val, "a non-negative integer")
+		return
+	}
+	*dst = uint32(n)
+}
+
+func (e *envOverrides) float64(key string, dst *float64) {
+	val, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	f, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		e.fail(key, val, "a number")
+		return
+	}
+	*dst = f
+}
+
+func (e *envOverrides) bool(key string, dst *bool) {
+	val, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		e.fail(key, val, "a boolean")
+		return
+	}
+	*dst = b
+}
+
+// duration reads a Go duration such as "30s" or "5m"
+func (e *envOverrides) duration(key string, dst *time.Duration) {
+	val, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		e.fail(key, val, "a duration such as 30s or 5m")
+		return
+	}
+	*dst = d
+}
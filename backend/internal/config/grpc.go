@@ -1,9 +1,100 @@
 package config
 
-import "google.golang.org/grpc"
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"time"
 
-func NewGRPCServer() *grpc.Server {
-	return grpc.NewServer(
-		grpc.MaxConcurrentStreams(500),
-	)
+	grpcHandler "github.com/shirloin/stockhub/internal/delivery/grpc/handler"
+	"github.com/shirloin/stockhub/internal/ratelimit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"gorm.io/gorm"
+)
+
+// NewGRPCServer builds the native gRPC server, serving TLS with tlsConfig unless it is nil,
+// rate limiting calls with limiter unless it is nil and authenticating them with auth. In
+// single-port mode it is served through the HTTP server, which terminates TLS itself.
+func NewGRPCServer(cfg *Config, tlsConfig *tls.Config, limiter *ratelimit.Limiter, auth *grpcHandler.APIKeyAuth) *grpc.Server {
+	unary := []grpc.UnaryServerInterceptor{grpcHandler.RequestIDUnaryInterceptor()}
+	stream := []grpc.StreamServerInterceptor{grpcHandler.RequestIDStreamInterceptor()}
+	unary = append(unary, auth.UnaryInterceptor())
+	stream = append(stream, grpcHandler.StreamMetricsInterceptor(), auth.StreamInterceptor())
+	if limiter != nil {
+		// After authentication so calls are charged to the key that made them
+		unary = append(unary, grpcHandler.RateLimitUnaryInterceptor(limiter, cfg.RateLimit.TrustForwardedFor))
+		stream = append(stream, grpcHandler.RateLimitStreamInterceptor(limiter, cfg.RateLimit.TrustForwardedFor))
+	}
+	unary = append(unary, grpcHandler.UnaryErrorInterceptor())
+	stream = append(stream, grpcHandler.StreamErrorInterceptor())
+
+	options := []grpc.ServerOption{
+		grpc.MaxConcurrentStreams(cfg.GRPC.MaxConcurrentStreams),
+		grpc.MaxRecvMsgSize(cfg.Server.MaxBodyBytes),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:              cfg.GRPC.KeepaliveTime,
+			Timeout:           cfg.GRPC.KeepaliveTimeout,
+			MaxConnectionIdle: cfg.GRPC.MaxConnectionIdle,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime: cfg.GRPC.KeepaliveMinTime,
+			// Watch streams can sit idle between updates; let clients keep them alive
+			PermitWithoutStream: true,
+		}),
+		// Continue traces from incoming metadata and record a span per call
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Tag and log every call with a request ID; handlers return domain errors, translated
+		// into status codes with details
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	return grpc.NewServer(options...)
+}
+
+// WatchDatabaseHealth reports every service as SERVING while the database answers pings and
+// NOT_SERVING otherwise, checking once per interval until ctx is cancelled
+func WatchDatabaseHealth(ctx context.Context, db *gorm.DB, healthServer *health.Server, services []string, interval time.Duration) {
+	setStatus := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		healthServer.SetServingStatus("", status)
+		for _, service := range services {
+			healthServer.SetServingStatus(service, status)
+		}
+	}
+
+	check := func() {
+		pingCtx, cancel := context.WithTimeout(ctx, interval)
+		defer cancel()
+
+		sqlDB, err := db.DB()
+		if err == nil {
+			err = sqlDB.PingContext(pingCtx)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Database health check failed", "error", err)
+			setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+			return
+		}
+		setStatus(healthpb.HealthCheckResponse_SERVING)
+	}
+
+	check()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			healthServer.Shutdown()
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
 }
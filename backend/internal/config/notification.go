@@ -0,0 +1,57 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/notification"
+	"github.com/shirloin/stockhub/internal/usecase"
+)
+
+// NotificationSinks returns the outbox sink queueing notification emails, or none when no SMTP
+// server is set
+func NotificationSinks(cfg NotificationsConfig, notifications *usecase.NotificationUseCase) []domain.EventSink {
+	if !cfg.Configured() {
+		return nil
+	}
+	return []domain.EventSink{notifications.Sink(notificationPolicy(cfg))}
+}
+
+// RunNotificationDispatcher emails due notifications until ctx is cancelled, or returns at once
+// when no SMTP server is set or sending is disabled on this replica. Notifications are queued
+// either way.
+func RunNotificationDispatcher(ctx context.Context, cfg NotificationsConfig, notifications *usecase.NotificationUseCase) {
+	if !cfg.Configured() || !cfg.Enabled {
+		return
+	}
+	mailer, err := notification.NewMailer(notification.Config{
+		Host:         cfg.SMTP.Host,
+		Port:         cfg.SMTP.Port,
+		Username:     cfg.SMTP.Username,
+		Password:     cfg.SMTP.Password,
+		TLS:          cfg.SMTP.TLS,
+		From:         cfg.From,
+		DashboardURL: cfg.DashboardURL,
+		Timeout:      cfg.SMTP.Timeout,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Notification mailer", "error", err)
+		return
+	}
+	notifications.Run(ctx, mailer, notificationPolicy(cfg))
+}
+
+func notificationPolicy(cfg NotificationsConfig) usecase.NotificationPolicy {
+	return usecase.NotificationPolicy{
+		DedupWindow:     cfg.DedupWindow,
+		CapacityWarning: cfg.CapacityWarning,
+		DailyHour:       cfg.DailyHour,
+		PollInterval:    cfg.PollInterval,
+		BatchSize:       cfg.BatchSize,
+		Timeout:         cfg.SMTP.Timeout,
+		MaxAttempts:     cfg.MaxAttempts,
+		Backoff:         cfg.Backoff,
+		MaxBackoff:      cfg.MaxBackoff,
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/eventbus"
+	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/internal/usecase"
+)
+
+// RunOutboxDispatcher delivers outbox events to sinks, plus the NOTIFY channel when one is set,
+// until ctx is cancelled, or returns at once when dispatching is disabled on this replica
+func RunOutboxDispatcher(ctx context.Context, cfg OutboxConfig, outbox *usecase.OutboxUseCase, sinks ...domain.EventSink) {
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.NotifyChannel != "" {
+		sinks = append(sinks, outbox.NotifySink(cfg.NotifyChannel))
+	}
+	outbox.Run(ctx, sinks, usecase.OutboxPolicy{
+		PollInterval: cfg.PollInterval,
+		BatchSize:    cfg.BatchSize,
+		Backoff:      cfg.Backoff,
+		MaxBackoff:   cfg.MaxBackoff,
+		Retention:    cfg.Retention,
+	})
+}
+
+// WatchForChanges wakes Watch streams on bus when the tables behind them change through any
+// replica, polling every organisation's records every interval until ctx is cancelled
+func WatchForChanges(ctx context.Context, interval time.Duration, repositories *repository.Repositories, bus *eventbus.Bus) {
+	ctx = domain.WithAllOrganizations(ctx)
+	go eventbus.Poll(ctx, bus, interval, repositories.ProductRepository.FindUpdatedSince, domain.EventProductUpdated)
+	go eventbus.Poll(ctx, bus, interval, repositories.WarehouseRepository.FindUpdatedSince, domain.EventWarehouseUpdated)
+	go eventbus.Poll(ctx, bus, interval, repositories.StockMovementRepository.FindUpdatedSince, domain.EventMovementCreated)
+	go eventbus.Poll(ctx, bus, interval, repositories.AlertRepository.FindTransitionsSince, domain.EventAlertChanged)
+}
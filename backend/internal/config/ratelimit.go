@@ -0,0 +1,21 @@
+package config
+
+import (
+	"github.com/shirloin/stockhub/internal/ratelimit"
+)
+
+// NewRateLimiter builds the limiter shared by the REST, Connect and gRPC servers, or returns
+// nil when rate limiting is disabled
+func NewRateLimiter(cfg RateLimitConfig) *ratelimit.Limiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	budget := func(b BudgetConfig) ratelimit.Budget {
+		return ratelimit.Budget{Rate: b.Rate, Burst: b.Burst}
+	}
+	return ratelimit.New(map[ratelimit.Class]ratelimit.Budget{
+		ratelimit.ClassRead:   budget(cfg.Reads),
+		ratelimit.ClassWrite:  budget(cfg.Writes),
+		ratelimit.ClassStream: budget(cfg.Streams),
+	}, cfg.MaxStreamsPerClient)
+}
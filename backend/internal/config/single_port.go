@@ -0,0 +1,31 @@
+package config
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NewSinglePortHandler routes every protocol arriving on one port: native gRPC by its
+// application/grpc content type, Connect and gRPC-Web by their service paths (so CORS
+// preflights, which carry no content type, reach the Connect CORS handler too), and
+// everything else to the REST API
+func NewSinglePortHandler(rest http.Handler, connect http.Handler, grpc http.Handler, connectPaths []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && isNativeGRPC(r.Header.Get("Content-Type")) {
+			grpc.ServeHTTP(w, r)
+			return
+		}
+		for _, path := range connectPaths {
+			if strings.HasPrefix(r.URL.Path, path) {
+				connect.ServeHTTP(w, r)
+				return
+			}
+		}
+		rest.ServeHTTP(w, r)
+	})
+}
+
+// isNativeGRPC matches application/grpc and application/grpc+proto, but not application/grpc-web
+func isNativeGRPC(contentType string) bool {
+	return contentType == "application/grpc" || strings.HasPrefix(contentType, "application/grpc+")
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSinglePortHandlerRoutesByProtocol(t *testing.T) {
+	named := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Handler", name)
+		})
+	}
+	handler := NewSinglePortHandler(named("rest"), named("connect"), named("grpc"), []string{"/product.ProductService/"})
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		http2       bool
+		want        string
+	}{
+		{"native gRPC", "POST", "/product.ProductService/GetProduct", "application/grpc", true, "grpc"},
+		{"native gRPC with codec", "POST", "/grpc.health.v1.Health/Check", "application/grpc+proto", true, "grpc"},
+		{"gRPC-Web", "POST", "/product.ProductService/GetProduct", "application/grpc-web+proto", false, "connect"},
+		{"Connect unary", "POST", "/product.ProductService/GetProduct", "application/json", false, "connect"},
+		{"Connect CORS preflight", "OPTIONS", "/product.ProductService/GetProduct", "", false, "connect"},
+		{"gRPC content type over HTTP/1.1", "POST", "/product.ProductService/GetProduct", "application/grpc", false, "connect"},
+		{"REST", "GET", "/api/products", "", false, "rest"},
+		{"REST JSON", "POST", "/api/stock-in", "application/json", true, "rest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.contentType != "" {
+				request.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.http2 {
+				request.ProtoMajor, request.ProtoMinor = 2, 0
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			if got := recorder.Header().Get("X-Handler"); got != tt.want {
+				t.Errorf("routed to %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertificateReloader serves the certificate in a cert/key file pair and picks up renewals,
+// such as those written by cert-manager or certbot, without a restart
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	modTime  time.Time // Newest modification time of the two files when cert was loaded
+}
+
+func NewCertificateReloader(certFile string, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload loads the pair again if either file changed since the last load and reports whether it did
+func (r *CertificateReloader) reload() (bool, error) {
+	modTime, err := r.newestModTime()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	unchanged := r.cert != nil && modTime.Equal(r.modTime)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+	r.mu.Lock()
+	r.cert, r.modTime = &cert, modTime
+	r.mu.Unlock()
+	return true, nil
+}
+
+func (r *CertificateReloader) newestModTime() (time.Time, error) {
+	var newest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest, nil
+}
+
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Watch checks the files every interval until ctx is cancelled. A pair that fails to load, for
+// example while only one of the files has been replaced, is logged and the current certificate
+// stays in use until the next check.
+func (r *CertificateReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				slog.Warn("Reloading TLS certificate failed, keeping the current one", "cert_file", r.certFile, "error", err)
+			} else if reloaded {
+				slog.Info("Reloaded TLS certificate", "cert_file", r.certFile)
+			}
+		}
+	}
+}
+
+// TLSConfig returns a server configuration presenting the current certificate on every handshake
+func (r *CertificateReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
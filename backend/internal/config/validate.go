@@ -0,0 +1,175 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/logging"
+	"github.com/shirloin/stockhub/internal/notification"
+	"github.com/shirloin/stockhub/internal/usecase"
+)
+
+// Validate reports every invalid setting, naming it by its key in the config file
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key string, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+	address := func(key string, addr string) {
+		_, port, err := net.SplitHostPort(addr)
+		check(err == nil && port != "", key, "%q is not an address such as :7788 or 0.0.0.0:7788", addr)
+	}
+	nonNegative := func(key string, d time.Duration) {
+		check(d >= 0, key, "must not be negative")
+	}
+
+	check(c.Server.Mode == ServerModeSplit || c.Server.Mode == ServerModeSingle, "server.mode", "%q is not split or single", c.Server.Mode)
+	address("server.http_addr", c.Server.HTTPAddr)
+	if c.Server.Mode != ServerModeSingle {
+		address("server.connect_addr", c.Server.ConnectAddr)
+		address("server.grpc_addr", c.Server.GRPCAddr)
+		check(c.Server.HTTPAddr != c.Server.ConnectAddr && c.Server.HTTPAddr != c.Server.GRPCAddr && c.Server.ConnectAddr != c.Server.GRPCAddr,
+			"server", "http_addr, connect_addr and grpc_addr must differ")
+	}
+	check(len(c.Server.CORSAllowedOrigins) > 0, "server.cors_allowed_origins", "must list at least one origin, or *")
+	check(c.Server.DrainDelay >= 0, "server.drain_delay", "must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout", "must be positive")
+	check(c.Server.MaxBodyBytes > 0, "server.max_body_bytes", "must be positive")
+
+	check(c.Database.URL != "", "database.url", "is required")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns", "must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns", "must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns", "must not exceed max_open_conns (%d)", c.Database.MaxOpenConns)
+	nonNegative("database.conn_max_lifetime", c.Database.ConnMaxLifetime)
+	nonNegative("database.conn_max_idle_time", c.Database.ConnMaxIdleTime)
+	nonNegative("database.statement_timeout", c.Database.StatementTimeout)
+	nonNegative("database.slow_query_threshold", c.Database.SlowQueryThreshold)
+
+	check(c.GRPC.MaxConcurrentStreams > 0, "grpc.max_concurrent_streams", "must be positive")
+	check(c.GRPC.KeepaliveTime > 0, "grpc.keepalive_time", "must be positive")
+	check(c.GRPC.KeepaliveTimeout > 0, "grpc.keepalive_timeout", "must be positive")
+	nonNegative("grpc.keepalive_min_time", c.GRPC.KeepaliveMinTime)
+	nonNegative("grpc.max_connection_idle", c.GRPC.MaxConnectionIdle)
+	check(c.GRPC.HealthCheckInterval > 0, "grpc.health_check_interval", "must be positive")
+
+	check(c.Streams.PollInterval > 0, "streams.poll_interval", "must be positive")
+	check(c.Pagination.DefaultPageSize > 0, "pagination.default_page_size", "must be positive")
+	check(c.Pagination.MaxPageSize >= c.Pagination.DefaultPageSize,
+		"pagination.max_page_size", "must be at least default_page_size (%d)", c.Pagination.DefaultPageSize)
+
+	_, err := logging.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level", "%q is not debug, info, warn or error", c.Log.Level)
+	format := strings.ToLower(c.Log.Format)
+	check(format == "json" || format == "text", "log.format", "%q is not json or text", c.Log.Format)
+
+	exporter := strings.ToLower(c.Tracing.Exporter)
+	check(exporter == "otlp" || exporter == "none", "tracing.exporter", "%q is not otlp or none", c.Tracing.Exporter)
+	check(c.Tracing.ServiceName != "", "tracing.service_name", "is required")
+
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls", "cert_file and key_file must be set together")
+	nonNegative("tls.reload_interval", c.TLS.ReloadInterval)
+	for _, file := range []struct{ key, path string }{{"tls.cert_file", c.TLS.CertFile}, {"tls.key_file", c.TLS.KeyFile}} {
+		if file.path != "" {
+			_, err := os.Stat(file.path)
+			check(err == nil, file.key, "%v", err)
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		for _, budget := range []struct {
+			key string
+			BudgetConfig
+		}{{"rate_limit.reads", c.RateLimit.Reads}, {"rate_limit.writes", c.RateLimit.Writes}, {"rate_limit.streams", c.RateLimit.Streams}} {
+			check(budget.Rate > 0, budget.key+".rate", "must be positive")
+			check(budget.Burst > 0, budget.key+".burst", "must be positive")
+		}
+		check(c.RateLimit.MaxStreamsPerClient >= 0, "rate_limit.max_streams_per_client", "must not be negative")
+		check(c.RateLimit.ClientIdleTimeout > 0, "rate_limit.client_idle_timeout", "must be positive")
+	}
+
+	if c.Auth.AdminToken != "" {
+		check(len(c.Auth.AdminToken) >= 16, "auth.admin_token", "must be at least 16 characters")
+		check(!usecase.IsAPIKey(c.Auth.AdminToken), "auth.admin_token", "must not look like an API key")
+	}
+
+	if c.Webhooks.Enabled {
+		check(c.Webhooks.PollInterval > 0, "webhooks.poll_interval", "must be positive")
+		check(c.Webhooks.BatchSize > 0, "webhooks.batch_size", "must be positive")
+		check(c.Webhooks.Timeout > 0, "webhooks.timeout", "must be positive")
+		check(c.Webhooks.MaxAttempts > 0, "webhooks.max_attempts", "must be positive")
+		check(c.Webhooks.Backoff > 0, "webhooks.backoff", "must be positive")
+		check(c.Webhooks.MaxBackoff >= c.Webhooks.Backoff, "webhooks.max_backoff", "must not be less than webhooks.backoff")
+		check(c.Webhooks.DisableAfter >= 0, "webhooks.disable_after", "must not be negative")
+	}
+
+	if c.Outbox.Enabled {
+		check(c.Outbox.PollInterval > 0, "outbox.poll_interval", "must be positive")
+		check(c.Outbox.BatchSize > 0, "outbox.batch_size", "must be positive")
+		check(c.Outbox.Backoff > 0, "outbox.backoff", "must be positive")
+		check(c.Outbox.MaxBackoff >= c.Outbox.Backoff, "outbox.max_backoff", "must not be less than outbox.backoff")
+		check(c.Outbox.Retention >= 0, "outbox.retention", "must not be negative")
+		// Postgres truncates longer identifiers, so listeners would wait on a different channel
+		check(len(c.Outbox.NotifyChannel) <= 63, "outbox.notify_channel", "must be at most 63 characters")
+	}
+
+	if c.Notifications.Configured() {
+		n := c.Notifications
+		check(n.SMTP.Port > 0 && n.SMTP.Port <= 65535, "notifications.smtp.port", "%d is not a port", n.SMTP.Port)
+		check(n.SMTP.TLS == notification.TLSStartTLS || n.SMTP.TLS == notification.TLSImplicit || n.SMTP.TLS == notification.TLSNone,
+			"notifications.smtp.tls", "%q is not starttls, tls or none", n.SMTP.TLS)
+		check(n.SMTP.Timeout > 0, "notifications.smtp.timeout", "must be positive")
+		_, err := mail.ParseAddress(n.From)
+		check(err == nil, "notifications.from", "%q is not an email address", n.From)
+		if n.DashboardURL != "" {
+			u, err := url.Parse(n.DashboardURL)
+			check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", "notifications.dashboard_url", "%q is not an http or https URL", n.DashboardURL)
+		}
+		nonNegative("notifications.dedup_window", n.DedupWindow)
+		check(n.CapacityWarning > 0 && n.CapacityWarning <= 100, "notifications.capacity_warning", "must be a percentage from 1 to 100")
+		check(n.DailyHour >= 0 && n.DailyHour <= 23, "notifications.daily_hour", "must be an hour from 0 to 23")
+		if n.Enabled {
+			check(n.PollInterval > 0, "notifications.poll_interval", "must be positive")
+			check(n.BatchSize > 0, "notifications.batch_size", "must be positive")
+			check(n.MaxAttempts > 0, "notifications.max_attempts", "must be positive")
+			check(n.Backoff > 0, "notifications.backoff", "must be positive")
+			check(n.MaxBackoff >= n.Backoff, "notifications.max_backoff", "must not be less than notifications.backoff")
+		}
+	}
+
+	if c.Alerts.Enabled {
+		check(c.Alerts.EvaluateInterval > 0, "alerts.evaluate_interval", "must be positive")
+	}
+
+	return errors.Join(errs...)
+}
+
+var dsnPassword = regexp.MustCompile(`(password=)(\S+)`)
+
+// Redacted returns a copy of the configuration safe to print, with the database and SMTP
+// passwords and the admin token masked
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	if c.Auth.AdminToken != "" {
+		redacted.Auth.AdminToken = "xxxxx"
+	}
+	if c.Notifications.SMTP.Password != "" {
+		redacted.Notifications.SMTP.Password = "xxxxx"
+	}
+	if u, err := url.Parse(c.Database.URL); err == nil && u.User != nil {
+		redacted.Database.URL = u.Redacted()
+	} else {
+		// keyword/value form: host=... user=... password=...
+		redacted.Database.URL = dsnPassword.ReplaceAllString(c.Database.URL, "${1}xxxxx")
+	}
+	return &redacted
+}
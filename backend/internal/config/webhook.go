@@ -0,0 +1,25 @@
+package config
+
+import (
+	"context"
+
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/internal/webhook"
+)
+
+// RunWebhookDispatcher delivers queued webhook events until ctx is cancelled, or returns at once
+// when delivery is disabled on this replica. Events are queued either way.
+func RunWebhookDispatcher(ctx context.Context, cfg WebhooksConfig, webhooks *usecase.WebhookUseCase) {
+	if !cfg.Enabled {
+		return
+	}
+	webhooks.Run(ctx, webhook.NewSender(cfg.Timeout, cfg.AllowPrivateURLs), usecase.WebhookPolicy{
+		PollInterval: cfg.PollInterval,
+		BatchSize:    cfg.BatchSize,
+		Timeout:      cfg.Timeout,
+		MaxAttempts:  cfg.MaxAttempts,
+		Backoff:      cfg.Backoff,
+		MaxBackoff:   cfg.MaxBackoff,
+		DisableAfter: cfg.DisableAfter,
+	})
+}
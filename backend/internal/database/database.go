@@ -1,46 +1,41 @@
 package database
 
 import (
-	"log"
+	"strconv"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/shirloin/stockhub/internal/config"
-	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/logging"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-var Instance *gorm.DB
-var err error
-
-func GetInstance() (*gorm.DB, error) {
-	if Instance == nil {
-		config := config.Load()
-		url := config.DATABASE_URL
-		db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
-		if err != nil {
-			return nil, err
-		}
-		Instance = db
+// Open connects to Postgres with the pool limits of cfg. The statement timeout is set on
+// every connection, so Postgres itself cancels a runaway query.
+func Open(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, err
 	}
-	return Instance, err
-}
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
+	sqlDB := stdlib.OpenDB(*connConfig)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-func Migrate() {
-	_, err := GetInstance()
+	// TranslateError reports unique and foreign key violations as gorm.ErrDuplicatedKey and
+	// gorm.ErrForeignKeyViolated, which the repositories turn into domain conflicts
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		TranslateError: true,
+		Logger:         logging.NewGormLogger(cfg.SlowQueryThreshold),
+	})
 	if err != nil {
-		log.Fatalf("Error getting database instance: %v", err)
-		panic(err)
+		sqlDB.Close()
+		return nil, err
 	}
-	Instance.AutoMigrate(
-		&domain.Category{},
-		&domain.Supplier{},
-		&domain.Product{},
-		&domain.Warehouse{},
-		&domain.WarehouseStock{},
-		&domain.StockTransfer{},
-		&domain.StockMovement{},
-		&domain.StockIn{},
-		&domain.StockOut{},
-		&domain.StockAdjustment{},
-	)
+	return db, nil
 }
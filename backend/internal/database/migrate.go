@@ -0,0 +1,330 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// MigrationsDir is where migrate create writes new files, relative to the backend directory
+const MigrationsDir = "internal/database/migrations"
+
+// migrationLockKey is the pg_advisory_lock key held while migrating, so replicas
+// starting together apply each migration once
+const migrationLockKey int64 = 7_788_001
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a pair of embedded files NNNNNN_name.up.sql and NNNNNN_name.down.sql.
+// Checksum is the SHA-256 of the up file, recorded when it is applied.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type MigrationState string
+
+const (
+	MigrationPending  MigrationState = "pending"
+	MigrationApplied  MigrationState = "applied"
+	MigrationModified MigrationState = "modified" // applied, but the embedded file has changed since
+	MigrationMissing  MigrationState = "missing"  // applied, but not embedded in this binary
+)
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	State     MigrationState
+	AppliedAt *time.Time
+}
+
+// Migrator applies the embedded migrations and records them in schema_migrations
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+		if (match[3] == "up" && migration.Up != "") || (match[3] == "down" && migration.Down != "") {
+			return nil, fmt.Errorf("migration %d has two %s files", version, match[3])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock.
+// Other instances block until it is released, then find the migrations already applied.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Waiting for the lock and long migrations must not hit the pool's statement timeout;
+	// RESET restores it before the connection goes back to the pool
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "RESET statement_timeout")
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		checksum text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Conn
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn queryer) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]appliedMigration{}
+	for rows.Next() {
+		var version int64
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.name, &record.checksum, &record.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = record
+	}
+	return applied, rows.Err()
+}
+
+// Up applies every pending migration in order, each in its own transaction, and returns
+// how many ran. It refuses to run if an applied migration's file has changed.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if record, ok := applied[migration.Version]; ok {
+				if record.checksum != migration.Checksum {
+					return fmt.Errorf("migration %d_%s was modified after it was applied", migration.Version, migration.Name)
+				}
+				continue
+			}
+			if err := m.run(ctx, conn, migration.Up, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+					migration.Version, migration.Name, migration.Checksum)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns how many ran
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.run(ctx, conn, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// run executes a migration script and its bookkeeping in one transaction
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, script string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Verify reports an error unless every embedded migration is applied unchanged. Unlike
+// Status it does not take the migration lock, so it is cheap enough for readiness probes.
+func (m *Migrator) Verify(ctx context.Context) error {
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, migration := range m.migrations {
+		record, ok := applied[migration.Version]
+		if !ok {
+			pending++
+			continue
+		}
+		if record.checksum != migration.Checksum {
+			return fmt.Errorf("migration %d_%s was modified after it was applied", migration.Version, migration.Name)
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%d migrations pending", pending)
+	}
+	return nil
+}
+
+// Status lists every embedded migration plus any applied migration this binary does not know
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: MigrationPending}
+			if record, ok := applied[migration.Version]; ok {
+				status.State = MigrationApplied
+				if record.checksum != migration.Checksum {
+					status.State = MigrationModified
+				}
+				status.AppliedAt = &record.appliedAt
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+		for version, record := range applied {
+			statuses = append(statuses, MigrationStatus{Version: version, Name: record.name, State: MigrationMissing, AppliedAt: &record.appliedAt})
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+		return nil
+	})
+	return statuses, err
+}
+
+// CreateMigration writes an empty up/down pair to dir, numbered after the highest version
+// already there, and returns the paths of both files
+func CreateMigration(dir string, name string) (string, string, error) {
+	if !regexp.MustCompile(`^[a-z0-9_]+$`).MatchString(name) {
+		return "", "", fmt.Errorf("migration name %q must be lowercase letters, digits and underscores", name)
+	}
+	migrations, err := loadMigrations(os.DirFS(dir), ".")
+	if err != nil {
+		return "", "", err
+	}
+	version := int64(1)
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", version, name))
+	up, down := base+".up.sql", base+".down.sql"
+	if err := os.WriteFile(up, []byte("-- "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte("-- Revert "+name+"\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
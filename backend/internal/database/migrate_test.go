@@ -0,0 +1,152 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []int64 // Expected, in order, when wantErr is empty
+		wantErr  string
+	}{
+		{
+			name: "pairs up and down files and sorts by version",
+			files: fstest.MapFS{
+				"m/000002_orders.up.sql":   file("CREATE TABLE orders ();"),
+				"m/000002_orders.down.sql": file("DROP TABLE orders;"),
+				"m/000001_init.up.sql":     file("CREATE TABLE products ();"),
+				"m/000001_init.down.sql":   file("DROP TABLE products;"),
+			},
+			versions: []int64{1, 2},
+		},
+		{
+			name: "ignores files that are not migrations",
+			files: fstest.MapFS{
+				"m/000001_init.up.sql":   file("SELECT 1;"),
+				"m/000001_init.down.sql": file("SELECT 1;"),
+				"m/README.md":            file("notes"),
+				"m/000002_Init.up.sql":   file("SELECT 1;"),
+				"m/000003_init.sql":      file("SELECT 1;"),
+				"m/init.up.sql":          file("SELECT 1;"),
+			},
+			versions: []int64{1},
+		},
+		{
+			name: "allows gaps between versions",
+			files: fstest.MapFS{
+				"m/000001_init.up.sql":     file("SELECT 1;"),
+				"m/000001_init.down.sql":   file("SELECT 1;"),
+				"m/000005_later.up.sql":    file("SELECT 5;"),
+				"m/000005_later.down.sql":  file("SELECT 5;"),
+				"m/000010_latest.up.sql":   file("SELECT 10;"),
+				"m/000010_latest.down.sql": file("SELECT 10;"),
+			},
+			versions: []int64{1, 5, 10},
+		},
+		{
+			name: "rejects an up file without its down file",
+			files: fstest.MapFS{
+				"m/000001_init.up.sql": file("SELECT 1;"),
+			},
+			wantErr: "needs both an up and a down file",
+		},
+		{
+			name: "rejects a down file without its up file",
+			files: fstest.MapFS{
+				"m/000001_init.down.sql": file("SELECT 1;"),
+			},
+			wantErr: "needs both an up and a down file",
+		},
+		{
+			name: "rejects one version with two names",
+			files: fstest.MapFS{
+				"m/000001_init.up.sql":    file("SELECT 1;"),
+				"m/000001_other.down.sql": file("SELECT 1;"),
+			},
+			wantErr: "has two names",
+		},
+		{
+			name: "rejects one version numbered twice",
+			files: fstest.MapFS{
+				"m/000001_init.up.sql":   file("SELECT 1;"),
+				"m/000001_init.down.sql": file("SELECT 1;"),
+				"m/1_init.up.sql":        file("SELECT 2;"),
+			},
+			wantErr: "has two up files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := loadMigrations(tt.files, "m")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("loaded %d migrations, want %d", len(migrations), len(tt.versions))
+			}
+			for i, migration := range migrations {
+				if migration.Version != tt.versions[i] {
+					t.Errorf("migration %d has version %d, want %d", i, migration.Version, tt.versions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMigrationsReadsFilesAndChecksumsUp(t *testing.T) {
+	up := "CREATE TABLE products ();"
+	migrations, err := loadMigrations(fstest.MapFS{
+		"m/000001_init.up.sql":   {Data: []byte(up)},
+		"m/000001_init.down.sql": {Data: []byte("DROP TABLE products;")},
+	}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(up))
+	want := Migration{
+		Version:  1,
+		Name:     "init",
+		Up:       up,
+		Down:     "DROP TABLE products;",
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+	if len(migrations) != 1 || migrations[0] != want {
+		t.Fatalf("loaded %+v, want %+v", migrations, want)
+	}
+
+	// Editing an applied up file must show up as a changed checksum
+	edited, err := loadMigrations(fstest.MapFS{
+		"m/000001_init.up.sql":   {Data: []byte(up + " -- edited")},
+		"m/000001_init.down.sql": {Data: []byte("DROP TABLE products;")},
+	}, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited[0].Checksum == want.Checksum {
+		t.Error("editing the up file did not change the checksum")
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no migrations are embedded")
+	}
+}
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS stock_document_lines;
+DROP TABLE IF EXISTS stock_documents;
+DROP TABLE IF EXISTS stock_adjustments;
+DROP TABLE IF EXISTS stock_outs;
+DROP TABLE IF EXISTS stock_ins;
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS stock_transfers;
+DROP TABLE IF EXISTS warehouse_stocks;
+DROP TABLE IF EXISTS warehouses;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS suppliers;
+DROP TABLE IF EXISTS categories;
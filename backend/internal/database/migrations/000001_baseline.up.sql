@@ -0,0 +1,234 @@
+-- Baseline: the schema previously created by GORM AutoMigrate. Every statement is
+-- IF NOT EXISTS so databases created by AutoMigrate adopt it without changes.
+
+CREATE TABLE IF NOT EXISTS categories (
+    uuid uuid,
+    name varchar(100) NOT NULL,
+    description text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON categories (name);
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    uuid uuid,
+    name varchar(100) NOT NULL,
+    email varchar(100),
+    phone varchar(20),
+    address text,
+    contact_name varchar(100),
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_suppliers_email ON suppliers (email);
+
+CREATE TABLE IF NOT EXISTS products (
+    uuid uuid,
+    title varchar(100) NOT NULL,
+    description text,
+    price bigint NOT NULL,
+    stock bigint NOT NULL DEFAULT 0,
+    low_stock_threshold bigint NOT NULL DEFAULT 10,
+    sku varchar(50),
+    barcode varchar(100),
+    image_url text,
+    category_uuid uuid,
+    supplier_uuid uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_products_category FOREIGN KEY (category_uuid) REFERENCES categories (uuid),
+    CONSTRAINT fk_products_supplier FOREIGN KEY (supplier_uuid) REFERENCES suppliers (uuid)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products (sku);
+CREATE INDEX IF NOT EXISTS idx_products_barcode ON products (barcode);
+CREATE INDEX IF NOT EXISTS idx_products_category_uuid ON products (category_uuid);
+CREATE INDEX IF NOT EXISTS idx_products_supplier_uuid ON products (supplier_uuid);
+-- Full-text index for product search; the expression must match productSearchVector in the product repository
+CREATE INDEX IF NOT EXISTS idx_products_search ON products USING GIN (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, '') || ' ' || coalesce(sku, '') || ' ' || coalesce(barcode, '')));
+
+CREATE TABLE IF NOT EXISTS warehouses (
+    uuid uuid,
+    name varchar(100) NOT NULL,
+    address text,
+    city varchar(100),
+    state varchar(100),
+    country varchar(100),
+    postal_code varchar(20),
+    manager_name varchar(100),
+    manager_email varchar(100),
+    manager_phone varchar(20),
+    capacity bigint DEFAULT 0,
+    is_active boolean DEFAULT true,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+
+CREATE TABLE IF NOT EXISTS warehouse_stocks (
+    uuid uuid,
+    product_uuid uuid NOT NULL,
+    warehouse_uuid uuid NOT NULL,
+    quantity bigint NOT NULL DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_warehouse_stocks_product FOREIGN KEY (product_uuid) REFERENCES products (uuid),
+    CONSTRAINT fk_warehouse_stocks_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_warehouse_stocks_product_uuid ON warehouse_stocks (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_warehouse_stocks_warehouse_uuid ON warehouse_stocks (warehouse_uuid);
+
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    uuid uuid,
+    product_uuid uuid NOT NULL,
+    from_warehouse_uuid uuid NOT NULL,
+    to_warehouse_uuid uuid NOT NULL,
+    quantity bigint NOT NULL,
+    transfer_date timestamptz NOT NULL,
+    notes text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_product_uuid ON stock_transfers (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_from_warehouse_uuid ON stock_transfers (from_warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_to_warehouse_uuid ON stock_transfers (to_warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_transfer_date ON stock_transfers (transfer_date);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    uuid uuid,
+    product_uuid uuid NOT NULL,
+    warehouse_uuid uuid NOT NULL,
+    movement_type varchar(20) NOT NULL,
+    quantity bigint NOT NULL,
+    previous_qty bigint NOT NULL DEFAULT 0,
+    new_qty bigint NOT NULL DEFAULT 0,
+    reference_number varchar(100),
+    to_warehouse_uuid uuid,
+    adjustment_reason varchar(20),
+    document_uuid uuid,
+    notes text,
+    created_by varchar(100),
+    movement_date timestamptz NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_stock_movements_product FOREIGN KEY (product_uuid) REFERENCES products (uuid),
+    CONSTRAINT fk_stock_movements_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_uuid ON stock_movements (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_warehouse_uuid ON stock_movements (warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_movement_type ON stock_movements (movement_type);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_reference_number ON stock_movements (reference_number);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_to_warehouse_uuid ON stock_movements (to_warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_document_uuid ON stock_movements (document_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_movement_date ON stock_movements (movement_date);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_ledger ON stock_movements (movement_date, created_at, uuid);
+
+CREATE TABLE IF NOT EXISTS stock_ins (
+    uuid uuid,
+    product_uuid uuid NOT NULL,
+    warehouse_uuid uuid NOT NULL,
+    quantity bigint NOT NULL,
+    purchase_order_no varchar(100),
+    supplier_uuid uuid,
+    received_date timestamptz NOT NULL,
+    received_by varchar(100),
+    notes text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_stock_ins_product FOREIGN KEY (product_uuid) REFERENCES products (uuid),
+    CONSTRAINT fk_stock_ins_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid),
+    CONSTRAINT fk_stock_ins_supplier FOREIGN KEY (supplier_uuid) REFERENCES suppliers (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_ins_product_uuid ON stock_ins (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_ins_warehouse_uuid ON stock_ins (warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_ins_purchase_order_no ON stock_ins (purchase_order_no);
+CREATE INDEX IF NOT EXISTS idx_stock_ins_supplier_uuid ON stock_ins (supplier_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_ins_received_date ON stock_ins (received_date);
+
+CREATE TABLE IF NOT EXISTS stock_outs (
+    uuid uuid,
+    product_uuid uuid NOT NULL,
+    warehouse_uuid uuid NOT NULL,
+    quantity bigint NOT NULL,
+    sales_order_no varchar(100),
+    customer_name varchar(100),
+    shipped_date timestamptz NOT NULL,
+    shipped_by varchar(100),
+    notes text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_stock_outs_product FOREIGN KEY (product_uuid) REFERENCES products (uuid),
+    CONSTRAINT fk_stock_outs_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_outs_product_uuid ON stock_outs (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_outs_warehouse_uuid ON stock_outs (warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_outs_sales_order_no ON stock_outs (sales_order_no);
+CREATE INDEX IF NOT EXISTS idx_stock_outs_shipped_date ON stock_outs (shipped_date);
+
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+    uuid uuid,
+    product_uuid uuid NOT NULL,
+    warehouse_uuid uuid NOT NULL,
+    quantity bigint NOT NULL,
+    previous_qty bigint NOT NULL DEFAULT 0,
+    new_qty bigint NOT NULL DEFAULT 0,
+    reason varchar(20) NOT NULL,
+    adjusted_by varchar(100),
+    adjustment_date timestamptz NOT NULL,
+    notes text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_stock_adjustments_product FOREIGN KEY (product_uuid) REFERENCES products (uuid),
+    CONSTRAINT fk_stock_adjustments_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_adjustments_product_uuid ON stock_adjustments (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_adjustments_warehouse_uuid ON stock_adjustments (warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_adjustments_adjustment_date ON stock_adjustments (adjustment_date);
+
+CREATE TABLE IF NOT EXISTS stock_documents (
+    uuid uuid,
+    document_type varchar(20) NOT NULL,
+    reference_number varchar(100) NOT NULL,
+    warehouse_uuid uuid NOT NULL,
+    supplier_uuid uuid,
+    customer_name varchar(100),
+    document_date timestamptz NOT NULL,
+    created_by varchar(100),
+    notes text,
+    total_quantity bigint NOT NULL DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_stock_documents_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid),
+    CONSTRAINT fk_stock_documents_supplier FOREIGN KEY (supplier_uuid) REFERENCES suppliers (uuid)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_documents_reference ON stock_documents (document_type, reference_number);
+CREATE INDEX IF NOT EXISTS idx_stock_documents_warehouse_uuid ON stock_documents (warehouse_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_documents_supplier_uuid ON stock_documents (supplier_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_documents_document_date ON stock_documents (document_date);
+
+CREATE TABLE IF NOT EXISTS stock_document_lines (
+    uuid uuid,
+    document_uuid uuid NOT NULL,
+    line_number bigint NOT NULL,
+    product_uuid uuid NOT NULL,
+    quantity bigint NOT NULL,
+    movement_uuid uuid,
+    notes text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_stock_documents_lines FOREIGN KEY (document_uuid) REFERENCES stock_documents (uuid),
+    CONSTRAINT fk_stock_document_lines_product FOREIGN KEY (product_uuid) REFERENCES products (uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_stock_document_lines_document_uuid ON stock_document_lines (document_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_document_lines_product_uuid ON stock_document_lines (product_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_document_lines_movement_uuid ON stock_document_lines (movement_uuid);
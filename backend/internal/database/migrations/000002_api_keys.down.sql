@@ -0,0 +1,2 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS api_key_uuid;
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,22 @@
+-- API keys for machine-to-machine integrations. Only a SHA-256 hash of each key is stored;
+-- prefix is the public start of the key, used to look it up.
+CREATE TABLE api_keys (
+    uuid uuid,
+    name varchar(100) NOT NULL,
+    prefix varchar(32) NOT NULL,
+    key_hash varchar(64) NOT NULL,
+    scopes jsonb NOT NULL DEFAULT '[]',
+    warehouse_uuids jsonb NOT NULL DEFAULT '[]',
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys (prefix);
+
+-- Movements posted with an API key record which one
+ALTER TABLE stock_movements ADD COLUMN api_key_uuid uuid;
+ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_api_key FOREIGN KEY (api_key_uuid) REFERENCES api_keys (uuid);
+CREATE INDEX idx_stock_movements_api_key_uuid ON stock_movements (api_key_uuid);
@@ -0,0 +1,39 @@
+-- Webhook subscriptions. secret signs every delivery with HMAC-SHA256, so it is kept as is.
+CREATE TABLE webhooks (
+    uuid uuid,
+    url varchar(2048) NOT NULL,
+    description varchar(255),
+    secret varchar(255) NOT NULL,
+    event_types jsonb NOT NULL DEFAULT '[]',
+    enabled boolean NOT NULL DEFAULT true,
+    consecutive_failures bigint NOT NULL DEFAULT 0,
+    disabled_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+
+-- One row per event and webhook: the delivery queue, and the log of what each endpoint answered
+CREATE TABLE webhook_deliveries (
+    uuid uuid,
+    webhook_uuid uuid NOT NULL,
+    event_id uuid NOT NULL,
+    event_type varchar(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(20) NOT NULL,
+    attempts bigint NOT NULL DEFAULT 0,
+    response_status bigint,
+    response_body text,
+    error text,
+    next_attempt_at timestamptz,
+    delivered_at timestamptz,
+    redelivery_of uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY (webhook_uuid) REFERENCES webhooks (uuid) ON DELETE CASCADE
+);
+CREATE INDEX idx_webhook_deliveries_webhook_uuid ON webhook_deliveries (webhook_uuid, created_at);
+CREATE INDEX idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+-- The dispatcher only ever looks for pending deliveries that are due
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
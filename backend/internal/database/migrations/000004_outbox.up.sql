@@ -0,0 +1,24 @@
+-- Events written in the same transaction as the stock and catalog changes they describe,
+-- waiting to be dispatched to the in-process bus, webhooks and the NOTIFY channel
+CREATE TABLE outbox (
+    id bigserial,
+    event_id uuid NOT NULL,
+    event_type varchar(50) NOT NULL,
+    aggregate_type varchar(50) NOT NULL,
+    aggregate_id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamptz NOT NULL,
+    attempts bigint NOT NULL DEFAULT 0,
+    last_error text,
+    next_attempt_at timestamptz NOT NULL,
+    delivered_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+-- The dispatcher reads undelivered events in order and checks each aggregate for earlier ones
+CREATE INDEX idx_outbox_undelivered ON outbox (id) WHERE delivered_at IS NULL;
+CREATE INDEX idx_outbox_aggregate ON outbox (aggregate_type, aggregate_id, id) WHERE delivered_at IS NULL;
+CREATE INDEX idx_outbox_delivered_at ON outbox (delivered_at) WHERE delivered_at IS NOT NULL;
+
+-- An event dispatched again must not queue a second delivery to the same webhook
+CREATE UNIQUE INDEX idx_webhook_deliveries_webhook_event ON webhook_deliveries (webhook_uuid, event_id) WHERE redelivery_of IS NULL;
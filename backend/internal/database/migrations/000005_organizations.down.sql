@@ -0,0 +1,53 @@
+DROP INDEX IF EXISTS idx_stock_movements_ledger;
+CREATE INDEX IF NOT EXISTS idx_stock_movements_ledger ON stock_movements (movement_date, created_at, uuid);
+
+ALTER TABLE products DROP CONSTRAINT fk_products_category, ADD CONSTRAINT fk_products_category FOREIGN KEY (category_uuid) REFERENCES categories (uuid);
+ALTER TABLE products DROP CONSTRAINT fk_products_supplier, ADD CONSTRAINT fk_products_supplier FOREIGN KEY (supplier_uuid) REFERENCES suppliers (uuid);
+ALTER TABLE warehouse_stocks DROP CONSTRAINT fk_warehouse_stocks_product, ADD CONSTRAINT fk_warehouse_stocks_product FOREIGN KEY (product_uuid) REFERENCES products (uuid);
+ALTER TABLE warehouse_stocks DROP CONSTRAINT fk_warehouse_stocks_warehouse, ADD CONSTRAINT fk_warehouse_stocks_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid);
+ALTER TABLE stock_movements DROP CONSTRAINT fk_stock_movements_product, ADD CONSTRAINT fk_stock_movements_product FOREIGN KEY (product_uuid) REFERENCES products (uuid);
+ALTER TABLE stock_movements DROP CONSTRAINT fk_stock_movements_warehouse, ADD CONSTRAINT fk_stock_movements_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid);
+ALTER TABLE stock_movements DROP CONSTRAINT fk_stock_movements_api_key, ADD CONSTRAINT fk_stock_movements_api_key FOREIGN KEY (api_key_uuid) REFERENCES api_keys (uuid);
+ALTER TABLE stock_ins DROP CONSTRAINT fk_stock_ins_product, ADD CONSTRAINT fk_stock_ins_product FOREIGN KEY (product_uuid) REFERENCES products (uuid);
+ALTER TABLE stock_ins DROP CONSTRAINT fk_stock_ins_warehouse, ADD CONSTRAINT fk_stock_ins_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid);
+ALTER TABLE stock_ins DROP CONSTRAINT fk_stock_ins_supplier, ADD CONSTRAINT fk_stock_ins_supplier FOREIGN KEY (supplier_uuid) REFERENCES suppliers (uuid);
+ALTER TABLE stock_outs DROP CONSTRAINT fk_stock_outs_product, ADD CONSTRAINT fk_stock_outs_product FOREIGN KEY (product_uuid) REFERENCES products (uuid);
+ALTER TABLE stock_outs DROP CONSTRAINT fk_stock_outs_warehouse, ADD CONSTRAINT fk_stock_outs_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid);
+ALTER TABLE stock_adjustments DROP CONSTRAINT fk_stock_adjustments_product, ADD CONSTRAINT fk_stock_adjustments_product FOREIGN KEY (product_uuid) REFERENCES products (uuid);
+ALTER TABLE stock_adjustments DROP CONSTRAINT fk_stock_adjustments_warehouse, ADD CONSTRAINT fk_stock_adjustments_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid);
+ALTER TABLE stock_documents DROP CONSTRAINT fk_stock_documents_warehouse, ADD CONSTRAINT fk_stock_documents_warehouse FOREIGN KEY (warehouse_uuid) REFERENCES warehouses (uuid);
+ALTER TABLE stock_documents DROP CONSTRAINT fk_stock_documents_supplier, ADD CONSTRAINT fk_stock_documents_supplier FOREIGN KEY (supplier_uuid) REFERENCES suppliers (uuid);
+ALTER TABLE stock_document_lines DROP CONSTRAINT fk_stock_documents_lines, ADD CONSTRAINT fk_stock_documents_lines FOREIGN KEY (document_uuid) REFERENCES stock_documents (uuid);
+ALTER TABLE stock_document_lines DROP CONSTRAINT fk_stock_document_lines_product, ADD CONSTRAINT fk_stock_document_lines_product FOREIGN KEY (product_uuid) REFERENCES products (uuid);
+
+ALTER TABLE categories DROP CONSTRAINT uni_categories_organization_uuid;
+ALTER TABLE suppliers DROP CONSTRAINT uni_suppliers_organization_uuid;
+ALTER TABLE products DROP CONSTRAINT uni_products_organization_uuid;
+ALTER TABLE warehouses DROP CONSTRAINT uni_warehouses_organization_uuid;
+ALTER TABLE stock_documents DROP CONSTRAINT uni_stock_documents_organization_uuid;
+ALTER TABLE api_keys DROP CONSTRAINT uni_api_keys_organization_uuid;
+
+-- Fails if organisations now share SKUs, category names or document references
+DROP INDEX IF EXISTS idx_categories_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON categories (name);
+DROP INDEX IF EXISTS idx_products_sku;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products (sku);
+DROP INDEX IF EXISTS idx_stock_documents_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_documents_reference ON stock_documents (document_type, reference_number);
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE categories DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE suppliers DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE products DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE warehouses DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE warehouse_stocks DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_transfers DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_ins DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_outs DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_adjustments DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_documents DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE stock_document_lines DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS organization_uuid;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS organization_uuid;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,96 @@
+-- Organisations: the client companies sharing this deployment. Every record belongs to one.
+-- Existing data goes to the default organisation, which also serves requests made without an
+-- API key; its UUID is domain.DefaultOrganizationUUID.
+CREATE TABLE IF NOT EXISTS organizations (
+    uuid uuid,
+    name varchar(100) NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_name ON organizations (name);
+INSERT INTO organizations (uuid, name, created_at, updated_at) VALUES ('00000000-0000-0000-0000-000000000001', 'Default', now(), now());
+
+-- The owning organisation of every record. The default only fills in existing rows; the
+-- application always sets it.
+ALTER TABLE categories ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_categories_organization REFERENCES organizations (uuid);
+ALTER TABLE categories ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE suppliers ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_suppliers_organization REFERENCES organizations (uuid);
+ALTER TABLE suppliers ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE products ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_products_organization REFERENCES organizations (uuid);
+ALTER TABLE products ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE warehouses ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_warehouses_organization REFERENCES organizations (uuid);
+ALTER TABLE warehouses ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE warehouse_stocks ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_warehouse_stocks_organization REFERENCES organizations (uuid);
+ALTER TABLE warehouse_stocks ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_transfers ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_transfers_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_transfers ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_movements ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_movements_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_movements ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_ins ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_ins_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_ins ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_outs ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_outs_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_outs ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_adjustments ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_adjustments_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_adjustments ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_documents ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_documents_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_documents ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE stock_document_lines ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_stock_document_lines_organization REFERENCES organizations (uuid);
+ALTER TABLE stock_document_lines ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE api_keys ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_api_keys_organization REFERENCES organizations (uuid);
+ALTER TABLE api_keys ALTER COLUMN organization_uuid DROP DEFAULT;
+ALTER TABLE webhooks ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' CONSTRAINT fk_webhooks_organization REFERENCES organizations (uuid);
+ALTER TABLE webhooks ALTER COLUMN organization_uuid DROP DEFAULT;
+
+-- Undelivered events go to the default organisation's webhooks and streams
+ALTER TABLE outbox ADD COLUMN organization_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE outbox ALTER COLUMN organization_uuid DROP DEFAULT;
+
+-- Unique keys hold within an organisation, so clients can use the same SKUs and names
+DROP INDEX IF EXISTS idx_categories_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_name ON categories (organization_uuid, name);
+DROP INDEX IF EXISTS idx_products_sku;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products (organization_uuid, sku);
+DROP INDEX IF EXISTS idx_stock_documents_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_documents_reference ON stock_documents (organization_uuid, document_type, reference_number);
+
+-- References never cross organisations: referenced tables get a unique (organization_uuid, uuid)
+-- key and foreign keys include the organisation. These keys also serve the scoped lookups.
+ALTER TABLE categories ADD CONSTRAINT uni_categories_organization_uuid UNIQUE (organization_uuid, uuid);
+ALTER TABLE suppliers ADD CONSTRAINT uni_suppliers_organization_uuid UNIQUE (organization_uuid, uuid);
+ALTER TABLE products ADD CONSTRAINT uni_products_organization_uuid UNIQUE (organization_uuid, uuid);
+ALTER TABLE warehouses ADD CONSTRAINT uni_warehouses_organization_uuid UNIQUE (organization_uuid, uuid);
+ALTER TABLE stock_documents ADD CONSTRAINT uni_stock_documents_organization_uuid UNIQUE (organization_uuid, uuid);
+ALTER TABLE api_keys ADD CONSTRAINT uni_api_keys_organization_uuid UNIQUE (organization_uuid, uuid);
+ALTER TABLE products DROP CONSTRAINT fk_products_category, ADD CONSTRAINT fk_products_category FOREIGN KEY (organization_uuid, category_uuid) REFERENCES categories (organization_uuid, uuid);
+ALTER TABLE products DROP CONSTRAINT fk_products_supplier, ADD CONSTRAINT fk_products_supplier FOREIGN KEY (organization_uuid, supplier_uuid) REFERENCES suppliers (organization_uuid, uuid);
+ALTER TABLE warehouse_stocks DROP CONSTRAINT fk_warehouse_stocks_product, ADD CONSTRAINT fk_warehouse_stocks_product FOREIGN KEY (organization_uuid, product_uuid) REFERENCES products (organization_uuid, uuid);
+ALTER TABLE warehouse_stocks DROP CONSTRAINT fk_warehouse_stocks_warehouse, ADD CONSTRAINT fk_warehouse_stocks_warehouse FOREIGN KEY (organization_uuid, warehouse_uuid) REFERENCES warehouses (organization_uuid, uuid);
+ALTER TABLE stock_movements DROP CONSTRAINT fk_stock_movements_product, ADD CONSTRAINT fk_stock_movements_product FOREIGN KEY (organization_uuid, product_uuid) REFERENCES products (organization_uuid, uuid);
+ALTER TABLE stock_movements DROP CONSTRAINT fk_stock_movements_warehouse, ADD CONSTRAINT fk_stock_movements_warehouse FOREIGN KEY (organization_uuid, warehouse_uuid) REFERENCES warehouses (organization_uuid, uuid);
+ALTER TABLE stock_movements DROP CONSTRAINT fk_stock_movements_api_key, ADD CONSTRAINT fk_stock_movements_api_key FOREIGN KEY (organization_uuid, api_key_uuid) REFERENCES api_keys (organization_uuid, uuid);
+ALTER TABLE stock_ins DROP CONSTRAINT fk_stock_ins_product, ADD CONSTRAINT fk_stock_ins_product FOREIGN KEY (organization_uuid, product_uuid) REFERENCES products (organization_uuid, uuid);
+ALTER TABLE stock_ins DROP CONSTRAINT fk_stock_ins_warehouse, ADD CONSTRAINT fk_stock_ins_warehouse FOREIGN KEY (organization_uuid, warehouse_uuid) REFERENCES warehouses (organization_uuid, uuid);
+ALTER TABLE stock_ins DROP CONSTRAINT fk_stock_ins_supplier, ADD CONSTRAINT fk_stock_ins_supplier FOREIGN KEY (organization_uuid, supplier_uuid) REFERENCES suppliers (organization_uuid, uuid);
+ALTER TABLE stock_outs DROP CONSTRAINT fk_stock_outs_product, ADD CONSTRAINT fk_stock_outs_product FOREIGN KEY (organization_uuid, product_uuid) REFERENCES products (organization_uuid, uuid);
+ALTER TABLE stock_outs DROP CONSTRAINT fk_stock_outs_warehouse, ADD CONSTRAINT fk_stock_outs_warehouse FOREIGN KEY (organization_uuid, warehouse_uuid) REFERENCES warehouses (organization_uuid, uuid);
+ALTER TABLE stock_adjustments DROP CONSTRAINT fk_stock_adjustments_product, ADD CONSTRAINT fk_stock_adjustments_product FOREIGN KEY (organization_uuid, product_uuid) REFERENCES products (organization_uuid, uuid);
+ALTER TABLE stock_adjustments DROP CONSTRAINT fk_stock_adjustments_warehouse, ADD CONSTRAINT fk_stock_adjustments_warehouse FOREIGN KEY (organization_uuid, warehouse_uuid) REFERENCES warehouses (organization_uuid, uuid);
+ALTER TABLE stock_documents DROP CONSTRAINT fk_stock_documents_warehouse, ADD CONSTRAINT fk_stock_documents_warehouse FOREIGN KEY (organization_uuid, warehouse_uuid) REFERENCES warehouses (organization_uuid, uuid);
+ALTER TABLE stock_documents DROP CONSTRAINT fk_stock_documents_supplier, ADD CONSTRAINT fk_stock_documents_supplier FOREIGN KEY (organization_uuid, supplier_uuid) REFERENCES suppliers (organization_uuid, uuid);
+ALTER TABLE stock_document_lines DROP CONSTRAINT fk_stock_documents_lines, ADD CONSTRAINT fk_stock_documents_lines FOREIGN KEY (organization_uuid, document_uuid) REFERENCES stock_documents (organization_uuid, uuid);
+ALTER TABLE stock_document_lines DROP CONSTRAINT fk_stock_document_lines_product, ADD CONSTRAINT fk_stock_document_lines_product FOREIGN KEY (organization_uuid, product_uuid) REFERENCES products (organization_uuid, uuid);
+
+-- Every statement on the other tables filters by organisation
+CREATE INDEX IF NOT EXISTS idx_warehouse_stocks_organization_uuid ON warehouse_stocks (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_organization_uuid ON stock_transfers (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_organization_uuid ON stock_movements (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_ins_organization_uuid ON stock_ins (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_outs_organization_uuid ON stock_outs (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_adjustments_organization_uuid ON stock_adjustments (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_stock_document_lines_organization_uuid ON stock_document_lines (organization_uuid);
+CREATE INDEX IF NOT EXISTS idx_webhooks_organization_uuid ON webhooks (organization_uuid);
+
+-- The ledger is paged per organisation
+DROP INDEX IF EXISTS idx_stock_movements_ledger;
+CREATE INDEX IF NOT EXISTS idx_stock_movements_ledger ON stock_movements (organization_uuid, movement_date, created_at, uuid);
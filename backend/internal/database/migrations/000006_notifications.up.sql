@@ -0,0 +1,44 @@
+-- How each warehouse manager, by email address, wants to be notified. Managers without a row
+-- receive every kind immediately.
+CREATE TABLE notification_preferences (
+    uuid uuid,
+    organization_uuid uuid NOT NULL,
+    email varchar(255) NOT NULL,
+    frequency varchar(20) NOT NULL,
+    kinds jsonb NOT NULL DEFAULT '[]',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_notification_preferences_organization FOREIGN KEY (organization_uuid) REFERENCES organizations (uuid)
+);
+CREATE UNIQUE INDEX idx_notification_preferences_email ON notification_preferences (organization_uuid, email);
+
+-- One row per notification and manager: the email queue, digests waiting for their hour
+-- included, and the log of how sending went
+CREATE TABLE notifications (
+    uuid uuid,
+    organization_uuid uuid NOT NULL,
+    recipient varchar(255) NOT NULL,
+    kind varchar(30) NOT NULL,
+    subject_uuid uuid NOT NULL,
+    subject_name varchar(100) NOT NULL,
+    sku varchar(50),
+    quantity bigint NOT NULL,
+    threshold bigint NOT NULL,
+    event_id uuid NOT NULL,
+    frequency varchar(20) NOT NULL,
+    status varchar(20) NOT NULL,
+    attempts bigint NOT NULL DEFAULT 0,
+    error text,
+    next_attempt_at timestamptz,
+    sent_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_notifications_organization FOREIGN KEY (organization_uuid) REFERENCES organizations (uuid)
+);
+CREATE INDEX idx_notifications_organization_uuid ON notifications (organization_uuid, created_at);
+-- De-duplication looks for a recent notification of the same kind about the same subject
+CREATE INDEX idx_notifications_dedup ON notifications (organization_uuid, recipient, kind, subject_uuid, created_at);
+-- The dispatcher only ever looks for pending notifications that are due
+CREATE INDEX idx_notifications_due ON notifications (next_attempt_at) WHERE status = 'pending';
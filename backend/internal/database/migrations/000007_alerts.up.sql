@@ -0,0 +1,68 @@
+-- Conditions users define, each opening an alert for every product, warehouse or adjustment it
+-- holds for
+CREATE TABLE alert_rules (
+    uuid uuid,
+    organization_uuid uuid NOT NULL,
+    name varchar(100) NOT NULL,
+    type varchar(30) NOT NULL,
+    product_uuid uuid,
+    warehouse_uuid uuid,
+    threshold bigint NOT NULL,
+    enabled boolean NOT NULL DEFAULT true,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_alert_rules_organization FOREIGN KEY (organization_uuid) REFERENCES organizations (uuid)
+);
+CREATE INDEX idx_alert_rules_organization_uuid ON alert_rules (organization_uuid, type);
+
+-- Alerts go away with their rule; disabling a rule keeps them
+CREATE TABLE alerts (
+    uuid uuid,
+    organization_uuid uuid NOT NULL,
+    rule_uuid uuid NOT NULL,
+    type varchar(30) NOT NULL,
+    subject_key varchar(100) NOT NULL,
+    product_uuid uuid,
+    warehouse_uuid uuid,
+    movement_uuid uuid,
+    status varchar(20) NOT NULL,
+    message varchar(255) NOT NULL,
+    value bigint NOT NULL,
+    threshold bigint NOT NULL,
+    snoozed_until timestamptz,
+    acknowledged_at timestamptz,
+    acknowledged_by varchar(100),
+    resolved_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (uuid),
+    CONSTRAINT fk_alerts_organization FOREIGN KEY (organization_uuid) REFERENCES organizations (uuid),
+    CONSTRAINT fk_alerts_rule FOREIGN KEY (rule_uuid) REFERENCES alert_rules (uuid) ON DELETE CASCADE
+);
+CREATE INDEX idx_alerts_organization_uuid ON alerts (organization_uuid, created_at);
+-- A rule has one unresolved alert per subject, however many events or replicas evaluate it
+CREATE UNIQUE INDEX idx_alerts_subject ON alerts (rule_uuid, subject_key) WHERE status <> 'resolved';
+CREATE INDEX idx_alerts_product_uuid ON alerts (product_uuid);
+CREATE INDEX idx_alerts_warehouse_uuid ON alerts (warehouse_uuid);
+-- The evaluator only ever looks for snoozes that ended
+CREATE INDEX idx_alerts_snoozed_until ON alerts (snoozed_until) WHERE status = 'snoozed';
+
+-- Every status an alert went through, in order; WatchAlerts streams resume from an id
+CREATE TABLE alert_transitions (
+    id bigserial,
+    organization_uuid uuid NOT NULL,
+    alert_uuid uuid NOT NULL,
+    from_status varchar(20),
+    to_status varchar(20) NOT NULL,
+    actor varchar(100) NOT NULL,
+    note varchar(255),
+    value bigint NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_alert_transitions_organization FOREIGN KEY (organization_uuid) REFERENCES organizations (uuid),
+    CONSTRAINT fk_alert_transitions_alert FOREIGN KEY (alert_uuid) REFERENCES alerts (uuid) ON DELETE CASCADE
+);
+CREATE INDEX idx_alert_transitions_alert_uuid ON alert_transitions (alert_uuid, id);
+CREATE INDEX idx_alert_transitions_organization_uuid ON alert_transitions (organization_uuid, id);
+CREATE INDEX idx_alert_transitions_created_at ON alert_transitions (created_at);
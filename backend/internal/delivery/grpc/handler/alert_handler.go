@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/eventbus"
+	"github.com/shirloin/stockhub/internal/repository"
+	pb "github.com/shirloin/stockhub/proto/alert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// alertTransitionBatch is how many transitions an update carries at most; more are sent in
+// further updates straight away
+const alertTransitionBatch = 100
+
+type AlertGRPCHandler struct {
+	pb.UnimplementedAlertServiceServer
+	alertRepository *repository.AlertRepository
+	shutdown        chan struct{}
+	bus             *eventbus.Bus
+}
+
+func NewAlertGRPCHandler(alertRepository *repository.AlertRepository) *AlertGRPCHandler {
+	return &AlertGRPCHandler{alertRepository: alertRepository}
+}
+
+// WatchAlerts sends the unresolved alerts, then every transition as alerts open, are
+// acknowledged, snoozed, reopen and resolve. Clients resuming with after_id get the transitions
+// they missed instead of the unresolved alerts.
+func (h *AlertGRPCHandler) WatchAlerts(req *pb.WatchAlertsRequest, stream pb.AlertService_WatchAlertsServer) error {
+	updates := h.bus.Subscribe(stream.Context(), domain.EventAlertChanged)
+	defer h.bus.Unsubscribe(updates)
+
+	ctx := stream.Context()
+	cursor := req.AfterId
+	if cursor <= 0 {
+		// The cursor is read first, so transitions made while the alerts are read are sent again
+		// rather than missed
+		last, err := h.alertRepository.LastTransitionID(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Failed to get initial alerts: %v", err)
+		}
+		alerts, err := h.alertRepository.GetActive(ctx, "", domain.AlertScope{})
+		if err != nil {
+			return status.Errorf(codes.Internal, "Failed to get initial alerts: %v", err)
+		}
+		protoAlerts := make([]*pb.Alert, len(alerts))
+		for i := range alerts {
+			protoAlerts[i] = alertToProto(&alerts[i])
+		}
+		cursor = last
+
+		// Send initial state
+		if err := stream.Send(&pb.AlertUpdate{
+			Alerts:           protoAlerts,
+			LastTransitionId: cursor,
+			Timestamp:        time.Now().Format(time.RFC3339),
+		}); err != nil {
+			return status.Errorf(codes.Internal, "Failed to send initial alerts: %v", err)
+		}
+	} else {
+		var err error
+		if cursor, err = h.sendTransitions(ctx, stream, cursor); err != nil {
+			return err
+		}
+	}
+
+	// Stream updates
+	for {
+		select {
+		case <-stream.Context().Done():
+			slog.DebugContext(ctx, "Alert client disconnected")
+			return nil
+		case <-h.shutdown:
+			return errShuttingDown
+		case <-updates.C():
+			updateCtx, span := startUpdateSpan(ctx, "AlertService.WatchAlerts")
+			next, err := h.sendTransitions(updateCtx, stream, cursor)
+			endUpdateSpan(span, err)
+			if err != nil {
+				if status.Code(err) == codes.Internal {
+					return err
+				}
+				continue
+			}
+			cursor = next
+		}
+	}
+}
+
+// sendTransitions sends the transitions after cursor in batches and returns the new cursor.
+// Failing to read them is logged and left for the next wake-up; failing to send ends the stream
+// with INTERNAL.
+func (h *AlertGRPCHandler) sendTransitions(ctx context.Context, stream pb.AlertService_WatchAlertsServer, cursor int64) (int64, error) {
+	for {
+		transitions, err := h.alertRepository.GetTransitionsAfter(ctx, cursor, alertTransitionBatch)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to get alert transitions", "error", err)
+			return cursor, status.Error(codes.Unavailable, "failed to get alert transitions")
+		}
+		if len(transitions) == 0 {
+			return cursor, nil
+		}
+
+		protoTransitions := make([]*pb.AlertTransition, len(transitions))
+		for i := range transitions {
+			protoTransitions[i] = alertTransitionToProto(&transitions[i])
+		}
+		cursor = transitions[len(transitions)-1].ID
+
+		if err := stream.Send(&pb.AlertUpdate{
+			Transitions:      protoTransitions,
+			LastTransitionId: cursor,
+			Timestamp:        time.Now().Format(time.RFC3339),
+		}); err != nil {
+			slog.WarnContext(ctx, "Failed to send alert update", "error", err)
+			return cursor, status.Errorf(codes.Internal, "Failed to send update: %v", err)
+		}
+		slog.DebugContext(ctx, "Sent alert update", "transitions", len(protoTransitions))
+		if len(transitions) < alertTransitionBatch {
+			return cursor, nil
+		}
+	}
+}
+
+func alertToProto(a *domain.Alert) *pb.Alert {
+	proto := &pb.Alert{
+		Uuid:           a.UUID,
+		RuleUuid:       a.RuleUUID,
+		Type:           string(a.Type),
+		Status:         string(a.Status),
+		Message:        a.Message,
+		Value:          int32(a.Value),
+		Threshold:      int32(a.Threshold),
+		AcknowledgedBy: a.AcknowledgedBy,
+		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      a.UpdatedAt.Format(time.RFC3339),
+	}
+	if a.ProductUUID != nil {
+		proto.ProductUuid = *a.ProductUUID
+	}
+	if a.WarehouseUUID != nil {
+		proto.WarehouseUuid = *a.WarehouseUUID
+	}
+	if a.MovementUUID != nil {
+		proto.MovementUuid = *a.MovementUUID
+	}
+	if a.SnoozedUntil != nil {
+		proto.SnoozedUntil = a.SnoozedUntil.Format(time.RFC3339)
+	}
+	if a.AcknowledgedAt != nil {
+		proto.AcknowledgedAt = a.AcknowledgedAt.Format(time.RFC3339)
+	}
+	if a.ResolvedAt != nil {
+		proto.ResolvedAt = a.ResolvedAt.Format(time.RFC3339)
+	}
+	return proto
+}
+
+func alertTransitionToProto(t *domain.AlertTransition) *pb.AlertTransition {
+	proto := &pb.AlertTransition{
+		Id:        t.ID,
+		From:      string(t.From),
+		To:        string(t.To),
+		Actor:     t.Actor,
+		Note:      t.Note,
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+	}
+	if t.Alert != nil {
+		proto.Alert = alertToProto(t.Alert)
+	}
+	return proto
+}
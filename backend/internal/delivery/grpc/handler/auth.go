@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/ratelimit"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/proto/alert/alertconnect"
+	"github.com/shirloin/stockhub/proto/movement/movementconnect"
+	"github.com/shirloin/stockhub/proto/product/productconnect"
+	"github.com/shirloin/stockhub/proto/warehouse/warehouseconnect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// procedureScopes is the scope an API key needs for each procedure; keys cannot call
+// procedures missing from it
+var procedureScopes = map[string]domain.APIKeyScope{
+	productconnect.ProductServiceWatchStockAlertsProcedure:        domain.ScopeCatalogRead,
+	productconnect.ProductServiceWatchTopProductsByPriceProcedure: domain.ScopeCatalogRead,
+	productconnect.ProductServiceGetProductProcedure:              domain.ScopeCatalogRead,
+	productconnect.ProductServiceListProductsProcedure:            domain.ScopeCatalogRead,
+	productconnect.ProductServiceCreateProductProcedure:           domain.ScopeCatalogWrite,
+	productconnect.ProductServiceUpdateProductProcedure:           domain.ScopeCatalogWrite,
+	productconnect.ProductServiceDeleteProductProcedure:           domain.ScopeCatalogWrite,
+
+	warehouseconnect.WarehouseServiceWatchWarehousesProcedure: domain.ScopeCatalogRead,
+	warehouseconnect.WarehouseServiceGetWarehouseProcedure:    domain.ScopeCatalogRead,
+	warehouseconnect.WarehouseServiceListWarehousesProcedure:  domain.ScopeCatalogRead,
+	warehouseconnect.WarehouseServiceCreateWarehouseProcedure: domain.ScopeCatalogWrite,
+	warehouseconnect.WarehouseServiceUpdateWarehouseProcedure: domain.ScopeCatalogWrite,
+	warehouseconnect.WarehouseServiceDeleteWarehouseProcedure: domain.ScopeCatalogWrite,
+	warehouseconnect.WarehouseServiceTransferStockProcedure:   domain.ScopeStockTransfer,
+
+	movementconnect.MovementServiceWatchMovementsProcedure:        domain.ScopeStockRead,
+	movementconnect.MovementServiceListMovementsProcedure:         domain.ScopeStockRead,
+	movementconnect.MovementServiceCreateStockInProcedure:         domain.ScopeStockIn,
+	movementconnect.MovementServiceCreateStockOutProcedure:        domain.ScopeStockOut,
+	movementconnect.MovementServiceCreateStockAdjustmentProcedure: domain.ScopeStockAdjust,
+
+	alertconnect.AlertServiceWatchAlertsProcedure: domain.ScopeStockRead,
+}
+
+// APIKeyAuth authenticates gRPC and Connect calls by the API key in their x-api-key metadata,
+// or an authorization bearer token that looks like a key. The server is built before the use
+// cases, so APIKeys is set once they exist.
+type APIKeyAuth struct {
+	APIKeys  *usecase.APIKeyUseCase
+	Required bool // Reject calls without a key; health checks and reflection are always open
+}
+
+// authenticate returns ctx carrying the key presented for procedure and limited to its
+// organisation, or limited to the default organisation when there is none and keys are optional
+func (a *APIKeyAuth) authenticate(ctx context.Context, procedure string, credential string) (context.Context, error) {
+	if ratelimit.Exempt(procedure) {
+		return ctx, nil
+	}
+	if credential == "" {
+		if a.Required {
+			return ctx, domain.ErrAPIKeyRequired
+		}
+		return domain.WithOrganization(ctx, domain.DefaultOrganizationUUID), nil
+	}
+
+	key, err := a.APIKeys.Authenticate(ctx, credential)
+	if err != nil {
+		return ctx, err
+	}
+	scope, ok := procedureScopes[procedure]
+	if !ok {
+		return ctx, domain.NewForbiddenError("API keys cannot call " + procedure)
+	}
+	if err := key.RequireScope(scope); err != nil {
+		return ctx, err
+	}
+	return domain.WithOrganization(domain.WithAPIKey(ctx, key), key.OrganizationUUID), nil
+}
+
+// metadataCredential returns the API key in the call's metadata
+func metadataCredential(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-api-key"); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	if values := md.Get("authorization"); len(values) > 0 {
+		if token, ok := strings.CutPrefix(values[0], "Bearer "); ok && usecase.IsAPIKey(token) {
+			return token
+		}
+	}
+	return ""
+}
+
+// headerCredential returns the API key in a Connect request's headers
+func headerCredential(header http.Header) string {
+	if key := header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok && usecase.IsAPIKey(token) {
+		return token
+	}
+	return ""
+}
+
+// UnaryInterceptor authenticates unary calls
+func (a *APIKeyAuth) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod, metadataCredential(ctx))
+		if err != nil {
+			return nil, grpcError(ctx, err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates streams
+func (a *APIKeyAuth) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(stream.Context(), info.FullMethod, metadataCredential(stream.Context()))
+		if err != nil {
+			return grpcError(ctx, err)
+		}
+		return handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// ConnectInterceptor authenticates Connect, gRPC-Web and HTTP/1.1 gRPC calls
+func (a *APIKeyAuth) ConnectInterceptor() connect.Interceptor {
+	return &connectAPIKeyInterceptor{auth: a}
+}
+
+type connectAPIKeyInterceptor struct {
+	auth *APIKeyAuth
+}
+
+func (i *connectAPIKeyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := i.auth.authenticate(ctx, req.Spec().Procedure, headerCredential(req.Header()))
+		if err != nil {
+			return nil, connectError(ctx, err)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *connectAPIKeyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *connectAPIKeyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.auth.authenticate(ctx, conn.Spec().Procedure, headerCredential(conn.RequestHeader()))
+		if err != nil {
+			return connectError(ctx, err)
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	pbAlert "github.com/shirloin/stockhub/proto/alert"
+	"github.com/shirloin/stockhub/proto/alert/alertconnect"
+	pbMovement "github.com/shirloin/stockhub/proto/movement"
+	"github.com/shirloin/stockhub/proto/movement/movementconnect"
+	pb "github.com/shirloin/stockhub/proto/product"
+	"github.com/shirloin/stockhub/proto/product/productconnect"
+	pbWarehouse "github.com/shirloin/stockhub/proto/warehouse"
+	"github.com/shirloin/stockhub/proto/warehouse/warehouseconnect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The Connect handlers serve the same gRPC handlers over the Connect, gRPC and gRPC-Web protocols.
+// Unary methods are promoted from the embedded gRPC handler; only the streams need adapting.
+
+type ProductConnectHandler struct {
+	*ProductGRPCHandler
+}
+
+func (h *ProductConnectHandler) WatchStockAlerts(ctx context.Context, req *pb.WatchStockAlertsRequest, stream *connect.ServerStream[pb.StockAlertUpdate]) error {
+	return h.ProductGRPCHandler.WatchStockAlerts(req, &connectServerStream[pb.StockAlertUpdate]{ctx: ctx, stream: stream})
+}
+
+func (h *ProductConnectHandler) WatchTopProductsByPrice(ctx context.Context, req *pb.WatchTopProductsByPriceRequest, stream *connect.ServerStream[pb.PriceUpdate]) error {
+	return h.ProductGRPCHandler.WatchTopProductsByPrice(req, &connectServerStream[pb.PriceUpdate]{ctx: ctx, stream: stream})
+}
+
+type WarehouseConnectHandler struct {
+	*WarehouseGRPCHandler
+}
+
+func (h *WarehouseConnectHandler) WatchWarehouses(ctx context.Context, req *pbWarehouse.WatchWarehousesRequest, stream *connect.ServerStream[pbWarehouse.WarehouseUpdate]) error {
+	return h.WarehouseGRPCHandler.WatchWarehouses(req, &connectServerStream[pbWarehouse.WarehouseUpdate]{ctx: ctx, stream: stream})
+}
+
+type MovementConnectHandler struct {
+	*MovementGRPCHandler
+}
+
+func (h *MovementConnectHandler) WatchMovements(ctx context.Context, req *pbMovement.WatchMovementsRequest, stream *connect.ServerStream[pbMovement.MovementUpdate]) error {
+	return h.MovementGRPCHandler.WatchMovements(req, &connectServerStream[pbMovement.MovementUpdate]{ctx: ctx, stream: stream})
+}
+
+type AlertConnectHandler struct {
+	*AlertGRPCHandler
+}
+
+func (h *AlertConnectHandler) WatchAlerts(ctx context.Context, req *pbAlert.WatchAlertsRequest, stream *connect.ServerStream[pbAlert.AlertUpdate]) error {
+	return h.AlertGRPCHandler.WatchAlerts(req, &connectServerStream[pbAlert.AlertUpdate]{ctx: ctx, stream: stream})
+}
+
+var (
+	_ productconnect.ProductServiceHandler     = (*ProductConnectHandler)(nil)
+	_ warehouseconnect.WarehouseServiceHandler = (*WarehouseConnectHandler)(nil)
+	_ movementconnect.MovementServiceHandler   = (*MovementConnectHandler)(nil)
+	_ alertconnect.AlertServiceHandler         = (*AlertConnectHandler)(nil)
+)
+
+// connectServerStream lets a gRPC server-streaming handler write to a Connect stream
+type connectServerStream[T any] struct {
+	ctx    context.Context
+	stream *connect.ServerStream[T]
+}
+
+var _ grpc.ServerStreamingServer[pb.PriceUpdate] = (*connectServerStream[pb.PriceUpdate])(nil)
+
+func (s *connectServerStream[T]) Send(msg *T) error {
+	return s.stream.Send(msg)
+}
+
+func (s *connectServerStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *connectServerStream[T]) SetHeader(md metadata.MD) error {
+	for key, values := range md {
+		for _, value := range values {
+			s.stream.ResponseHeader().Add(key, value)
+		}
+	}
+	return nil
+}
+
+// SendHeader only records md; Connect flushes headers with the first message
+func (s *connectServerStream[T]) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *connectServerStream[T]) SetTrailer(md metadata.MD) {
+	for key, values := range md {
+		for _, value := range values {
+			s.stream.ResponseTrailer().Add(key, value)
+		}
+	}
+}
+
+func (s *connectServerStream[T]) SendMsg(m any) error {
+	msg, ok := m.(*T)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, errors.New("unexpected stream message type"))
+	}
+	return s.stream.Send(msg)
+}
+
+func (s *connectServerStream[T]) RecvMsg(any) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("server streams do not receive messages"))
+}
+
+// ConnectErrorInterceptor turns the errors returned by the handlers into Connect errors through
+// grpcError, so clients of every protocol see the same code, message and details
+func ConnectErrorInterceptor() connect.Interceptor {
+	return &connectErrorInterceptor{}
+}
+
+type connectErrorInterceptor struct{}
+
+func (i *connectErrorInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		return res, connectError(ctx, err)
+	}
+}
+
+func (i *connectErrorInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *connectErrorInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return connectError(ctx, next(ctx, conn))
+	}
+}
+
+func connectError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return err
+	}
+	// gRPC and Connect share the same numeric codes and detail encoding
+	st := status.Convert(grpcError(ctx, err))
+	connectErr = connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Proto().GetDetails() {
+		if errorDetail, detailErr := connect.NewErrorDetail(detail); detailErr == nil {
+			connectErr.AddDetail(errorDetail)
+		}
+	}
+	return connectErr
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errShuttingDown ends Watch streams when the server stops
+var errShuttingDown = status.Error(codes.Unavailable, "server is shutting down")
+
+// UnaryErrorInterceptor translates the errors returned by unary handlers with grpcError
+func UnaryErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		return res, grpcError(ctx, err)
+	}
+}
+
+// StreamErrorInterceptor translates the errors returned by streaming handlers with grpcError
+func StreamErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return grpcError(stream.Context(), handler(srv, stream))
+	}
+}
+
+// grpcError translates a use case error into a gRPC status error. Domain errors carry their
+// code as the ErrorInfo reason and validation failures as BadRequest field violations;
+// status errors pass through. Anything else is internal: the cause is logged with the request
+// ID and the client only gets a generic message, so database errors are not leaked.
+func grpcError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	domainErr := domain.AsError(err)
+	if domainErr == nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+		case errors.Is(err, context.Canceled):
+			return status.Error(codes.Canceled, context.Canceled.Error())
+		}
+		slog.ErrorContext(ctx, "Internal error", "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	st := status.New(grpcCode(domainErr.Code), err.Error())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: string(domainErr.Code), Domain: "stockhub"}}
+	if len(domainErr.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range domainErr.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if withDetails, detailsErr := st.WithDetails(details...); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// grpcCode maps a domain error code to its gRPC code
+func grpcCode(code domain.ErrorCode) codes.Code {
+	switch code {
+	case domain.ErrorCodeNotFound:
+		return codes.NotFound
+	case domain.ErrorCodeValidation:
+		return codes.InvalidArgument
+	case domain.ErrorCodeConflict:
+		return codes.AlreadyExists
+	case domain.ErrorCodeInsufficientStock,
+		domain.ErrorCodeCapacityExceeded:
+		return codes.FailedPrecondition
+	case domain.ErrorCodeUnauthenticated:
+		return codes.Unauthenticated
+	case domain.ErrorCodeForbidden:
+		return codes.PermissionDenied
+	}
+	return codes.Internal
+}
+
+// parseProtoTime reads an optional RFC 3339 timestamp; empty values give the zero time
+func parseProtoTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 timestamp", field)
+	}
+	return t, nil
+}
+
+// pageSize holds the REST pagination settings for list and Watch requests
+type pageSize struct {
+	defaultLimit int
+	maxLimit     int // 0 is unlimited
+}
+
+// pageAndLimit applies the REST defaults of page 1 and defaultLimit items to unset values
+// and caps the limit at maxLimit
+func (s pageSize) pageAndLimit(page, limit int32) (int, int) {
+	p, l := int(page), int(limit)
+	if p <= 0 {
+		p = 1
+	}
+	if l <= 0 {
+		l = s.defaultLimit
+	}
+	return p, s.clamp(l)
+}
+
+// clamp caps limit at maxLimit
+func (s pageSize) clamp(limit int) int {
+	if s.maxLimit > 0 && limit > s.maxLimit {
+		return s.maxLimit
+	}
+	return limit
+}
@@ -1,6 +1,10 @@
 package handler
 
 import (
+	"sync"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/eventbus"
 	"github.com/shirloin/stockhub/internal/repository"
 	"github.com/shirloin/stockhub/internal/usecase"
 )
@@ -9,12 +13,43 @@ type GRPCHandler struct {
 	ProductGRPCHandler   *ProductGRPCHandler
 	WarehouseGRPCHandler *WarehouseGRPCHandler
 	MovementGRPCHandler  *MovementGRPCHandler
+	AlertGRPCHandler     *AlertGRPCHandler
+	shutdown             chan struct{}
+	shutdownOnce         sync.Once
 }
 
-func InitGRPCHandler(repositories *repository.Repositories, usecases *usecase.Usecases) *GRPCHandler {
-	return &GRPCHandler{
-		ProductGRPCHandler:   NewProductGRPCHandler(repositories.ProductRepository),
+// Events that change what each Watch stream shows
+var (
+	productEvents   = []domain.EventType{domain.EventProductCreated, domain.EventProductUpdated, domain.EventProductDeleted, domain.EventProductLowStock}
+	warehouseEvents = []domain.EventType{domain.EventWarehouseCreated, domain.EventWarehouseUpdated, domain.EventWarehouseDeleted}
+)
+
+// InitGRPCHandler builds the gRPC handlers; their Watch streams send updates when bus wakes them
+func InitGRPCHandler(repositories *repository.Repositories, usecases *usecase.Usecases, bus *eventbus.Bus, defaultPageSize int, maxPageSize int) *GRPCHandler {
+	h := &GRPCHandler{
+		ProductGRPCHandler:   NewProductGRPCHandler(repositories.ProductRepository, usecases.ProductUsecase),
 		WarehouseGRPCHandler: NewWarehouseGRPCHandler(repositories.WarehouseRepository, repositories.WarehouseStockRepository, usecases.WarehouseUsecase),
-		MovementGRPCHandler:  NewMovementGRPCHandler(repositories.StockMovementRepository),
+		MovementGRPCHandler:  NewMovementGRPCHandler(repositories.StockMovementRepository, usecases.StockMovementUseCase, usecases.StockInUseCase, usecases.StockOutUseCase, usecases.StockAdjustmentUseCase),
+		AlertGRPCHandler:     NewAlertGRPCHandler(repositories.AlertRepository),
+		shutdown:             make(chan struct{}),
 	}
+	h.ProductGRPCHandler.shutdown = h.shutdown
+	h.WarehouseGRPCHandler.shutdown = h.shutdown
+	h.MovementGRPCHandler.shutdown = h.shutdown
+	h.AlertGRPCHandler.shutdown = h.shutdown
+	pages := pageSize{defaultLimit: defaultPageSize, maxLimit: maxPageSize}
+	h.ProductGRPCHandler.pageSize = pages
+	h.WarehouseGRPCHandler.pageSize = pages
+	h.MovementGRPCHandler.pageSize = pages
+	h.ProductGRPCHandler.bus = bus
+	h.WarehouseGRPCHandler.bus = bus
+	h.MovementGRPCHandler.bus = bus
+	h.AlertGRPCHandler.bus = bus
+	return h
+}
+
+// Shutdown ends every open Watch stream with UNAVAILABLE so clients reconnect to another
+// instance; streams opened afterwards end right after their initial state
+func (h *GRPCHandler) Shutdown() {
+	h.shutdownOnce.Do(func() { close(h.shutdown) })
 }
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"google.golang.org/grpc"
+)
+
+// StreamMetricsInterceptor tracks open streams and updates sent on the native gRPC server
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		service, method := splitProcedure(info.FullMethod)
+		defer trackStream(service, method)()
+		return handler(srv, &meteredServerStream{ServerStream: stream, service: service})
+	}
+}
+
+type meteredServerStream struct {
+	grpc.ServerStream
+	service string
+}
+
+func (s *meteredServerStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	metrics.StreamUpdates.WithLabelValues(s.service, "sent").Inc()
+	return nil
+}
+
+// ConnectMetricsInterceptor does the same as StreamMetricsInterceptor for the Connect handlers
+func ConnectMetricsInterceptor() connect.Interceptor {
+	return &connectMetricsInterceptor{}
+}
+
+type connectMetricsInterceptor struct{}
+
+func (i *connectMetricsInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+func (i *connectMetricsInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *connectMetricsInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		service, method := splitProcedure(conn.Spec().Procedure)
+		defer trackStream(service, method)()
+		return next(ctx, &meteredHandlerConn{StreamingHandlerConn: conn, service: service})
+	}
+}
+
+type meteredHandlerConn struct {
+	connect.StreamingHandlerConn
+	service string
+}
+
+func (c *meteredHandlerConn) Send(m any) error {
+	if err := c.StreamingHandlerConn.Send(m); err != nil {
+		return err
+	}
+	metrics.StreamUpdates.WithLabelValues(c.service, "sent").Inc()
+	return nil
+}
+
+// trackStream counts a stream as open until the returned function is called
+func trackStream(service, method string) func() {
+	gauge := metrics.StreamsActive.WithLabelValues(service, method)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// splitProcedure turns /product.ProductService/WatchStockAlerts into its service and method
+func splitProcedure(procedure string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	return service, method
+}
@@ -2,11 +2,13 @@ package handler
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/eventbus"
 	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/internal/usecase"
 	pb "github.com/shirloin/stockhub/proto/movement"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,21 +17,201 @@ import (
 type MovementGRPCHandler struct {
 	pb.UnimplementedMovementServiceServer
 	movementRepository *repository.StockMovementRepository
+	movementUseCase    *usecase.StockMovementUseCase
+	stockInUseCase     *usecase.StockInUseCase
+	stockOutUseCase    *usecase.StockOutUseCase
+	adjustmentUseCase  *usecase.StockAdjustmentUseCase
+	shutdown           chan struct{}
+	pageSize           pageSize
+	bus                *eventbus.Bus
 }
 
-func NewMovementGRPCHandler(movementRepository *repository.StockMovementRepository) *MovementGRPCHandler {
+func NewMovementGRPCHandler(
+	movementRepository *repository.StockMovementRepository,
+	movementUseCase *usecase.StockMovementUseCase,
+	stockInUseCase *usecase.StockInUseCase,
+	stockOutUseCase *usecase.StockOutUseCase,
+	adjustmentUseCase *usecase.StockAdjustmentUseCase,
+) *MovementGRPCHandler {
 	return &MovementGRPCHandler{
 		movementRepository: movementRepository,
+		movementUseCase:    movementUseCase,
+		stockInUseCase:     stockInUseCase,
+		stockOutUseCase:    stockOutUseCase,
+		adjustmentUseCase:  adjustmentUseCase,
 	}
 }
 
+// ListMovements mirrors GET /api/stock-movements: cursor pagination when cursor is set, pages otherwise
+func (h *MovementGRPCHandler) ListMovements(ctx context.Context, req *pb.ListMovementsRequest) (*pb.ListMovementsResponse, error) {
+	filter, err := h.movementFilterFromProto(req)
+	if err != nil {
+		return nil, err
+	}
+	sorts, err := domain.ParseStockMovementSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+	page, limit := h.pageSize.pageAndLimit(req.Page, req.Limit)
+
+	if req.Cursor != nil {
+		if len(sorts) > 0 {
+			return nil, domain.ErrMovementSortCursor
+		}
+		result, err := h.movementUseCase.GetPage(ctx, filter, req.GetCursor(), limit, req.IncludeTotal)
+		if err != nil {
+			return nil, err
+		}
+		resp := &pb.ListMovementsResponse{
+			Movements:  h.movementsToProto(result.Items),
+			Limit:      int32(limit),
+			NextCursor: result.NextCursor,
+			PrevCursor: result.PrevCursor,
+		}
+		if result.Total != nil {
+			resp.Total = *result.Total
+			resp.TotalApproximate = true
+		}
+		return resp, nil
+	}
+
+	movements, total, err := h.movementUseCase.ListPaginated(ctx, filter, sorts, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListMovementsResponse{
+		Movements: h.movementsToProto(movements),
+		Page:      int32(page),
+		Limit:     int32(limit),
+		Total:     total,
+	}, nil
+}
+
+func (h *MovementGRPCHandler) CreateStockIn(ctx context.Context, req *pb.CreateStockInRequest) (*pb.StockIn, error) {
+	in := req.GetStockIn()
+	if in == nil {
+		return nil, domain.NewValidationError("stock_in", "stock_in is required")
+	}
+	if in.Quantity <= 0 {
+		return nil, domain.ErrQuantityInvalid
+	}
+	receivedDate, err := parseProtoTime("received_date", in.ReceivedDate)
+	if err != nil {
+		return nil, err
+	}
+	stockIn := &domain.StockIn{
+		ProductUUID:     in.ProductUuid,
+		WarehouseUUID:   in.WarehouseUuid,
+		Quantity:        int(in.Quantity),
+		PurchaseOrderNo: in.PurchaseOrderNo,
+		SupplierUUID:    in.SupplierUuid,
+		ReceivedDate:    receivedDate,
+		ReceivedBy:      in.ReceivedBy,
+		Notes:           in.Notes,
+	}
+	if err := h.stockInUseCase.Create(ctx, stockIn); err != nil {
+		return nil, err
+	}
+	return &pb.StockIn{
+		Uuid:            stockIn.UUID,
+		ProductUuid:     stockIn.ProductUUID,
+		WarehouseUuid:   stockIn.WarehouseUUID,
+		Quantity:        int32(stockIn.Quantity),
+		PurchaseOrderNo: stockIn.PurchaseOrderNo,
+		SupplierUuid:    stockIn.SupplierUUID,
+		ReceivedDate:    stockIn.ReceivedDate.Format(time.RFC3339),
+		ReceivedBy:      stockIn.ReceivedBy,
+		Notes:           stockIn.Notes,
+		CreatedAt:       stockIn.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *MovementGRPCHandler) CreateStockOut(ctx context.Context, req *pb.CreateStockOutRequest) (*pb.StockOut, error) {
+	out := req.GetStockOut()
+	if out == nil {
+		return nil, domain.NewValidationError("stock_out", "stock_out is required")
+	}
+	if out.Quantity <= 0 {
+		return nil, domain.ErrQuantityInvalid
+	}
+	shippedDate, err := parseProtoTime("shipped_date", out.ShippedDate)
+	if err != nil {
+		return nil, err
+	}
+	stockOut := &domain.StockOut{
+		ProductUUID:   out.ProductUuid,
+		WarehouseUUID: out.WarehouseUuid,
+		Quantity:      int(out.Quantity),
+		SalesOrderNo:  out.SalesOrderNo,
+		CustomerName:  out.CustomerName,
+		ShippedDate:   shippedDate,
+		ShippedBy:     out.ShippedBy,
+		Notes:         out.Notes,
+	}
+	if err := h.stockOutUseCase.Create(ctx, stockOut); err != nil {
+		return nil, err
+	}
+	return &pb.StockOut{
+		Uuid:          stockOut.UUID,
+		ProductUuid:   stockOut.ProductUUID,
+		WarehouseUuid: stockOut.WarehouseUUID,
+		Quantity:      int32(stockOut.Quantity),
+		SalesOrderNo:  stockOut.SalesOrderNo,
+		CustomerName:  stockOut.CustomerName,
+		ShippedDate:   stockOut.ShippedDate.Format(time.RFC3339),
+		ShippedBy:     stockOut.ShippedBy,
+		Notes:         stockOut.Notes,
+		CreatedAt:     stockOut.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (h *MovementGRPCHandler) CreateStockAdjustment(ctx context.Context, req *pb.CreateStockAdjustmentRequest) (*pb.StockAdjustment, error) {
+	in := req.GetAdjustment()
+	if in == nil {
+		return nil, domain.NewValidationError("adjustment", "adjustment is required")
+	}
+	if in.Quantity == 0 {
+		return nil, domain.NewValidationError("quantity", "quantity cannot be 0")
+	}
+	adjustmentDate, err := parseProtoTime("adjustment_date", in.AdjustmentDate)
+	if err != nil {
+		return nil, err
+	}
+	adjustment := &domain.StockAdjustment{
+		ProductUUID:    in.ProductUuid,
+		WarehouseUUID:  in.WarehouseUuid,
+		Quantity:       int(in.Quantity),
+		Reason:         domain.AdjustmentReason(in.Reason),
+		AdjustedBy:     in.AdjustedBy,
+		AdjustmentDate: adjustmentDate,
+		Notes:          in.Notes,
+	}
+	if err := h.adjustmentUseCase.Create(ctx, adjustment); err != nil {
+		return nil, err
+	}
+	return &pb.StockAdjustment{
+		Uuid:           adjustment.UUID,
+		ProductUuid:    adjustment.ProductUUID,
+		WarehouseUuid:  adjustment.WarehouseUUID,
+		Quantity:       int32(adjustment.Quantity),
+		PreviousQty:    int32(adjustment.PreviousQty),
+		NewQty:         int32(adjustment.NewQty),
+		Reason:         string(adjustment.Reason),
+		AdjustedBy:     adjustment.AdjustedBy,
+		AdjustmentDate: adjustment.AdjustmentDate.Format(time.RFC3339),
+		Notes:          adjustment.Notes,
+		CreatedAt:      adjustment.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
 func (h *MovementGRPCHandler) WatchMovements(req *pb.WatchMovementsRequest, stream pb.MovementService_WatchMovementsServer) error {
-	updates := h.movementRepository.SubscribeToChanges()
+	updates := h.bus.Subscribe(stream.Context(), domain.EventMovementCreated)
+	defer h.bus.Unsubscribe(updates)
 
-	ctx := context.Background()
+	ctx := stream.Context()
 
 	// Get initial movements
-	limit := int(req.Limit)
+	limit := h.pageSize.clamp(int(req.Limit))
 	initialMovements, err := h.movementRepository.GetAll(ctx, limit)
 	if err != nil {
 		return status.Errorf(codes.Internal, "Failed to get initial movements: %v", err)
@@ -52,31 +234,38 @@ func (h *MovementGRPCHandler) WatchMovements(req *pb.WatchMovementsRequest, stre
 	for {
 		select {
 		case <-stream.Context().Done():
-			log.Println("Movement client disconnected")
+			slog.DebugContext(ctx, "Movement client disconnected")
 			return nil
-		case movements := <-updates:
+		case <-h.shutdown:
+			return errShuttingDown
+		case <-updates.C():
+			updateCtx, span := startUpdateSpan(ctx, "MovementService.WatchMovements")
+			movements, err := h.movementRepository.GetAll(updateCtx, limit)
+			if err != nil {
+				slog.ErrorContext(updateCtx, "Failed to get movements", "error", err)
+				endUpdateSpan(span, err)
+				continue
+			}
+
 			// Convert to Proto
 			protoMovements := make([]*pb.StockMovement, 0, len(movements))
 			for _, m := range movements {
 				protoMovements = append(protoMovements, h.movementToProto(&m))
 			}
 
-			// Apply limit if specified
-			if limit > 0 && limit < len(protoMovements) {
-				protoMovements = protoMovements[:limit]
-			}
-
 			// Send update
 			update := &pb.MovementUpdate{
 				Movements: protoMovements,
 				Timestamp: time.Now().Format(time.RFC3339),
 			}
 
-			if err := stream.Send(update); err != nil {
-				log.Printf("Failed to send movement update: %v", err)
+			err = stream.Send(update)
+			endUpdateSpan(span, err)
+			if err != nil {
+				slog.WarnContext(updateCtx, "Failed to send movement update", "error", err)
 				return status.Errorf(codes.Internal, "Failed to send update: %v", err)
 			}
-			log.Printf("Sent movement update %d movements", len(protoMovements))
+			slog.DebugContext(updateCtx, "Sent movement update", "movements", len(protoMovements))
 		}
 	}
 }
@@ -98,6 +287,7 @@ func (h *MovementGRPCHandler) movementToProto(m *domain.StockMovement) *pb.Stock
 		MovementDate:     m.MovementDate.Format(time.RFC3339),
 		CreatedAt:        m.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:        m.UpdatedAt.Format(time.RFC3339),
+		DocumentUuid:     m.DocumentUUID,
 	}
 
 	// Add product if loaded
@@ -118,3 +308,43 @@ func (h *MovementGRPCHandler) movementToProto(m *domain.StockMovement) *pb.Stock
 
 	return proto
 }
+
+func (h *MovementGRPCHandler) movementsToProto(movements []domain.StockMovement) []*pb.StockMovement {
+	protoMovements := make([]*pb.StockMovement, len(movements))
+	for i, m := range movements {
+		protoMovements[i] = h.movementToProto(&m)
+	}
+	return protoMovements
+}
+
+// movementFilterFromProto reads the same filter as the REST ledger listing; dates are YYYY-MM-DD and both inclusive
+func (h *MovementGRPCHandler) movementFilterFromProto(req *pb.ListMovementsRequest) (domain.StockMovementFilter, error) {
+	filter := domain.StockMovementFilter{
+		WarehouseUUID:    req.WarehouseUuid,
+		ProductUUID:      req.ProductUuid,
+		ReferenceNumber:  req.ReferenceNumber,
+		AdjustmentReason: domain.AdjustmentReason(req.AdjustmentReason),
+		CreatedBy:        req.CreatedBy,
+		QuantitySign:     domain.QuantitySign(req.QuantitySign),
+	}
+	if req.StartDate != "" {
+		d, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return filter, domain.NewValidationError("start_date", "start_date must use YYYY-MM-DD")
+		}
+		filter.StartDate = d
+	}
+	if req.EndDate != "" {
+		d, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return filter, domain.NewValidationError("end_date", "end_date must use YYYY-MM-DD")
+		}
+		filter.EndDate = d.AddDate(0, 0, 1)
+	}
+	for _, movementType := range req.MovementTypes {
+		if movementType != "" && movementType != "ALL" {
+			filter.MovementTypes = append(filter.MovementTypes, domain.StockMovementType(movementType))
+		}
+	}
+	return filter, nil
+}
@@ -2,10 +2,13 @@ package handler
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"time"
 
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/eventbus"
 	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/internal/usecase"
 	pb "github.com/shirloin/stockhub/proto/product"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,20 +17,100 @@ import (
 type ProductGRPCHandler struct {
 	pb.UnimplementedProductServiceServer
 	productRepository *repository.ProductRepository
+	productUseCase    *usecase.ProductUseCase
+	shutdown          chan struct{}
+	pageSize          pageSize
+	bus               *eventbus.Bus
 }
 
-func NewProductGRPCHandler(productRepository *repository.ProductRepository) *ProductGRPCHandler {
-	return &ProductGRPCHandler{productRepository: productRepository}
+func NewProductGRPCHandler(productRepository *repository.ProductRepository, productUseCase *usecase.ProductUseCase) *ProductGRPCHandler {
+	return &ProductGRPCHandler{productRepository: productRepository, productUseCase: productUseCase}
+}
+
+func (h *ProductGRPCHandler) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	product, err := h.productUseCase.GetById(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return product.ToProto(), nil
+}
+
+func (h *ProductGRPCHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	filter := domain.ProductFilter{
+		Search:       req.Search,
+		CategoryUUID: req.CategoryUuid,
+		SupplierUUID: req.SupplierUuid,
+		StockStatus:  domain.ProductStockStatus(req.StockStatus),
+	}
+	if req.MinPrice != nil {
+		minPrice := int(req.GetMinPrice())
+		filter.MinPrice = &minPrice
+	}
+	if req.MaxPrice != nil {
+		maxPrice := int(req.GetMaxPrice())
+		filter.MaxPrice = &maxPrice
+	}
+	sort, err := domain.ParseProductSort(req.Sort)
+	if err != nil {
+		return nil, err
+	}
+	filter.Sort = sort
+
+	page, limit := h.pageSize.pageAndLimit(req.Page, req.Limit)
+	products, total, err := h.productUseCase.GetAllPaginated(ctx, filter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	protoProducts := make([]*pb.Product, len(products))
+	for i, p := range products {
+		protoProducts[i] = p.ToProto()
+	}
+	return &pb.ListProductsResponse{Products: protoProducts, Page: int32(page), Limit: int32(limit), Total: total}, nil
+}
+
+func (h *ProductGRPCHandler) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	if req.Product == nil {
+		return nil, domain.NewValidationError("product", "product is required")
+	}
+	product := domain.ProductFromProto(req.Product)
+	if err := h.productUseCase.Create(ctx, product); err != nil {
+		return nil, err
+	}
+	return product.ToProto(), nil
+}
+
+func (h *ProductGRPCHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	if req.Product == nil {
+		return nil, domain.NewValidationError("product", "product is required")
+	}
+	if err := h.productUseCase.Update(ctx, req.Uuid, domain.ProductFromProto(req.Product)); err != nil {
+		return nil, err
+	}
+	updated, err := h.productUseCase.GetById(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return updated.ToProto(), nil
+}
+
+func (h *ProductGRPCHandler) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if err := h.productUseCase.Delete(ctx, req.Uuid); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteProductResponse{}, nil
 }
 
 func (h *ProductGRPCHandler) WatchTopProductsByPrice(req *pb.WatchTopProductsByPriceRequest, stream pb.ProductService_WatchTopProductsByPriceServer) error {
-	updates := h.productRepository.SubscribeToChanges()
+	updates := h.bus.Subscribe(stream.Context(), productEvents...)
+	defer h.bus.Unsubscribe(updates)
 
-	ctx := context.Background()
+	ctx := stream.Context()
 	limit := int(req.Limit)
 	if limit <= 0 {
 		limit = 5 // Default to 5 if not specified
 	}
+	limit = h.pageSize.clamp(limit)
 
 	// Get initial top products by price
 	initialProducts, err := h.productRepository.GetTopByPrice(ctx, limit)
@@ -44,19 +127,23 @@ func (h *ProductGRPCHandler) WatchTopProductsByPrice(req *pb.WatchTopProductsByP
 	if err := stream.Send(&pb.PriceUpdate{Products: protoProducts, Timestamp: time.Now().Format(time.RFC3339)}); err != nil {
 		return status.Errorf(codes.Internal, "Failed to send initial top products: %v", err)
 	}
-	log.Printf("Sent initial top products by price %d products", len(protoProducts))
+	slog.DebugContext(ctx, "Sent initial top products by price", "products", len(protoProducts))
 
 	// Stream updates when products change
 	for {
 		select {
 		case <-stream.Context().Done():
-			log.Println("Top products by price client disconnected")
+			slog.DebugContext(ctx, "Top products by price client disconnected")
 			return nil
-		case <-updates:
+		case <-h.shutdown:
+			return errShuttingDown
+		case <-updates.C():
+			updateCtx, span := startUpdateSpan(ctx, "ProductService.WatchTopProductsByPrice")
 			// Product change detected, get updated top products by price
-			topProducts, err := h.productRepository.GetTopByPrice(ctx, limit)
+			topProducts, err := h.productRepository.GetTopByPrice(updateCtx, limit)
 			if err != nil {
-				log.Printf("Error getting top products by price: %v", err)
+				slog.ErrorContext(updateCtx, "Failed to get top products by price", "error", err)
+				endUpdateSpan(span, err)
 				continue
 			}
 
@@ -69,19 +156,22 @@ func (h *ProductGRPCHandler) WatchTopProductsByPrice(req *pb.WatchTopProductsByP
 			// Send update
 			update := &pb.PriceUpdate{Products: protoProducts, Timestamp: time.Now().Format(time.RFC3339)}
 
-			if err := stream.Send(update); err != nil {
-				log.Printf("Failed to send top products by price update: %v", err)
+			err = stream.Send(update)
+			endUpdateSpan(span, err)
+			if err != nil {
+				slog.WarnContext(updateCtx, "Failed to send top products by price update", "error", err)
 				return status.Errorf(codes.Internal, "Failed to send update: %v", err)
 			}
-			log.Printf("Sent top products by price update %d products", len(protoProducts))
+			slog.DebugContext(updateCtx, "Sent top products by price update", "products", len(protoProducts))
 		}
 	}
 }
 
 func (h *ProductGRPCHandler) WatchStockAlerts(req *pb.WatchStockAlertsRequest, stream pb.ProductService_WatchStockAlertsServer) error {
-	updates := h.productRepository.SubscribeToChanges()
+	updates := h.bus.Subscribe(stream.Context(), productEvents...)
+	defer h.bus.Unsubscribe(updates)
 
-	ctx := context.Background()
+	ctx := stream.Context()
 
 	// Send initial alerts
 	lowStockProducts, err := h.productRepository.GetLowStockProducts(ctx)
@@ -89,11 +179,6 @@ func (h *ProductGRPCHandler) WatchStockAlerts(req *pb.WatchStockAlertsRequest, s
 		return status.Errorf(codes.Internal, "Failed to get low stock products: %v", err)
 	}
 
-	log.Printf("Found %d low stock products from database", len(lowStockProducts))
-	for _, p := range lowStockProducts {
-		log.Printf("  - %s: stock=%d, threshold=%d", p.Title, p.Stock, p.LowStockThreshold)
-	}
-
 	protoAlerts := make([]*pb.StockAlert, len(lowStockProducts))
 	for i, product := range lowStockProducts {
 		alertType := "low_stock"
@@ -115,27 +200,26 @@ func (h *ProductGRPCHandler) WatchStockAlerts(req *pb.WatchStockAlertsRequest, s
 	if err := stream.Send(&pb.StockAlertUpdate{Alerts: protoAlerts, Timestamp: time.Now().Format(time.RFC3339)}); err != nil {
 		return status.Errorf(codes.Internal, "Failed to send initial stock alerts: %v", err)
 	}
-	log.Printf("Sent initial stock alerts %d alerts", len(protoAlerts))
+	slog.DebugContext(ctx, "Sent initial stock alerts", "alerts", len(protoAlerts))
 
 	// Stream updates when products change
 	for {
 		select {
 		case <-stream.Context().Done():
-			log.Println("Stock alert client disconnected")
+			slog.DebugContext(ctx, "Stock alert client disconnected")
 			return nil
-		case products := <-updates:
+		case <-h.shutdown:
+			return errShuttingDown
+		case <-updates.C():
+			updateCtx, span := startUpdateSpan(ctx, "ProductService.WatchStockAlerts")
 			// Product change detected, get updated low stock products
-			lowStockProducts, err := h.productRepository.GetLowStockProducts(ctx)
+			lowStockProducts, err := h.productRepository.GetLowStockProducts(updateCtx)
 			if err != nil {
-				log.Printf("Error getting low stock products: %v", err)
+				slog.ErrorContext(updateCtx, "Failed to get low stock products", "error", err)
+				endUpdateSpan(span, err)
 				continue
 			}
 
-			log.Printf("Found %d low stock products after product change (triggered by %d products)", len(lowStockProducts), len(products))
-			for _, p := range lowStockProducts {
-				log.Printf("  - %s: stock=%d, threshold=%d", p.Title, p.Stock, p.LowStockThreshold)
-			}
-
 			// Convert to Proto
 			protoAlerts := make([]*pb.StockAlert, len(lowStockProducts))
 			for i, product := range lowStockProducts {
@@ -156,12 +240,13 @@ func (h *ProductGRPCHandler) WatchStockAlerts(req *pb.WatchStockAlertsRequest, s
 
 			// Send update
 			update := &pb.StockAlertUpdate{Alerts: protoAlerts, Timestamp: time.Now().Format(time.RFC3339)}
-			log.Printf("Sending stock alerts update %d alerts", len(protoAlerts))
-			if err := stream.Send(update); err != nil {
-				log.Printf("Failed to send stock alerts update: %v", err)
+			err = stream.Send(update)
+			endUpdateSpan(span, err)
+			if err != nil {
+				slog.WarnContext(updateCtx, "Failed to send stock alerts update", "error", err)
 				return status.Errorf(codes.Internal, "Failed to send update: %v", err)
 			}
-			log.Printf("Sent stock alerts update %d alerts (triggered by %d product changes)", len(protoAlerts), len(products))
+			slog.DebugContext(updateCtx, "Sent stock alerts update", "alerts", len(protoAlerts))
 		}
 	}
 }
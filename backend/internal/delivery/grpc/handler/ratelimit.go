@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimitUnaryInterceptor charges unary calls to the read or write budget of the caller,
+// failing them with RESOURCE_EXHAUSTED and a RetryInfo detail once it is spent. Health checks
+// and reflection are exempt. It runs after authentication, see ratelimit.Key.
+func RateLimitUnaryInterceptor(limiter *ratelimit.Limiter, trustForwardedFor bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if ratelimit.Exempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		class := ratelimit.ClassifyProcedure(info.FullMethod)
+		if ok, retryAfter := limiter.Allow(grpcClientKey(ctx, trustForwardedFor), class); !ok {
+			return nil, rateLimitedStatus("grpc", class, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor charges streams to the stream budget of the caller and caps how
+// many it may hold open at once
+func RateLimitStreamInterceptor(limiter *ratelimit.Limiter, trustForwardedFor bool) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if ratelimit.Exempt(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		release, retryAfter, ok := limiter.OpenStream(grpcClientKey(stream.Context(), trustForwardedFor))
+		if !ok {
+			return rateLimitedStatus("grpc", ratelimit.ClassStream, retryAfter)
+		}
+		defer release()
+		return handler(srv, stream)
+	}
+}
+
+// grpcClientKey identifies the caller by the API key that authenticated it, or else its
+// address
+func grpcClientKey(ctx context.Context, trustForwardedFor bool) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var ip string
+	if trustForwardedFor {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			ip = ratelimit.ForwardedIP(values[0])
+		}
+	}
+	if ip == "" {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			ip = ratelimit.AddrIP(p.Addr.String())
+		}
+	}
+	return ratelimit.Key(ctx, ip)
+}
+
+func rateLimitedStatus(transport string, class ratelimit.Class, retryAfter time.Duration) error {
+	metrics.RateLimited.WithLabelValues(transport, string(class)).Inc()
+	seconds := ratelimit.RetryAfterSeconds(retryAfter)
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded, retry in %ds", seconds))
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(seconds) * time.Second)}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// ConnectRateLimitInterceptor does the same as the gRPC interceptors for the Connect handlers,
+// also setting Retry-After on the response
+func ConnectRateLimitInterceptor(limiter *ratelimit.Limiter, trustForwardedFor bool) connect.Interceptor {
+	return &connectRateLimitInterceptor{limiter: limiter, trustForwardedFor: trustForwardedFor}
+}
+
+type connectRateLimitInterceptor struct {
+	limiter           *ratelimit.Limiter
+	trustForwardedFor bool
+}
+
+func (i *connectRateLimitInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		class := ratelimit.ClassifyProcedure(req.Spec().Procedure)
+		if ok, retryAfter := i.limiter.Allow(i.clientKey(ctx, req.Header(), req.Peer()), class); !ok {
+			return nil, rateLimitedConnectError(class, retryAfter)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *connectRateLimitInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *connectRateLimitInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		release, retryAfter, ok := i.limiter.OpenStream(i.clientKey(ctx, conn.RequestHeader(), conn.Peer()))
+		if !ok {
+			return rateLimitedConnectError(ratelimit.ClassStream, retryAfter)
+		}
+		defer release()
+		return next(ctx, conn)
+	}
+}
+
+func (i *connectRateLimitInterceptor) clientKey(ctx context.Context, header http.Header, p connect.Peer) string {
+	return ratelimit.HeaderKey(ctx, header, p.Addr, i.trustForwardedFor)
+}
+
+func rateLimitedConnectError(class ratelimit.Class, retryAfter time.Duration) error {
+	metrics.RateLimited.WithLabelValues("connect", string(class)).Inc()
+	seconds := ratelimit.RetryAfterSeconds(retryAfter)
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("rate limit exceeded, retry in %ds", seconds))
+	if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(seconds) * time.Second)}); detailErr == nil {
+		err.AddDetail(detail)
+	}
+	err.Meta().Set("Retry-After", strconv.Itoa(seconds))
+	return err
+}
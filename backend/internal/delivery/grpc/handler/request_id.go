@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadata is the gRPC metadata key matching the X-Request-ID HTTP header
+var requestIDMetadata = strings.ToLower(logging.RequestIDHeader)
+
+// requestContext assigns the call a request ID, honouring x-request-id metadata, and
+// returns it in the response headers
+func requestContext(ctx context.Context) context.Context {
+	var incoming string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadata); len(values) > 0 {
+			incoming = values[0]
+		}
+	}
+	requestID := logging.NewRequestID(incoming)
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestID))
+	return logging.WithRequestID(ctx, requestID)
+}
+
+// logCall logs a finished call at info level, or error level for server-side failures
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if isServerError(code) {
+		level = slog.LevelError
+	}
+	slog.Log(ctx, level, "gRPC request", "method", method, "code", code.String(), "duration", time.Since(start))
+}
+
+// isServerError reports codes that mean the server failed rather than the caller
+func isServerError(code codes.Code) bool {
+	return code == codes.Internal || code == codes.Unknown || code == codes.DataLoss
+}
+
+// RequestIDUnaryInterceptor tags unary calls with a request ID and logs them
+func RequestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = requestContext(ctx)
+		start := time.Now()
+		res, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// RequestIDStreamInterceptor tags streams with a request ID and logs them when they end
+func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := requestContext(stream.Context())
+		start := time.Now()
+		err := handler(srv, &contextServerStream{ServerStream: stream, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/shirloin/stockhub/internal/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startUpdateSpan starts the span of one update pushed on a Watch stream, as a child of the
+// stream's span, so the queries behind each update are grouped together rather than piling
+// up under a span that lasts as long as the client stays connected
+func startUpdateSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, method+" update")
+}
+
+// endUpdateSpan ends an update span, marking it failed when err is set
+func endUpdateSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
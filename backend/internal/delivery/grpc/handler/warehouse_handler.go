@@ -2,10 +2,11 @@ package handler
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/eventbus"
 	"github.com/shirloin/stockhub/internal/repository"
 	"github.com/shirloin/stockhub/internal/usecase"
 	pb "github.com/shirloin/stockhub/proto/warehouse"
@@ -18,6 +19,9 @@ type WarehouseGRPCHandler struct {
 	warehouseRepository      *repository.WarehouseRepository
 	warehouseStockRepository *repository.WarehouseStockRepository
 	warehouseUseCase         *usecase.WarehouseUseCase
+	shutdown                 chan struct{}
+	pageSize                 pageSize
+	bus                      *eventbus.Bus
 }
 
 func NewWarehouseGRPCHandler(
@@ -32,15 +36,117 @@ func NewWarehouseGRPCHandler(
 	}
 }
 
+func (h *WarehouseGRPCHandler) GetWarehouse(ctx context.Context, req *pb.GetWarehouseRequest) (*pb.Warehouse, error) {
+	warehouse, err := h.warehouseUseCase.GetByID(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return h.warehouseToProto(warehouse), nil
+}
+
+func (h *WarehouseGRPCHandler) ListWarehouses(ctx context.Context, req *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+	page, limit := h.pageSize.pageAndLimit(req.Page, req.Limit)
+
+	var protoWarehouses []*pb.WarehouseWithMetrics
+	var total int64
+	if req.IncludeMetrics {
+		warehouses, count, err := h.warehouseUseCase.GetAllWithMetricsPaginated(ctx, page, limit)
+		if err != nil {
+			return nil, err
+		}
+		protoWarehouses = make([]*pb.WarehouseWithMetrics, len(warehouses))
+		for i, w := range warehouses {
+			proto := h.warehouseToProtoWithMetrics(w)
+			protoWarehouses[i] = &proto
+		}
+		total = count
+	} else {
+		warehouses, count, err := h.warehouseUseCase.GetAllPaginated(ctx, page, limit)
+		if err != nil {
+			return nil, err
+		}
+		protoWarehouses = make([]*pb.WarehouseWithMetrics, len(warehouses))
+		for i, w := range warehouses {
+			protoWarehouses[i] = &pb.WarehouseWithMetrics{Warehouse: h.warehouseToProto(&w)}
+		}
+		total = count
+	}
+
+	return &pb.ListWarehousesResponse{Warehouses: protoWarehouses, Page: int32(page), Limit: int32(limit), Total: total}, nil
+}
+
+func (h *WarehouseGRPCHandler) CreateWarehouse(ctx context.Context, req *pb.CreateWarehouseRequest) (*pb.Warehouse, error) {
+	if req.Warehouse == nil {
+		return nil, domain.NewValidationError("warehouse", "warehouse is required")
+	}
+	warehouse := h.warehouseFromProto(req.Warehouse)
+	if err := h.warehouseUseCase.Create(ctx, warehouse); err != nil {
+		return nil, err
+	}
+	return h.warehouseToProto(warehouse), nil
+}
+
+func (h *WarehouseGRPCHandler) UpdateWarehouse(ctx context.Context, req *pb.UpdateWarehouseRequest) (*pb.Warehouse, error) {
+	if req.Warehouse == nil {
+		return nil, domain.NewValidationError("warehouse", "warehouse is required")
+	}
+	if err := h.warehouseUseCase.Update(ctx, req.Uuid, h.warehouseFromProto(req.Warehouse)); err != nil {
+		return nil, err
+	}
+	updated, err := h.warehouseUseCase.GetByID(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return h.warehouseToProto(updated), nil
+}
+
+func (h *WarehouseGRPCHandler) DeleteWarehouse(ctx context.Context, req *pb.DeleteWarehouseRequest) (*pb.DeleteWarehouseResponse, error) {
+	if err := h.warehouseUseCase.Delete(ctx, req.Uuid); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteWarehouseResponse{}, nil
+}
+
+func (h *WarehouseGRPCHandler) TransferStock(ctx context.Context, req *pb.TransferStockRequest) (*pb.StockTransfer, error) {
+	transferDate, err := parseProtoTime("transfer_date", req.TransferDate)
+	if err != nil {
+		return nil, err
+	}
+	transfer := &domain.StockTransfer{
+		ProductUUID:       req.ProductUuid,
+		FromWarehouseUUID: req.FromWarehouseUuid,
+		ToWarehouseUUID:   req.ToWarehouseUuid,
+		Quantity:          int(req.Quantity),
+		TransferDate:      transferDate,
+		Notes:             req.Notes,
+	}
+	if err := h.warehouseUseCase.TransferStock(ctx, transfer); err != nil {
+		return nil, err
+	}
+	return &pb.StockTransfer{
+		Uuid:              transfer.UUID,
+		ProductUuid:       transfer.ProductUUID,
+		FromWarehouseUuid: transfer.FromWarehouseUUID,
+		ToWarehouseUuid:   transfer.ToWarehouseUUID,
+		Quantity:          int32(transfer.Quantity),
+		TransferDate:      transfer.TransferDate.Format(time.RFC3339),
+		Notes:             transfer.Notes,
+		CreatedAt:         transfer.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
 func (h *WarehouseGRPCHandler) WatchWarehouses(req *pb.WatchWarehousesRequest, stream pb.WarehouseService_WatchWarehousesServer) error {
-	updates := h.warehouseRepository.SubscribeToChanges()
+	// Stock movements change utilization
+	updates := h.bus.Subscribe(stream.Context(), append(warehouseEvents, domain.EventMovementCreated)...)
+	defer h.bus.Unsubscribe(updates)
 
-	ctx := context.Background()
+	ctx := stream.Context()
+	limit := h.pageSize.clamp(int(req.Limit))
 
 	// Get initial warehouses with metrics if requested
 	var initialWarehouses []*pb.WarehouseWithMetrics
 	if req.IncludeMetrics {
-		warehouses, err := h.warehouseUseCase.GetAllWithMetrics(ctx, int(req.Limit))
+		warehouses, err := h.warehouseUseCase.GetAllWithMetrics(ctx, limit)
 		if err != nil {
 			return status.Errorf(codes.Internal, "Failed to get initial warehouses: %v", err)
 		}
@@ -87,15 +193,19 @@ func (h *WarehouseGRPCHandler) WatchWarehouses(req *pb.WatchWarehousesRequest, s
 	for {
 		select {
 		case <-stream.Context().Done():
-			log.Println("Warehouse client disconnected")
+			slog.DebugContext(ctx, "Warehouse client disconnected")
 			return nil
-		case warehouses := <-updates:
+		case <-h.shutdown:
+			return errShuttingDown
+		case <-updates.C():
+			updateCtx, span := startUpdateSpan(ctx, "WarehouseService.WatchWarehouses")
 			// If metrics are requested, get warehouses with metrics sorted by utilization
 			var protoWarehouses []*pb.WarehouseWithMetrics
 			if req.IncludeMetrics {
-				warehousesWithMetrics, err := h.warehouseUseCase.GetAllWithMetrics(ctx, int(req.Limit))
+				warehousesWithMetrics, err := h.warehouseUseCase.GetAllWithMetrics(updateCtx, limit)
 				if err != nil {
-					log.Printf("Failed to get warehouses with metrics: %v", err)
+					slog.ErrorContext(updateCtx, "Failed to get warehouses with metrics", "error", err)
+					endUpdateSpan(span, err)
 					continue
 				}
 				protoWarehouses = make([]*pb.WarehouseWithMetrics, len(warehousesWithMetrics))
@@ -104,10 +214,16 @@ func (h *WarehouseGRPCHandler) WatchWarehouses(req *pb.WatchWarehousesRequest, s
 					protoWarehouses[i] = &proto
 				}
 			} else {
+				warehouses, err := h.warehouseRepository.GetAll(updateCtx)
+				if err != nil {
+					slog.ErrorContext(updateCtx, "Failed to get warehouses", "error", err)
+					endUpdateSpan(span, err)
+					continue
+				}
 				// Convert to Proto with metrics (calculate on the fly)
 				protoWarehouses = make([]*pb.WarehouseWithMetrics, 0, len(warehouses))
 				for _, w := range warehouses {
-					totalStock, _ := h.warehouseStockRepository.GetTotalStockByWarehouse(ctx, w.UUID)
+					totalStock, _ := h.warehouseStockRepository.GetTotalStockByWarehouse(updateCtx, w.UUID)
 					var utilization float64
 					if w.Capacity > 0 {
 						utilization = (float64(totalStock) / float64(w.Capacity)) * 100
@@ -129,11 +245,13 @@ func (h *WarehouseGRPCHandler) WatchWarehouses(req *pb.WatchWarehousesRequest, s
 				Timestamp:  time.Now().Format(time.RFC3339),
 			}
 
-			if err := stream.Send(update); err != nil {
-				log.Printf("Failed to send warehouse update: %v", err)
+			err := stream.Send(update)
+			endUpdateSpan(span, err)
+			if err != nil {
+				slog.WarnContext(updateCtx, "Failed to send warehouse update", "error", err)
 				return status.Errorf(codes.Internal, "Failed to send update: %v", err)
 			}
-			log.Printf("Sent warehouse update %d warehouses", len(protoWarehouses))
+			slog.DebugContext(updateCtx, "Sent warehouse update", "warehouses", len(protoWarehouses))
 		}
 	}
 }
@@ -157,6 +275,23 @@ func (h *WarehouseGRPCHandler) warehouseToProto(w *domain.Warehouse) *pb.Warehou
 	}
 }
 
+// warehouseFromProto copies the editable fields of a proto warehouse; uuid and timestamps are ignored
+func (h *WarehouseGRPCHandler) warehouseFromProto(w *pb.Warehouse) *domain.Warehouse {
+	return &domain.Warehouse{
+		Name:         w.GetName(),
+		Address:      w.GetAddress(),
+		City:         w.GetCity(),
+		State:        w.GetState(),
+		Country:      w.GetCountry(),
+		PostalCode:   w.GetPostalCode(),
+		ManagerName:  w.GetManagerName(),
+		ManagerEmail: w.GetManagerEmail(),
+		ManagerPhone: w.GetManagerPhone(),
+		Capacity:     int(w.GetCapacity()),
+		IsActive:     w.GetIsActive(),
+	}
+}
+
 func (h *WarehouseGRPCHandler) warehouseToProtoWithMetrics(w domain.WarehouseWithMetrics) pb.WarehouseWithMetrics {
 	return pb.WarehouseWithMetrics{
 		Warehouse:   h.warehouseToProto(&w.Warehouse),
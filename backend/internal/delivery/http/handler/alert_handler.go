@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+type AlertHandler struct {
+	alertUsecase *usecase.AlertUseCase
+	pagination   Pagination
+}
+
+func NewAlertHandler(alertUsecase *usecase.AlertUseCase) *AlertHandler {
+	return &AlertHandler{alertUsecase: alertUsecase}
+}
+
+func (h *AlertHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	// Leaving enabled out creates an enabled rule
+	rule := domain.AlertRule{Enabled: true}
+
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.alertUsecase.CreateRule(r.Context(), &rule); err != nil {
+		writeError(w, r, err, "Failed to create alert rule")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "Alert rule created successfully", rule)
+}
+
+func (h *AlertHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.alertUsecase.GetRules(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to get alert rules")
+		return
+	}
+	response.Success(w, http.StatusOK, "Alert rules fetched successfully", rules)
+}
+
+func (h *AlertHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	rule, err := h.alertUsecase.GetRuleByID(r.Context(), uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to get alert rule")
+		return
+	}
+	response.Success(w, http.StatusOK, "Alert rule fetched successfully", rule)
+}
+
+func (h *AlertHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	// Leaving enabled out keeps the rule enabled rather than switching it off
+	rule := domain.AlertRule{Enabled: true}
+
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.alertUsecase.UpdateRule(r.Context(), uuid, &rule); err != nil {
+		writeError(w, r, err, "Failed to update alert rule")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Alert rule updated successfully", rule)
+}
+
+func (h *AlertHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	if err := h.alertUsecase.DeleteRule(r.Context(), uuid); err != nil {
+		writeError(w, r, err, "Failed to delete alert rule")
+		return
+	}
+	response.Success(w, http.StatusOK, "Alert rule deleted successfully", nil)
+}
+
+// GetAll returns the alerts, newest first, always paginated
+func (h *AlertHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	page, limit, _ := h.pagination.parse(r)
+	query := r.URL.Query()
+	filter := domain.AlertFilter{
+		Status:        domain.AlertStatus(query.Get("status")),
+		Type:          domain.AlertRuleType(query.Get("type")),
+		RuleUUID:      query.Get("ruleUuid"),
+		ProductUUID:   query.Get("productUuid"),
+		WarehouseUUID: query.Get("warehouseUuid"),
+	}
+	alerts, total, err := h.alertUsecase.GetAll(r.Context(), filter, page, limit)
+	if err != nil {
+		writeError(w, r, err, "Failed to get alerts")
+		return
+	}
+	response.PaginatedSuccess(w, http.StatusOK, "Alerts fetched successfully", page, limit, total, alerts)
+}
+
+// GetById returns the alert with its history
+func (h *AlertHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	alert, err := h.alertUsecase.GetByID(r.Context(), uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to get alert")
+		return
+	}
+	response.Success(w, http.StatusOK, "Alert fetched successfully", alert)
+}
+
+func (h *AlertHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.alertUsecase.Acknowledge, "acknowledge", "acknowledged")
+}
+
+func (h *AlertHandler) Snooze(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.alertUsecase.Snooze, "snooze", "snoozed")
+}
+
+func (h *AlertHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	h.act(w, r, h.alertUsecase.Resolve, "resolve", "resolved")
+}
+
+// act applies one of the alert actions, whose body is optional
+func (h *AlertHandler) act(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, uuid string, action domain.AlertAction) (*domain.Alert, error), verb, done string) {
+	uuid := mux.Vars(r)["uuid"]
+	var action domain.AlertAction
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&action); err != nil && !errors.Is(err, io.EOF) {
+			response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	alert, err := apply(r.Context(), uuid, action)
+	if err != nil {
+		writeError(w, r, err, "Failed to "+verb+" alert")
+		return
+	}
+	response.Success(w, http.StatusOK, "Alert "+done+" successfully", alert)
+}
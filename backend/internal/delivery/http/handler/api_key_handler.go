@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// APIKeyHandler serves the admin API for API keys and authenticates the requests made with them
+type APIKeyHandler struct {
+	apiKeyUsecase *usecase.APIKeyUseCase
+	auth          Auth
+}
+
+func NewAPIKeyHandler(apiKeyUsecase *usecase.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUsecase: apiKeyUsecase}
+}
+
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var key domain.APIKey
+
+	if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	issued, err := h.apiKeyUsecase.Create(r.Context(), &key)
+	if err != nil {
+		writeError(w, r, err, "Failed to create API key")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "API key created successfully; store the key now, it is not shown again", issued)
+}
+
+func (h *APIKeyHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyUsecase.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to get API keys")
+		return
+	}
+	response.Success(w, http.StatusOK, "API keys fetched successfully", keys)
+}
+
+func (h *APIKeyHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	key, err := h.apiKeyUsecase.GetByID(r.Context(), uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to get API key")
+		return
+	}
+	response.Success(w, http.StatusOK, "API key fetched successfully", key)
+}
+
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	issued, err := h.apiKeyUsecase.Rotate(r.Context(), uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to rotate API key")
+		return
+	}
+	response.Success(w, http.StatusOK, "API key rotated successfully; store the key now, it is not shown again", issued)
+}
+
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	if err := h.apiKeyUsecase.Revoke(r.Context(), uuid); err != nil {
+		writeError(w, r, err, "Failed to revoke API key")
+		return
+	}
+	response.Success(w, http.StatusOK, "API key revoked successfully", nil)
+}
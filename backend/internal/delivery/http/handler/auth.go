@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+)
+
+// Auth configures how REST requests are authenticated
+type Auth struct {
+	AdminToken    string // Bearer token for /api/admin; the admin API is disabled when empty
+	RequireAPIKey bool   // Reject requests without an API key, except for the docs and the admin API
+}
+
+// APIKeyHeader carries API keys; a bearer token that looks like a key is accepted as well
+const APIKeyHeader = "X-API-Key"
+
+// routeScope is the scope an API key needs for the routes under prefix. A {name} segment of the
+// prefix matches any one segment of the path.
+type routeScope struct {
+	prefix string
+	read   domain.APIKeyScope // GET and HEAD
+	write  domain.APIKeyScope // Every other method
+}
+
+// routeScopes is checked in order, so more specific prefixes come first
+var routeScopes = []routeScope{
+	{"/api/warehouses/transfer", "", domain.ScopeStockTransfer},
+	{"/api/warehouses/stock", "", domain.ScopeStockIn},
+	{"/api/warehouses/{uuid}/stock", domain.ScopeStockRead, ""},
+	{"/api/products", domain.ScopeCatalogRead, domain.ScopeCatalogWrite},
+	{"/api/categories", domain.ScopeCatalogRead, domain.ScopeCatalogWrite},
+	{"/api/suppliers", domain.ScopeCatalogRead, domain.ScopeCatalogWrite},
+	{"/api/warehouses", domain.ScopeCatalogRead, domain.ScopeCatalogWrite},
+	{"/api/stock-movements", domain.ScopeStockRead, ""},
+	{"/api/stock-in", domain.ScopeStockRead, domain.ScopeStockIn},
+	{"/api/receipts", domain.ScopeStockRead, domain.ScopeStockIn},
+	{"/api/stock-out", domain.ScopeStockRead, domain.ScopeStockOut},
+	{"/api/shipments", domain.ScopeStockRead, domain.ScopeStockOut},
+	{"/api/stock-adjustments", domain.ScopeStockRead, domain.ScopeStockAdjust},
+	{"/api/imports", "", domain.ScopeImport},
+	{"/api/notifications", domain.ScopeCatalogRead, ""},
+	{"/api/notification-preferences", domain.ScopeCatalogRead, domain.ScopeCatalogWrite},
+	{"/api/alert-rules", domain.ScopeCatalogRead, domain.ScopeCatalogWrite},
+	// Whoever can see alerts can acknowledge, snooze and resolve them
+	{"/api/alerts", domain.ScopeStockRead, domain.ScopeStockRead},
+}
+
+// openPaths need no API key even when one is required
+var openPaths = []string{"/api/openapi.json", "/api/docs"}
+
+const adminPrefix = "/api/admin/"
+
+// requiredScope returns the scope an API key needs for the request, or false when keys may
+// not call it at all
+func requiredScope(method string, path string) (domain.APIKeyScope, bool) {
+	for _, route := range routeScopes {
+		if !matchesPrefix(path, route.prefix) {
+			continue
+		}
+		scope := route.write
+		if method == http.MethodGet || method == http.MethodHead {
+			scope = route.read
+		}
+		return scope, scope != ""
+	}
+	return "", false
+}
+
+// matchesPrefix reports whether path is prefix or lies below it, segment by segment
+func matchesPrefix(path string, prefix string) bool {
+	pathSegments := strings.Split(path, "/")
+	prefixSegments := strings.Split(prefix, "/")
+	if len(pathSegments) < len(prefixSegments) {
+		return false
+	}
+	for i, segment := range prefixSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// apiKeyCredential returns the API key presented in the X-API-Key header or as a bearer token
+func apiKeyCredential(header http.Header) string {
+	if key := header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer "); ok && usecase.IsAPIKey(token) {
+		return token
+	}
+	return ""
+}
+
+// Authenticate identifies requests made with an API key and rejects those the key's scopes do
+// not cover. The key travels in the request context, so stock it posts is checked against its
+// warehouses and attributed to it, and the request only sees the key's organisation. Requests
+// without a key act for the default organisation, unless RequireAPIKey turns them away.
+func (h *APIKeyHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credential := apiKeyCredential(r.Header)
+		if credential == "" {
+			if h.auth.RequireAPIKey && !strings.HasPrefix(r.URL.Path, adminPrefix) && !isOpenPath(r.URL.Path) {
+				writeError(w, r, domain.ErrAPIKeyRequired, "Failed to authenticate")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(domain.WithOrganization(r.Context(), domain.DefaultOrganizationUUID)))
+			return
+		}
+
+		key, err := h.apiKeyUsecase.Authenticate(r.Context(), credential)
+		if err != nil {
+			writeError(w, r, err, "Failed to authenticate")
+			return
+		}
+		if !isOpenPath(r.URL.Path) {
+			scope, ok := requiredScope(r.Method, r.URL.Path)
+			if !ok {
+				writeError(w, r, domain.NewForbiddenError("API keys cannot call "+r.Method+" "+r.URL.Path), "Failed to authorize")
+				return
+			}
+			if err := key.RequireScope(scope); err != nil {
+				writeError(w, r, err, "Failed to authorize")
+				return
+			}
+		}
+		ctx := domain.WithOrganization(domain.WithAPIKey(r.Context(), key), key.OrganizationUUID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireAdmin only lets requests bearing the admin token through; they manage every
+// organisation, so they see the records of all of them
+func (h *APIKeyHandler) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.auth.AdminToken == "" {
+			writeError(w, r, domain.ErrAdminDisabled, "Failed to authorize")
+			return
+		}
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.auth.AdminToken)) != 1 {
+			writeError(w, r, domain.ErrAdminTokenInvalid, "Failed to authorize")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(domain.WithAllOrganizations(r.Context())))
+	})
+}
+
+func isOpenPath(path string) bool {
+	for _, open := range openPaths {
+		if path == open {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method  string
+		path    string
+		scope   domain.APIKeyScope
+		allowed bool
+	}{
+		{http.MethodGet, "/api/warehouses", domain.ScopeCatalogRead, true},
+		{http.MethodGet, "/api/warehouses/8b0f7c1e-7d7e-4a59-9a55-0d3c6f1f2a10", domain.ScopeCatalogRead, true},
+		{http.MethodPut, "/api/warehouses/8b0f7c1e-7d7e-4a59-9a55-0d3c6f1f2a10", domain.ScopeCatalogWrite, true},
+		{http.MethodGet, "/api/warehouses/8b0f7c1e-7d7e-4a59-9a55-0d3c6f1f2a10/stock", domain.ScopeStockRead, true},
+		{http.MethodGet, "/api/warehouses/8b0f7c1e-7d7e-4a59-9a55-0d3c6f1f2a10/stock/export", domain.ScopeStockRead, true},
+		{http.MethodPost, "/api/warehouses/8b0f7c1e-7d7e-4a59-9a55-0d3c6f1f2a10/stock", "", false},
+		{http.MethodPost, "/api/warehouses/stock", domain.ScopeStockIn, true},
+		{http.MethodPost, "/api/warehouses/transfer", domain.ScopeStockTransfer, true},
+		{http.MethodGet, "/api/stock-movements", domain.ScopeStockRead, true},
+		{http.MethodGet, "/api/stock-movements-archive", "", false},
+		{http.MethodPost, "/api/imports/products", domain.ScopeImport, true},
+		{http.MethodGet, "/api/unknown", "", false},
+	}
+	for _, tt := range tests {
+		scope, allowed := requiredScope(tt.method, tt.path)
+		if scope != tt.scope || allowed != tt.allowed {
+			t.Errorf("requiredScope(%s, %s) = %q, %v, want %q, %v", tt.method, tt.path, scope, allowed, tt.scope, tt.allowed)
+		}
+	}
+}
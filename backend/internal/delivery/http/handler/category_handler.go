@@ -3,7 +3,6 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/shirloin/stockhub/internal/domain"
@@ -13,6 +12,7 @@ import (
 
 type CategoryHandler struct {
 	categoryUsecase *usecase.CategoryUseCase
+	pagination      Pagination
 }
 
 func NewCategoryHandler(categoryUsecase *usecase.CategoryUseCase) *CategoryHandler {
@@ -28,11 +28,7 @@ func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.categoryUsecase.Create(r.Context(), &category); err != nil {
-		if err == domain.ErrCategoryNameRequired {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create category: "+err.Error())
+		writeError(w, r, err, "Failed to create category")
 		return
 	}
 
@@ -40,29 +36,13 @@ func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *CategoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	// Check for pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := 1
-	limit := 10 // Default limit
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
+	page, limit, paginated := h.pagination.parse(r)
 
 	// If pagination is requested
-	if pageStr != "" || (limitStr != "" && limit != 10) {
+	if paginated {
 		categories, total, err := h.categoryUsecase.GetAllPaginated(r.Context(), page, limit)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get categories: "+err.Error())
+			writeError(w, r, err, "Failed to get categories")
 			return
 		}
 		response.PaginatedSuccess(w, http.StatusOK, "Categories fetched successfully", page, limit, total, categories)
@@ -70,9 +50,9 @@ func (h *CategoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fallback to non-paginated response
-	categories, err := h.categoryUsecase.GetAll(r.Context())
+	categories, err := h.categoryUsecase.GetAll(r.Context(), h.pagination.listLimit())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get categories: "+err.Error())
+		writeError(w, r, err, "Failed to get categories")
 		return
 	}
 	response.Success(w, http.StatusOK, "Categories fetched successfully", categories)
@@ -82,11 +62,7 @@ func (h *CategoryHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	category, err := h.categoryUsecase.GetByID(r.Context(), uuid)
 	if err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Category not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to get category: "+err.Error())
+		writeError(w, r, err, "Failed to get category")
 		return
 	}
 	response.Success(w, http.StatusOK, "Category fetched successfully", category)
@@ -100,21 +76,13 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.categoryUsecase.Update(r.Context(), uuid, &category); err != nil {
-		if err == domain.ErrCategoryNameRequired {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Category not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to update category: "+err.Error())
+		writeError(w, r, err, "Failed to update category")
 		return
 	}
 
 	updatedCategory, err := h.categoryUsecase.GetByID(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to fetch updated category")
+		writeError(w, r, err, "Failed to fetch updated category")
 		return
 	}
 
@@ -124,11 +92,7 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	if err := h.categoryUsecase.Delete(r.Context(), uuid); err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Category not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to delete category: "+err.Error())
+		writeError(w, r, err, "Failed to delete category")
 		return
 	}
 	response.Success(w, http.StatusOK, "Category deleted successfully", nil)
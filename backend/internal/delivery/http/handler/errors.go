@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// writeError translates a use case error into the response status and error code. Errors
+// without a domain error in their chain are internal: the cause is logged with the request ID
+// and the client only gets fallback, so database errors are not leaked.
+func writeError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	domainErr := domain.AsError(err)
+	if domainErr == nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			response.Error(w, http.StatusGatewayTimeout, fallback+": request timed out")
+			return
+		}
+		slog.ErrorContext(r.Context(), fallback, "error", err)
+		response.ErrorWithCode(w, http.StatusInternalServerError, string(domain.ErrorCodeInternal), fallback+": internal error", nil)
+		return
+	}
+
+	var details interface{}
+	if len(domainErr.Fields) > 0 {
+		details = domainErr.Fields
+	}
+	response.ErrorWithCode(w, httpStatus(domainErr.Code), string(domainErr.Code), err.Error(), details)
+}
+
+// httpStatus maps a domain error code to its HTTP status
+func httpStatus(code domain.ErrorCode) int {
+	switch code {
+	case domain.ErrorCodeNotFound:
+		return http.StatusNotFound
+	case domain.ErrorCodeValidation:
+		return http.StatusBadRequest
+	case domain.ErrorCodeConflict,
+		domain.ErrorCodeInsufficientStock,
+		domain.ErrorCodeCapacityExceeded:
+		return http.StatusConflict
+	case domain.ErrorCodeUnauthenticated:
+		return http.StatusUnauthorized
+	case domain.ErrorCodeForbidden:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
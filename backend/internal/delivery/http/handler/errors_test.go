@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+func TestWriteErrorHidesInternalCauses(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+	writeError(rec, req, errors.New(`ERROR: relation "products" does not exist (SQLSTATE 42P01)`), "Failed to get products")
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "SQLSTATE") || strings.Contains(body, "relation") {
+		t.Errorf("body leaks the cause: %s", body)
+	}
+	if !strings.Contains(body, `"code":"`+string(domain.ErrorCodeInternal)+`"`) {
+		t.Errorf("body = %s, want the internal error code", body)
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+	"github.com/shirloin/stockhub/pkg/spreadsheet"
+)
+
+type ExportHandler struct {
+	exportUseCase *usecase.ExportUseCase
+}
+
+func NewExportHandler(exportUseCase *usecase.ExportUseCase) *ExportHandler {
+	return &ExportHandler{exportUseCase: exportUseCase}
+}
+
+func (h *ExportHandler) StockMovements(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStockMovementFilter(r)
+	if err == nil {
+		err = filter.Validate()
+	}
+	if err != nil {
+		writeError(w, r, err, "Invalid export filter")
+		return
+	}
+
+	writeExport(w, r, "stock-movements", func(out spreadsheet.Writer) error {
+		return h.exportUseCase.ExportStockMovements(r.Context(), filter, out)
+	})
+}
+
+func (h *ExportHandler) StockIns(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStockRecordFilter(r)
+	if err != nil {
+		writeError(w, r, err, "Invalid export filter")
+		return
+	}
+	writeExport(w, r, "stock-in", func(out spreadsheet.Writer) error {
+		return h.exportUseCase.ExportStockIns(r.Context(), filter, out)
+	})
+}
+
+func (h *ExportHandler) StockOuts(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStockRecordFilter(r)
+	if err != nil {
+		writeError(w, r, err, "Invalid export filter")
+		return
+	}
+	writeExport(w, r, "stock-out", func(out spreadsheet.Writer) error {
+		return h.exportUseCase.ExportStockOuts(r.Context(), filter, out)
+	})
+}
+
+func (h *ExportHandler) StockAdjustments(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStockRecordFilter(r)
+	if err != nil {
+		writeError(w, r, err, "Invalid export filter")
+		return
+	}
+	filter.Reason = domain.AdjustmentReason(r.URL.Query().Get("reason"))
+	writeExport(w, r, "stock-adjustments", func(out spreadsheet.Writer) error {
+		return h.exportUseCase.ExportStockAdjustments(r.Context(), filter, out)
+	})
+}
+
+func (h *ExportHandler) WarehouseStock(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	writeExport(w, r, "warehouse-stock", func(out spreadsheet.Writer) error {
+		return h.exportUseCase.ExportWarehouseStock(r.Context(), uuid, out)
+	})
+}
+
+// parseDateRange reads optional startDate/endDate (YYYY-MM-DD) query parameters.
+// The end date is inclusive, so it is returned as the start of the following day.
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	var startDate, endDate time.Time
+	if startDateStr := r.URL.Query().Get("startDate"); startDateStr != "" {
+		d, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return startDate, endDate, domain.NewValidationError("startDate", "Invalid startDate format. Use YYYY-MM-DD")
+		}
+		startDate = d
+	}
+	if endDateStr := r.URL.Query().Get("endDate"); endDateStr != "" {
+		d, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return startDate, endDate, domain.NewValidationError("endDate", "Invalid endDate format. Use YYYY-MM-DD")
+		}
+		endDate = d.AddDate(0, 0, 1)
+	}
+	return startDate, endDate, nil
+}
+
+func parseStockRecordFilter(r *http.Request) (domain.StockRecordFilter, error) {
+	startDate, endDate, err := parseDateRange(r)
+	if err != nil {
+		return domain.StockRecordFilter{}, err
+	}
+	return domain.StockRecordFilter{
+		WarehouseUUID: r.URL.Query().Get("warehouseUuid"),
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}, nil
+}
+
+// exportFormat picks the file format from the format query parameter, then the Accept header, defaulting to CSV
+func exportFormat(r *http.Request) (spreadsheet.Format, error) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return spreadsheet.ParseFormat(format)
+	}
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if format, err := spreadsheet.ParseFormat(accept); err == nil {
+			return format, nil
+		}
+	}
+	return spreadsheet.FormatCSV, nil
+}
+
+// trackingWriter remembers whether any bytes reached the client, after which
+// an error can no longer be reported as a JSON response
+type trackingWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	t.written = true
+	return t.w.Write(p)
+}
+
+func writeExport(w http.ResponseWriter, r *http.Request, name string, run func(out spreadsheet.Writer) error) {
+	format, err := exportFormat(r)
+	if err != nil {
+		response.Error(w, http.StatusNotAcceptable, err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102-150405"), format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	tw := &trackingWriter{w: w}
+	out, err := spreadsheet.NewWriter(tw, format)
+	if err != nil {
+		writeError(w, r, err, "Failed to export")
+		return
+	}
+
+	if err = run(out); err != nil {
+		out.Abort()
+	} else {
+		err = out.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if tw.written {
+		slog.ErrorContext(r.Context(), "Export aborted after partial write", "export", name, "error", err)
+		return
+	}
+	w.Header().Del("Content-Disposition")
+	writeError(w, r, err, "Failed to export")
+}
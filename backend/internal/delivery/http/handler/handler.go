@@ -13,10 +13,19 @@ type Handler struct {
 	StockInHandler         *StockInHandler
 	StockOutHandler        *StockOutHandler
 	StockAdjustmentHandler *StockAdjustmentHandler
+	ImportHandler          *ImportHandler
+	ExportHandler          *ExportHandler
+	StockDocumentHandler   *StockDocumentHandler
+	APIKeyHandler          *APIKeyHandler
+	WebhookHandler         *WebhookHandler
+	OrganizationHandler    *OrganizationHandler
+	NotificationHandler    *NotificationHandler
+	AlertHandler           *AlertHandler
+	HealthHandler          *HealthHandler // Built by Bootstrap, which knows the readiness checks
 }
 
-func InitHandlers(usecases *usecase.Usecases) *Handler {
-	return &Handler{
+func InitHandlers(usecases *usecase.Usecases, pagination Pagination, auth Auth) *Handler {
+	h := &Handler{
 		ProductHandler:         NewProductHandler(usecases.ProductUsecase),
 		CategoryHandler:        NewCategoryHandler(usecases.CategoryUsecase),
 		SupplierHandler:        NewSupplierHandler(usecases.SupplierUsecase),
@@ -25,5 +34,24 @@ func InitHandlers(usecases *usecase.Usecases) *Handler {
 		StockInHandler:         NewStockInHandler(usecases.StockInUseCase),
 		StockOutHandler:        NewStockOutHandler(usecases.StockOutUseCase),
 		StockAdjustmentHandler: NewStockAdjustmentHandler(usecases.StockAdjustmentUseCase),
+		ImportHandler:          NewImportHandler(usecases.ImportUseCase),
+		ExportHandler:          NewExportHandler(usecases.ExportUseCase),
+		StockDocumentHandler:   NewStockDocumentHandler(usecases.StockDocumentUseCase),
+		APIKeyHandler:          NewAPIKeyHandler(usecases.APIKeyUseCase),
+		WebhookHandler:         NewWebhookHandler(usecases.WebhookUseCase),
+		OrganizationHandler:    NewOrganizationHandler(usecases.OrganizationUseCase),
+		NotificationHandler:    NewNotificationHandler(usecases.NotificationUseCase),
+		AlertHandler:           NewAlertHandler(usecases.AlertUseCase),
 	}
+	h.ProductHandler.pagination = pagination
+	h.CategoryHandler.pagination = pagination
+	h.SupplierHandler.pagination = pagination
+	h.WarehouseHandler.pagination = pagination
+	h.StockMovementHandler.pagination = pagination
+	h.StockDocumentHandler.pagination = pagination
+	h.WebhookHandler.pagination = pagination
+	h.NotificationHandler.pagination = pagination
+	h.AlertHandler.pagination = pagination
+	h.APIKeyHandler.auth = auth
+	return h
 }
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// HealthCheck reports an error while a dependency the server needs is unavailable
+type HealthCheck func(ctx context.Context) error
+
+type HealthHandler struct {
+	checks   map[string]HealthCheck
+	draining atomic.Bool
+}
+
+func NewHealthHandler(checks map[string]HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Drain fails readiness from now on so load balancers stop routing here during shutdown
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Live reports that the process is up. It checks no dependencies, so a database outage
+// takes the instance out of rotation without getting it restarted.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	response.Success(w, http.StatusOK, "Alive", nil)
+}
+
+// Ready runs every readiness check and lists the failing ones in details
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		response.Error(w, http.StatusServiceUnavailable, "Shutting down")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make(map[string]string, len(names))
+	var failures []domain.FieldError
+	for _, name := range names {
+		if err := h.checks[name](ctx); err != nil {
+			failures = append(failures, domain.FieldError{Field: name, Message: err.Error()})
+			continue
+		}
+		results[name] = "ok"
+	}
+	if len(failures) > 0 {
+		response.ErrorWithCode(w, http.StatusServiceUnavailable, response.StatusCode(http.StatusServiceUnavailable), "Not ready", failures)
+		return
+	}
+	response.Success(w, http.StatusOK, "Ready", results)
+}
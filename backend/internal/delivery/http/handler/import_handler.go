@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+	"github.com/shirloin/stockhub/pkg/spreadsheet"
+)
+
+// MaxImportFileSize is the largest upload accepted by Import
+const MaxImportFileSize = 32 << 20 // 32 MB
+
+type ImportHandler struct {
+	importUseCase *usecase.ImportUseCase
+}
+
+func NewImportHandler(importUseCase *usecase.ImportUseCase) *ImportHandler {
+	return &ImportHandler{importUseCase: importUseCase}
+}
+
+// Import accepts a multipart upload with a CSV or XLSX "file" field.
+// Query parameters: dryRun=true to only validate, batchSize to control rows per transaction.
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	entity := domain.ImportEntity(mux.Vars(r)["entity"])
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportFileSize)
+	if err := r.ParseMultipartForm(MaxImportFileSize); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid upload: "+err.Error())
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Missing file: "+err.Error())
+		return
+	}
+	defer file.Close()
+
+	format, err := spreadsheet.DetectFormat(header.Filename, header.Header.Get("Content-Type"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reader, err := spreadsheet.NewReader(file, format)
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Failed to read file: "+err.Error())
+		return
+	}
+	defer reader.Close()
+
+	opts := domain.ImportOptions{
+		DryRun:    r.URL.Query().Get("dryRun") == "true",
+		CreatedBy: r.FormValue("createdBy"),
+	}
+	if batchSizeStr := r.URL.Query().Get("batchSize"); batchSizeStr != "" {
+		if b, err := strconv.Atoi(batchSizeStr); err == nil && b > 0 {
+			opts.BatchSize = b
+		}
+	}
+
+	result, err := h.importUseCase.Import(r.Context(), entity, reader, opts)
+	if err != nil {
+		writeError(w, r, err, "Failed to import "+string(entity))
+		return
+	}
+
+	message := "Import completed"
+	if result.DryRun {
+		message = "Import validated"
+	}
+	response.Success(w, http.StatusOK, message, result)
+}
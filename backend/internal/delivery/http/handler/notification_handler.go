@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+type NotificationHandler struct {
+	notificationUsecase *usecase.NotificationUseCase
+	pagination          Pagination
+}
+
+func NewNotificationHandler(notificationUsecase *usecase.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{notificationUsecase: notificationUsecase}
+}
+
+// GetAll returns the notification log, newest first, always paginated
+func (h *NotificationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	page, limit, _ := h.pagination.parse(r)
+	filter := domain.NotificationFilter{
+		Recipient: r.URL.Query().Get("recipient"),
+		Status:    domain.NotificationStatus(r.URL.Query().Get("status")),
+	}
+	notifications, total, err := h.notificationUsecase.GetAll(r.Context(), filter, page, limit)
+	if err != nil {
+		writeError(w, r, err, "Failed to get notifications")
+		return
+	}
+	response.PaginatedSuccess(w, http.StatusOK, "Notifications fetched successfully", page, limit, total, notifications)
+}
+
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	preferences, err := h.notificationUsecase.GetPreferences(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to get notification preferences")
+		return
+	}
+	response.Success(w, http.StatusOK, "Notification preferences fetched successfully", preferences)
+}
+
+// GetPreference returns the manager's preference, or the defaults when they have not set one
+func (h *NotificationHandler) GetPreference(w http.ResponseWriter, r *http.Request) {
+	email := mux.Vars(r)["email"]
+	preference, err := h.notificationUsecase.GetPreference(r.Context(), email)
+	if err != nil {
+		writeError(w, r, err, "Failed to get notification preference")
+		return
+	}
+	response.Success(w, http.StatusOK, "Notification preference fetched successfully", preference)
+}
+
+func (h *NotificationHandler) SavePreference(w http.ResponseWriter, r *http.Request) {
+	email := mux.Vars(r)["email"]
+	var preference domain.NotificationPreference
+
+	if err := json.NewDecoder(r.Body).Decode(&preference); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.notificationUsecase.SavePreference(r.Context(), email, &preference); err != nil {
+		writeError(w, r, err, "Failed to save notification preference")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Notification preference saved successfully", preference)
+}
+
+func (h *NotificationHandler) DeletePreference(w http.ResponseWriter, r *http.Request) {
+	email := mux.Vars(r)["email"]
+	if err := h.notificationUsecase.DeletePreference(r.Context(), email); err != nil {
+		writeError(w, r, err, "Failed to delete notification preference")
+		return
+	}
+	response.Success(w, http.StatusOK, "Notification preference deleted successfully", nil)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// OrganizationHandler serves the admin API for organisations
+type OrganizationHandler struct {
+	organizationUsecase *usecase.OrganizationUseCase
+}
+
+func NewOrganizationHandler(organizationUsecase *usecase.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{organizationUsecase: organizationUsecase}
+}
+
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var organization domain.Organization
+
+	if err := json.NewDecoder(r.Body).Decode(&organization); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.organizationUsecase.Create(r.Context(), &organization); err != nil {
+		writeError(w, r, err, "Failed to create organization")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "Organization created successfully", organization)
+}
+
+func (h *OrganizationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.organizationUsecase.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to get organizations")
+		return
+	}
+	response.Success(w, http.StatusOK, "Organizations fetched successfully", organizations)
+}
+
+func (h *OrganizationHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	organization, err := h.organizationUsecase.GetByID(r.Context(), uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to get organization")
+		return
+	}
+	response.Success(w, http.StatusOK, "Organization fetched successfully", organization)
+}
+
+func (h *OrganizationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	var organization domain.Organization
+
+	if err := json.NewDecoder(r.Body).Decode(&organization); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.organizationUsecase.Update(r.Context(), uuid, &organization); err != nil {
+		writeError(w, r, err, "Failed to update organization")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Organization updated successfully", organization)
+}
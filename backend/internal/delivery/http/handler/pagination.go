@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Pagination reads the ?page= and ?limit= of list requests
+type Pagination struct {
+	DefaultLimit int // Page size when the request does not set limit
+	MaxLimit     int // Largest limit a request may ask for; 0 is unlimited
+}
+
+// clamp caps limit at MaxLimit
+func (p Pagination) clamp(limit int) int {
+	if p.MaxLimit > 0 && limit > p.MaxLimit {
+		return p.MaxLimit
+	}
+	return limit
+}
+
+// defaultLimit is the page size of requests that do not set limit
+func (p Pagination) defaultLimit() int {
+	if p.DefaultLimit <= 0 {
+		return 10
+	}
+	return p.DefaultLimit
+}
+
+// listLimit caps the plain lists returned to requests that do not ask for a page; 0 is unlimited
+func (p Pagination) listLimit() int {
+	return p.MaxLimit
+}
+
+// parse returns the requested page and limit, ignoring values that are not positive integers.
+// requested reports whether the client asked for a page at all, with ?page= or ?limit=; list
+// endpoints return a plain list capped at listLimit otherwise. Limits above MaxLimit are capped.
+func (p Pagination) parse(r *http.Request) (page int, limit int, requested bool) {
+	defaultLimit := p.defaultLimit()
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	page, limit = 1, defaultLimit
+	if pageStr != "" {
+		if n, err := strconv.Atoi(pageStr); err == nil && n > 0 {
+			page = n
+		}
+	}
+	if limitStr != "" {
+		if n, err := strconv.Atoi(limitStr); err == nil && n > 0 {
+			limit = p.clamp(n)
+		}
+	}
+	return page, limit, pageStr != "" || limitStr != ""
+}
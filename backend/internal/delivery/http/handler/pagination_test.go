@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginationParse(t *testing.T) {
+	pagination := Pagination{DefaultLimit: 100, MaxLimit: 100}
+
+	tests := []struct {
+		query     string
+		page      int
+		limit     int
+		paginated bool
+	}{
+		{"", 1, 100, false},
+		{"?page=2", 2, 100, true},
+		{"?limit=100", 1, 100, true},
+		{"?limit=5000", 1, 100, true}, // Capped at the default, but still a page
+		{"?limit=0", 1, 100, true},
+		{"?page=3&limit=20", 3, 20, true},
+	}
+	for _, tt := range tests {
+		page, limit, paginated := pagination.parse(httptest.NewRequest("GET", "/api/products"+tt.query, nil))
+		if page != tt.page || limit != tt.limit || paginated != tt.paginated {
+			t.Errorf("parse(%q) = %d, %d, %v, want %d, %d, %v", tt.query, page, limit, paginated, tt.page, tt.limit, tt.paginated)
+		}
+	}
+}
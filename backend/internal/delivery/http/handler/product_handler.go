@@ -13,6 +13,7 @@ import (
 
 type ProductHandler struct {
 	productUsecase *usecase.ProductUseCase
+	pagination     Pagination
 }
 
 func NewProductHandler(productUsecase *usecase.ProductUseCase) *ProductHandler {
@@ -28,44 +29,58 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.productUsecase.Create(r.Context(), &product); err != nil {
-		if err == domain.ErrProductTitleRequired ||
-			err == domain.ErrProductSKURequired ||
-			err == domain.ErrProductPriceInvalid ||
-			err == domain.ErrProductStockInvalid {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create product: "+err.Error())
+		writeError(w, r, err, "Failed to create product")
 		return
 	}
 
 	response.Success(w, http.StatusCreated, "Product created successfully", product)
 }
 
-func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	// Check for pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := 1
-	limit := 10 // Default limit
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
+// parseProductFilter reads search, categoryUuid, supplierUuid, minPrice, maxPrice, stockStatus and sort
+func parseProductFilter(r *http.Request) (domain.ProductFilter, error) {
+	query := r.URL.Query()
+	filter := domain.ProductFilter{
+		Search:       query.Get("search"),
+		CategoryUUID: query.Get("categoryUuid"),
+		SupplierUUID: query.Get("supplierUuid"),
+		StockStatus:  domain.ProductStockStatus(query.Get("stockStatus")),
+	}
+	if minPriceStr := query.Get("minPrice"); minPriceStr != "" {
+		minPrice, err := strconv.Atoi(minPriceStr)
+		if err != nil {
+			return filter, domain.ErrProductPriceRangeInvalid
 		}
+		filter.MinPrice = &minPrice
 	}
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+	if maxPriceStr := query.Get("maxPrice"); maxPriceStr != "" {
+		maxPrice, err := strconv.Atoi(maxPriceStr)
+		if err != nil {
+			return filter, domain.ErrProductPriceRangeInvalid
 		}
+		filter.MaxPrice = &maxPrice
+	}
+	sort, err := domain.ParseProductSort(query.Get("sort"))
+	if err != nil {
+		return filter, err
 	}
+	filter.Sort = sort
+	return filter, filter.Validate()
+}
+
+func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r)
+	if err != nil {
+		writeError(w, r, err, "Invalid product filter")
+		return
+	}
+
+	page, limit, paginated := h.pagination.parse(r)
 
 	// If pagination is requested
-	if pageStr != "" || (limitStr != "" && limit != 10) {
-		products, total, err := h.productUsecase.GetAllPaginated(r.Context(), page, limit)
+	if paginated {
+		products, total, err := h.productUsecase.GetAllPaginated(r.Context(), filter, page, limit)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get products: "+err.Error())
+			writeError(w, r, err, "Failed to get products")
 			return
 		}
 		response.PaginatedSuccess(w, http.StatusOK, "Products fetched successfully", page, limit, total, products)
@@ -73,9 +88,9 @@ func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fallback to non-paginated response
-	products, err := h.productUsecase.GetAll(r.Context())
+	products, err := h.productUsecase.GetAll(r.Context(), filter, h.pagination.listLimit())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get products")
+		writeError(w, r, err, "Failed to get products")
 		return
 	}
 	response.Success(w, http.StatusOK, "Products fetched successfully", products)
@@ -85,11 +100,7 @@ func (h *ProductHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	product, err := h.productUsecase.GetById(r.Context(), uuid)
 	if err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Product not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to get product: "+err.Error())
+		writeError(w, r, err, "Failed to get product")
 		return
 	}
 	response.Success(w, http.StatusOK, "Product fetched successfully", product)
@@ -102,25 +113,14 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.productUsecase.Update(r.Context(), uuid, &product); err != nil {
-		if err == domain.ErrProductTitleRequired ||
-			err == domain.ErrProductSKURequired ||
-			err == domain.ErrProductPriceInvalid ||
-			err == domain.ErrProductStockInvalid {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Product not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to update product: "+err.Error())
+		writeError(w, r, err, "Failed to update product")
 		return
 	}
 
 	// Fetch updated product
 	updatedProduct, err := h.productUsecase.GetById(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to fetch updated product")
+		writeError(w, r, err, "Failed to fetch updated product")
 		return
 	}
 
@@ -129,7 +129,7 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	if err := h.productUsecase.Delete(r.Context(), uuid); err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to delete product")
+		writeError(w, r, err, "Failed to delete product")
 		return
 	}
 	response.Success(w, http.StatusOK, "Product deleted successfully", nil)
@@ -140,13 +140,13 @@ func (h *ProductHandler) GetTopByStock(w http.ResponseWriter, r *http.Request) {
 	limit := 5 // Default to 5
 	if limitStr != "" {
 		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+			limit = h.pagination.clamp(l)
 		}
 	}
 
 	products, err := h.productUsecase.GetTopByStock(r.Context(), limit)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get top products: "+err.Error())
+		writeError(w, r, err, "Failed to get top products")
 		return
 	}
 	response.Success(w, http.StatusOK, "Top products fetched successfully", products)
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+type StockDocumentHandler struct {
+	stockDocumentUseCase *usecase.StockDocumentUseCase
+	pagination           Pagination
+}
+
+func NewStockDocumentHandler(stockDocumentUseCase *usecase.StockDocumentUseCase) *StockDocumentHandler {
+	return &StockDocumentHandler{stockDocumentUseCase: stockDocumentUseCase}
+}
+
+func (h *StockDocumentHandler) CreateReceipt(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, domain.StockDocumentReceipt)
+}
+
+func (h *StockDocumentHandler) CreateShipment(w http.ResponseWriter, r *http.Request) {
+	h.create(w, r, domain.StockDocumentShipment)
+}
+
+func (h *StockDocumentHandler) GetReceipts(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.StockDocumentReceipt)
+}
+
+func (h *StockDocumentHandler) GetShipments(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.StockDocumentShipment)
+}
+
+func (h *StockDocumentHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, domain.StockDocumentReceipt)
+}
+
+func (h *StockDocumentHandler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, domain.StockDocumentShipment)
+}
+
+func documentLabel(documentType domain.StockDocumentType) string {
+	if documentType == domain.StockDocumentShipment {
+		return "Shipment"
+	}
+	return "Receipt"
+}
+
+func (h *StockDocumentHandler) create(w http.ResponseWriter, r *http.Request, documentType domain.StockDocumentType) {
+	var document domain.StockDocument
+
+	if err := json.NewDecoder(r.Body).Decode(&document); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	var err error
+	if documentType == domain.StockDocumentReceipt {
+		err = h.stockDocumentUseCase.CreateReceipt(r.Context(), &document)
+	} else {
+		err = h.stockDocumentUseCase.CreateShipment(r.Context(), &document)
+	}
+	if err != nil {
+		writeError(w, r, err, "Failed to create "+string(documentType)+" document")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, documentLabel(documentType)+" posted successfully", document)
+}
+
+func (h *StockDocumentHandler) list(w http.ResponseWriter, r *http.Request, documentType domain.StockDocumentType) {
+	page, limit, _ := h.pagination.parse(r)
+
+	documents, total, err := h.stockDocumentUseCase.GetAllPaginated(r.Context(), documentType, r.URL.Query().Get("warehouseUuid"), page, limit)
+	if err != nil {
+		writeError(w, r, err, "Failed to get "+string(documentType)+" documents")
+		return
+	}
+	response.PaginatedSuccess(w, http.StatusOK, documentLabel(documentType)+"s fetched successfully", page, limit, total, documents)
+}
+
+func (h *StockDocumentHandler) get(w http.ResponseWriter, r *http.Request, documentType domain.StockDocumentType) {
+	uuid := mux.Vars(r)["uuid"]
+	document, err := h.stockDocumentUseCase.GetByID(r.Context(), documentType, uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to get "+string(documentType)+" document")
+		return
+	}
+	response.Success(w, http.StatusOK, documentLabel(documentType)+" fetched successfully", document)
+}
@@ -3,8 +3,7 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
-	"time"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/shirloin/stockhub/internal/domain"
@@ -14,171 +13,118 @@ import (
 
 type StockMovementHandler struct {
 	stockMovementUseCase *usecase.StockMovementUseCase
+	pagination           Pagination
 }
 
 func NewStockMovementHandler(stockMovementUseCase *usecase.StockMovementUseCase) *StockMovementHandler {
 	return &StockMovementHandler{stockMovementUseCase: stockMovementUseCase}
 }
 
-func (h *StockMovementHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	// Check for filter type parameter
-	typeStr := r.URL.Query().Get("type")
-	movementType := domain.StockMovementType(typeStr)
-
-	// Check for pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := 1
-	limit := 10 // Default limit
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
-
-	// If pagination is requested
-	if pageStr != "" || (limitStr != "" && limit != 10) {
-		// If type filter is provided, use filtered pagination
-		if typeStr != "" && typeStr != "ALL" && movementType != "" {
-			movements, total, err := h.stockMovementUseCase.GetByTypePaginated(r.Context(), movementType, page, limit)
-			if err != nil {
-				response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
-				return
+// parseStockMovementFilter reads the movement filter shared by the ledger listing and its export:
+// warehouseUuid, productUuid, type (comma separated or repeated; ALL matches any), startDate and
+// endDate (YYYY-MM-DD, both inclusive), reference, reason, createdBy, apiKeyUuid and sign (positive or negative)
+func parseStockMovementFilter(r *http.Request) (domain.StockMovementFilter, error) {
+	query := r.URL.Query()
+	startDate, endDate, err := parseDateRange(r)
+	if err != nil {
+		return domain.StockMovementFilter{}, err
+	}
+	filter := domain.StockMovementFilter{
+		WarehouseUUID:    query.Get("warehouseUuid"),
+		ProductUUID:      query.Get("productUuid"),
+		StartDate:        startDate,
+		EndDate:          endDate,
+		ReferenceNumber:  query.Get("reference"),
+		AdjustmentReason: domain.AdjustmentReason(query.Get("reason")),
+		CreatedBy:        query.Get("createdBy"),
+		APIKeyUUID:       query.Get("apiKeyUuid"),
+		QuantitySign:     domain.QuantitySign(query.Get("sign")),
+	}
+	for _, typeStr := range query["type"] {
+		for _, movementType := range strings.Split(typeStr, ",") {
+			movementType = strings.TrimSpace(movementType)
+			if movementType != "" && movementType != "ALL" {
+				filter.MovementTypes = append(filter.MovementTypes, domain.StockMovementType(movementType))
 			}
-			response.PaginatedSuccess(w, http.StatusOK, "Movements fetched successfully", page, limit, total, movements)
-			return
-		}
-		// Otherwise get all movements with pagination
-		movements, total, err := h.stockMovementUseCase.GetAllPaginated(r.Context(), page, limit)
-		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
-			return
 		}
-		response.PaginatedSuccess(w, http.StatusOK, "Movements fetched successfully", page, limit, total, movements)
-		return
-	}
-
-	// Fallback to non-paginated response
-	oldLimitStr := r.URL.Query().Get("limit")
-	oldLimit := 0 // 0 means no limit
-	if oldLimitStr != "" {
-		if l, err := strconv.Atoi(oldLimitStr); err == nil && l > 0 {
-			oldLimit = l
-		}
-	}
-
-	// If type filter is provided, use filtered response
-	if typeStr != "" && typeStr != "ALL" && movementType != "" {
-		movements, err := h.stockMovementUseCase.GetByType(r.Context(), movementType, oldLimit)
-		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
-			return
-		}
-		response.Success(w, http.StatusOK, "Movements fetched successfully", movements)
-		return
 	}
+	return filter, nil
+}
 
-	movements, err := h.stockMovementUseCase.GetAll(r.Context(), oldLimit)
-	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
-		return
-	}
-	response.Success(w, http.StatusOK, "Movements fetched successfully", movements)
+// GetAll lists the ledger with any combination of the filters read by parseStockMovementFilter.
+// Pagination is by cursor (?cursor=) or by page (?page= or ?limit=); other requests get a plain
+// list of the default page size.
+func (h *StockMovementHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.StockMovementFilter{}, h.pagination.defaultLimit())
 }
 
+// GetByWarehouse is an alias for GetAll?warehouseUuid={uuid}
 func (h *StockMovementHandler) GetByWarehouse(w http.ResponseWriter, r *http.Request) {
-	uuid := mux.Vars(r)["uuid"]
-	limitStr := r.URL.Query().Get("limit")
-	limit := 100
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil {
-			limit = l
-		}
-	}
-
-	movements, err := h.stockMovementUseCase.GetByWarehouse(r.Context(), uuid, limit)
-	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
-		return
-	}
-	response.Success(w, http.StatusOK, "Movements fetched successfully", movements)
+	h.list(w, r, domain.StockMovementFilter{WarehouseUUID: mux.Vars(r)["uuid"]}, 100)
 }
 
+// GetByProduct is an alias for GetAll?productUuid={uuid}
 func (h *StockMovementHandler) GetByProduct(w http.ResponseWriter, r *http.Request) {
-	uuid := mux.Vars(r)["uuid"]
-	limitStr := r.URL.Query().Get("limit")
-	limit := 100
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil {
-			limit = l
-		}
-	}
+	h.list(w, r, domain.StockMovementFilter{ProductUUID: mux.Vars(r)["uuid"]}, 100)
+}
 
-	movements, err := h.stockMovementUseCase.GetByProduct(r.Context(), uuid, limit)
-	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
+// GetByDateRange is an alias for GetAll that requires both startDate and endDate
+func (h *StockMovementHandler) GetByDateRange(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("startDate") == "" || r.URL.Query().Get("endDate") == "" {
+		writeError(w, r, domain.NewValidationError("startDate", "startDate and endDate are required. Use YYYY-MM-DD"), "Failed to get movements")
 		return
 	}
-	response.Success(w, http.StatusOK, "Movements fetched successfully", movements)
+	h.list(w, r, domain.StockMovementFilter{}, 100)
 }
 
-func (h *StockMovementHandler) GetByDateRange(w http.ResponseWriter, r *http.Request) {
-	startDateStr := r.URL.Query().Get("startDate")
-	endDateStr := r.URL.Query().Get("endDate")
+// GetByType is an alias for GetAll?type=
+func (h *StockMovementHandler) GetByType(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, domain.StockMovementFilter{}, 100)
+}
 
-	startDate, err := time.Parse("2006-01-02", startDateStr)
+// list serves every movement listing. Dimensions set on base come from the route and take
+// precedence over query parameters; defaultLimit caps the plain, unpaginated list.
+func (h *StockMovementHandler) list(w http.ResponseWriter, r *http.Request, base domain.StockMovementFilter, defaultLimit int) {
+	filter, err := parseStockMovementFilter(r)
 	if err != nil {
-		response.Error(w, http.StatusBadRequest, "Invalid startDate format. Use YYYY-MM-DD")
+		writeError(w, r, err, "Invalid movement filter")
 		return
 	}
-
-	endDate, err := time.Parse("2006-01-02", endDateStr)
-	if err != nil {
-		response.Error(w, http.StatusBadRequest, "Invalid endDate format. Use YYYY-MM-DD")
-		return
+	if base.WarehouseUUID != "" {
+		filter.WarehouseUUID = base.WarehouseUUID
+	}
+	if base.ProductUUID != "" {
+		filter.ProductUUID = base.ProductUUID
 	}
 
-	movements, err := h.stockMovementUseCase.GetByDateRange(r.Context(), startDate, endDate)
+	sorts, err := domain.ParseStockMovementSort(r.URL.Query().Get("sort"))
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
+		writeError(w, r, err, "Invalid movement sort")
 		return
 	}
-	response.Success(w, http.StatusOK, "Movements fetched successfully", movements)
-}
-
-func (h *StockMovementHandler) GetByType(w http.ResponseWriter, r *http.Request) {
-	movementType := domain.StockMovementType(r.URL.Query().Get("type"))
 
-	// Check for pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
+	page, limit, paginated := h.pagination.parse(r)
 
-	page := 1
-	limit := 10 // Default limit
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
+	// Cursor pagination: ?cursor= (empty for the first page) with the nextCursor/prevCursor of a previous response
+	if r.URL.Query().Has("cursor") {
+		if len(sorts) > 0 {
+			writeError(w, r, domain.ErrMovementSortCursor, "Failed to get movements")
+			return
 		}
-	}
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+		result, err := h.stockMovementUseCase.GetPage(r.Context(), filter, r.URL.Query().Get("cursor"), limit, r.URL.Query().Get("includeTotal") == "true")
+		if err != nil {
+			writeError(w, r, err, "Failed to get movements")
+			return
 		}
+		response.CursorPaginatedSuccess(w, http.StatusOK, "Movements fetched successfully", limit, result.Total, result.NextCursor, result.PrevCursor, result.Items)
+		return
 	}
 
 	// If pagination is requested
-	if pageStr != "" || (limitStr != "" && limit != 10) {
-		movements, total, err := h.stockMovementUseCase.GetByTypePaginated(r.Context(), movementType, page, limit)
+	if paginated {
+		movements, total, err := h.stockMovementUseCase.ListPaginated(r.Context(), filter, sorts, page, limit)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
+			writeError(w, r, err, "Failed to get movements")
 			return
 		}
 		response.PaginatedSuccess(w, http.StatusOK, "Movements fetched successfully", page, limit, total, movements)
@@ -186,17 +132,9 @@ func (h *StockMovementHandler) GetByType(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Fallback to non-paginated response
-	oldLimitStr := r.URL.Query().Get("limit")
-	oldLimit := 100
-	if oldLimitStr != "" {
-		if l, err := strconv.Atoi(oldLimitStr); err == nil {
-			oldLimit = l
-		}
-	}
-
-	movements, err := h.stockMovementUseCase.GetByType(r.Context(), movementType, oldLimit)
+	movements, err := h.stockMovementUseCase.List(r.Context(), filter, sorts, defaultLimit)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get movements: "+err.Error())
+		writeError(w, r, err, "Failed to get movements")
 		return
 	}
 	response.Success(w, http.StatusOK, "Movements fetched successfully", movements)
@@ -219,16 +157,12 @@ func (h *StockInHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if stockIn.Quantity <= 0 {
-		response.Error(w, http.StatusBadRequest, "Quantity must be greater than 0")
+		writeError(w, r, domain.ErrQuantityInvalid, "Failed to create stock in")
 		return
 	}
 
 	if err := h.stockInUseCase.Create(r.Context(), &stockIn); err != nil {
-		if err == domain.ErrWarehouseCapacityExceeded {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create stock in: "+err.Error())
+		writeError(w, r, err, "Failed to create stock in")
 		return
 	}
 
@@ -238,7 +172,7 @@ func (h *StockInHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *StockInHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	stockIns, err := h.stockInUseCase.GetAll(r.Context())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get stock ins: "+err.Error())
+		writeError(w, r, err, "Failed to get stock ins")
 		return
 	}
 	response.Success(w, http.StatusOK, "Stock ins fetched successfully", stockIns)
@@ -248,7 +182,7 @@ func (h *StockInHandler) GetByWarehouse(w http.ResponseWriter, r *http.Request)
 	uuid := mux.Vars(r)["uuid"]
 	stockIns, err := h.stockInUseCase.GetByWarehouse(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get stock ins: "+err.Error())
+		writeError(w, r, err, "Failed to get stock ins")
 		return
 	}
 	response.Success(w, http.StatusOK, "Stock ins fetched successfully", stockIns)
@@ -271,16 +205,12 @@ func (h *StockOutHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if stockOut.Quantity <= 0 {
-		response.Error(w, http.StatusBadRequest, "Quantity must be greater than 0")
+		writeError(w, r, domain.ErrQuantityInvalid, "Failed to create stock out")
 		return
 	}
 
 	if err := h.stockOutUseCase.Create(r.Context(), &stockOut); err != nil {
-		if err == domain.ErrInsufficientStock {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create stock out: "+err.Error())
+		writeError(w, r, err, "Failed to create stock out")
 		return
 	}
 
@@ -290,7 +220,7 @@ func (h *StockOutHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *StockOutHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	stockOuts, err := h.stockOutUseCase.GetAll(r.Context())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get stock outs: "+err.Error())
+		writeError(w, r, err, "Failed to get stock outs")
 		return
 	}
 	response.Success(w, http.StatusOK, "Stock outs fetched successfully", stockOuts)
@@ -300,7 +230,7 @@ func (h *StockOutHandler) GetByWarehouse(w http.ResponseWriter, r *http.Request)
 	uuid := mux.Vars(r)["uuid"]
 	stockOuts, err := h.stockOutUseCase.GetByWarehouse(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get stock outs: "+err.Error())
+		writeError(w, r, err, "Failed to get stock outs")
 		return
 	}
 	response.Success(w, http.StatusOK, "Stock outs fetched successfully", stockOuts)
@@ -323,16 +253,12 @@ func (h *StockAdjustmentHandler) Create(w http.ResponseWriter, r *http.Request)
 	}
 
 	if adjustment.Quantity == 0 {
-		response.Error(w, http.StatusBadRequest, "Quantity cannot be 0")
+		writeError(w, r, domain.NewValidationError("quantity", "Quantity cannot be 0"), "Failed to create adjustment")
 		return
 	}
 
 	if err := h.adjustmentUseCase.Create(r.Context(), &adjustment); err != nil {
-		if err == domain.ErrWarehouseCapacityExceeded {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create adjustment: "+err.Error())
+		writeError(w, r, err, "Failed to create adjustment")
 		return
 	}
 
@@ -342,7 +268,7 @@ func (h *StockAdjustmentHandler) Create(w http.ResponseWriter, r *http.Request)
 func (h *StockAdjustmentHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	adjustments, err := h.adjustmentUseCase.GetAll(r.Context())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get adjustments: "+err.Error())
+		writeError(w, r, err, "Failed to get adjustments")
 		return
 	}
 	response.Success(w, http.StatusOK, "Adjustments fetched successfully", adjustments)
@@ -352,7 +278,7 @@ func (h *StockAdjustmentHandler) GetByWarehouse(w http.ResponseWriter, r *http.R
 	uuid := mux.Vars(r)["uuid"]
 	adjustments, err := h.adjustmentUseCase.GetByWarehouse(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get adjustments: "+err.Error())
+		writeError(w, r, err, "Failed to get adjustments")
 		return
 	}
 	response.Success(w, http.StatusOK, "Adjustments fetched successfully", adjustments)
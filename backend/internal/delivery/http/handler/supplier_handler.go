@@ -3,7 +3,6 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/shirloin/stockhub/internal/domain"
@@ -13,6 +12,7 @@ import (
 
 type SupplierHandler struct {
 	supplierUsecase *usecase.SupplierUseCase
+	pagination      Pagination
 }
 
 func NewSupplierHandler(supplierUsecase *usecase.SupplierUseCase) *SupplierHandler {
@@ -28,11 +28,7 @@ func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.supplierUsecase.Create(r.Context(), &supplier); err != nil {
-		if err == domain.ErrSupplierNameRequired || err == domain.ErrSupplierEmailInvalid {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create supplier: "+err.Error())
+		writeError(w, r, err, "Failed to create supplier")
 		return
 	}
 
@@ -40,29 +36,13 @@ func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *SupplierHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	// Check for pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := 1
-	limit := 10 // Default limit
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
+	page, limit, paginated := h.pagination.parse(r)
 
 	// If pagination is requested
-	if pageStr != "" || (limitStr != "" && limit != 10) {
+	if paginated {
 		suppliers, total, err := h.supplierUsecase.GetAllPaginated(r.Context(), page, limit)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get suppliers: "+err.Error())
+			writeError(w, r, err, "Failed to get suppliers")
 			return
 		}
 		response.PaginatedSuccess(w, http.StatusOK, "Suppliers fetched successfully", page, limit, total, suppliers)
@@ -70,9 +50,9 @@ func (h *SupplierHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fallback to non-paginated response
-	suppliers, err := h.supplierUsecase.GetAll(r.Context())
+	suppliers, err := h.supplierUsecase.GetAll(r.Context(), h.pagination.listLimit())
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get suppliers: "+err.Error())
+		writeError(w, r, err, "Failed to get suppliers")
 		return
 	}
 	response.Success(w, http.StatusOK, "Suppliers fetched successfully", suppliers)
@@ -82,11 +62,7 @@ func (h *SupplierHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	supplier, err := h.supplierUsecase.GetByID(r.Context(), uuid)
 	if err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Supplier not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to get supplier: "+err.Error())
+		writeError(w, r, err, "Failed to get supplier")
 		return
 	}
 	response.Success(w, http.StatusOK, "Supplier fetched successfully", supplier)
@@ -100,21 +76,13 @@ func (h *SupplierHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.supplierUsecase.Update(r.Context(), uuid, &supplier); err != nil {
-		if err == domain.ErrSupplierNameRequired || err == domain.ErrSupplierEmailInvalid {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Supplier not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to update supplier: "+err.Error())
+		writeError(w, r, err, "Failed to update supplier")
 		return
 	}
 
 	updatedSupplier, err := h.supplierUsecase.GetByID(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to fetch updated supplier")
+		writeError(w, r, err, "Failed to fetch updated supplier")
 		return
 	}
 
@@ -124,11 +92,7 @@ func (h *SupplierHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *SupplierHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	if err := h.supplierUsecase.Delete(r.Context(), uuid); err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Supplier not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to delete supplier: "+err.Error())
+		writeError(w, r, err, "Failed to delete supplier")
 		return
 	}
 	response.Success(w, http.StatusOK, "Supplier deleted successfully", nil)
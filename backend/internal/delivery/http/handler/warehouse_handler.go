@@ -3,7 +3,6 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/shirloin/stockhub/internal/domain"
@@ -13,6 +12,7 @@ import (
 
 type WarehouseHandler struct {
 	warehouseUsecase *usecase.WarehouseUseCase
+	pagination       Pagination
 }
 
 func NewWarehouseHandler(warehouseUsecase *usecase.WarehouseUseCase) *WarehouseHandler {
@@ -28,11 +28,7 @@ func (h *WarehouseHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.warehouseUsecase.Create(r.Context(), &warehouse); err != nil {
-		if err == domain.ErrWarehouseNameRequired {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to create warehouse: "+err.Error())
+		writeError(w, r, err, "Failed to create warehouse")
 		return
 	}
 
@@ -43,37 +39,21 @@ func (h *WarehouseHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	// Check if metrics are requested via query parameter
 	includeMetrics := r.URL.Query().Get("metrics") == "true"
 
-	// Check for pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
-	page := 1
-	limit := 10 // Default limit
-
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
-	}
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
-	}
+	page, limit, paginated := h.pagination.parse(r)
 
 	// If pagination is requested (page or limit provided)
-	if pageStr != "" || (limitStr != "" && limit != 10) {
+	if paginated {
 		if includeMetrics {
 			warehouses, total, err := h.warehouseUsecase.GetAllWithMetricsPaginated(r.Context(), page, limit)
 			if err != nil {
-				response.Error(w, http.StatusInternalServerError, "Failed to get warehouses: "+err.Error())
+				writeError(w, r, err, "Failed to get warehouses")
 				return
 			}
 			response.PaginatedSuccess(w, http.StatusOK, "Warehouses fetched successfully", page, limit, total, warehouses)
 		} else {
 			warehouses, total, err := h.warehouseUsecase.GetAllPaginated(r.Context(), page, limit)
 			if err != nil {
-				response.Error(w, http.StatusInternalServerError, "Failed to get warehouses: "+err.Error())
+				writeError(w, r, err, "Failed to get warehouses")
 				return
 			}
 			response.PaginatedSuccess(w, http.StatusOK, "Warehouses fetched successfully", page, limit, total, warehouses)
@@ -82,25 +62,17 @@ func (h *WarehouseHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Fallback to non-paginated responses for backward compatibility
-	oldLimitStr := r.URL.Query().Get("limit")
-	oldLimit := 0 // 0 means no limit
-	if oldLimitStr != "" {
-		if l, err := strconv.Atoi(oldLimitStr); err == nil && l > 0 {
-			oldLimit = l
-		}
-	}
-
 	if includeMetrics {
-		warehouses, err := h.warehouseUsecase.GetAllWithMetrics(r.Context(), oldLimit)
+		warehouses, err := h.warehouseUsecase.GetAllWithMetrics(r.Context(), h.pagination.listLimit())
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get warehouses: "+err.Error())
+			writeError(w, r, err, "Failed to get warehouses")
 			return
 		}
 		response.Success(w, http.StatusOK, "Warehouses fetched successfully", warehouses)
 	} else {
-		warehouses, err := h.warehouseUsecase.GetAll(r.Context())
+		warehouses, err := h.warehouseUsecase.GetAll(r.Context(), h.pagination.listLimit())
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "Failed to get warehouses: "+err.Error())
+			writeError(w, r, err, "Failed to get warehouses")
 			return
 		}
 		response.Success(w, http.StatusOK, "Warehouses fetched successfully", warehouses)
@@ -111,11 +83,7 @@ func (h *WarehouseHandler) GetById(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	warehouse, err := h.warehouseUsecase.GetByID(r.Context(), uuid)
 	if err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Warehouse not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to get warehouse: "+err.Error())
+		writeError(w, r, err, "Failed to get warehouse")
 		return
 	}
 	response.Success(w, http.StatusOK, "Warehouse fetched successfully", warehouse)
@@ -129,21 +97,13 @@ func (h *WarehouseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if err := h.warehouseUsecase.Update(r.Context(), uuid, &warehouse); err != nil {
-		if err == domain.ErrWarehouseNameRequired {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Warehouse not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to update warehouse: "+err.Error())
+		writeError(w, r, err, "Failed to update warehouse")
 		return
 	}
 
 	updatedWarehouse, err := h.warehouseUsecase.GetByID(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to fetch updated warehouse")
+		writeError(w, r, err, "Failed to fetch updated warehouse")
 		return
 	}
 
@@ -153,11 +113,7 @@ func (h *WarehouseHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *WarehouseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	if err := h.warehouseUsecase.Delete(r.Context(), uuid); err != nil {
-		if err.Error() == "record not found" {
-			response.Error(w, http.StatusNotFound, "Warehouse not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to delete warehouse: "+err.Error())
+		writeError(w, r, err, "Failed to delete warehouse")
 		return
 	}
 	response.Success(w, http.StatusOK, "Warehouse deleted successfully", nil)
@@ -167,7 +123,7 @@ func (h *WarehouseHandler) GetStock(w http.ResponseWriter, r *http.Request) {
 	uuid := mux.Vars(r)["uuid"]
 	stock, err := h.warehouseUsecase.GetWarehouseStock(r.Context(), uuid)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "Failed to get warehouse stock: "+err.Error())
+		writeError(w, r, err, "Failed to get warehouse stock")
 		return
 	}
 	response.Success(w, http.StatusOK, "Warehouse stock fetched successfully", stock)
@@ -181,15 +137,7 @@ func (h *WarehouseHandler) AddStock(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.warehouseUsecase.AddStock(r.Context(), &stock); err != nil {
-		if err == domain.ErrInsufficientStock {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		if err == domain.ErrWarehouseCapacityExceeded {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to add stock: "+err.Error())
+		writeError(w, r, err, "Failed to add stock")
 		return
 	}
 
@@ -205,16 +153,12 @@ func (h *WarehouseHandler) TransferStock(w http.ResponseWriter, r *http.Request)
 	}
 
 	if transfer.Quantity <= 0 {
-		response.Error(w, http.StatusBadRequest, domain.ErrQuantityInvalid.Error())
+		writeError(w, r, domain.ErrQuantityInvalid, "Failed to transfer stock")
 		return
 	}
 
 	if err := h.warehouseUsecase.TransferStock(r.Context(), &transfer); err != nil {
-		if err == domain.ErrInsufficientStock {
-			response.Error(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "Failed to transfer stock: "+err.Error())
+		writeError(w, r, err, "Failed to transfer stock")
 		return
 	}
 
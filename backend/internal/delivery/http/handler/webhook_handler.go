@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/usecase"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+type WebhookHandler struct {
+	webhookUsecase *usecase.WebhookUseCase
+	pagination     Pagination
+}
+
+func NewWebhookHandler(webhookUsecase *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{webhookUsecase: webhookUsecase}
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var webhook domain.Webhook
+
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.webhookUsecase.Create(r.Context(), &webhook); err != nil {
+		writeError(w, r, err, "Failed to create webhook")
+		return
+	}
+
+	response.Success(w, http.StatusCreated, "Webhook created successfully; store the secret now, it is not shown again", webhook)
+}
+
+func (h *WebhookHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookUsecase.GetAll(r.Context())
+	if err != nil {
+		writeError(w, r, err, "Failed to get webhooks")
+		return
+	}
+	response.Success(w, http.StatusOK, "Webhooks fetched successfully", webhooks)
+}
+
+func (h *WebhookHandler) GetById(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	webhook, err := h.webhookUsecase.GetByID(r.Context(), uuid)
+	if err != nil {
+		writeError(w, r, err, "Failed to get webhook")
+		return
+	}
+	response.Success(w, http.StatusOK, "Webhook fetched successfully", webhook)
+}
+
+func (h *WebhookHandler) Update(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	// Leaving enabled out keeps the webhook enabled rather than switching it off
+	webhook := domain.Webhook{Enabled: true}
+
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.webhookUsecase.Update(r.Context(), uuid, &webhook); err != nil {
+		writeError(w, r, err, "Failed to update webhook")
+		return
+	}
+
+	response.Success(w, http.StatusOK, "Webhook updated successfully", webhook)
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	if err := h.webhookUsecase.Delete(r.Context(), uuid); err != nil {
+		writeError(w, r, err, "Failed to delete webhook")
+		return
+	}
+	response.Success(w, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// GetDeliveries returns the webhook's delivery log, newest first, always paginated
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	page, limit, _ := h.pagination.parse(r)
+	deliveries, total, err := h.webhookUsecase.GetDeliveries(r.Context(), uuid, page, limit)
+	if err != nil {
+		writeError(w, r, err, "Failed to get webhook deliveries")
+		return
+	}
+	response.PaginatedSuccess(w, http.StatusOK, "Webhook deliveries fetched successfully", page, limit, total, deliveries)
+}
+
+// Redeliver queues a past delivery's event again; it is sent by the dispatcher shortly after
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	delivery, err := h.webhookUsecase.Redeliver(r.Context(), vars["uuid"], vars["deliveryUuid"])
+	if err != nil {
+		writeError(w, r, err, "Failed to redeliver webhook event")
+		return
+	}
+	response.Success(w, http.StatusAccepted, "Webhook event queued for redelivery", delivery)
+}
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+//go:embed docs.html
+var docsHTML []byte
+
+// Spec is the OpenAPI 3 document describing the REST API
+type Spec struct {
+	Doc    *openapi3.T
+	json   []byte
+	router routers.Router
+}
+
+// NewSpec loads and validates the embedded document
+func NewSpec() (*Spec, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(specYAML)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	specJSON, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Spec{
+		Doc:    doc,
+		json:   specJSON,
+		router: router,
+	}, nil
+}
+
+// HasOperation reports whether the document describes method on the path template, e.g. /api/products/{uuid}
+func (s *Spec) HasOperation(method, path string) bool {
+	pathItem := s.Doc.Paths.Find(path)
+	if pathItem == nil {
+		return false
+	}
+	return pathItem.GetOperation(strings.ToUpper(method)) != nil
+}
+
+func (s *Spec) ServeJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.json)
+}
+
+func (s *Spec) ServeDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(docsHTML)
+}
+
+// Middleware rejects requests whose parameters or body do not match the document.
+// Requests for paths the document does not describe are passed through unchanged.
+func (s *Spec) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := s.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				// Uploads are parsed and validated row by row by the import handler. What the
+				// operation declares decides, so a client cannot skip validation of a JSON body
+				// by calling it an upload.
+				ExcludeRequestBody: takesUpload(route),
+			},
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			// The body limit applies while the document reads the body to validate it
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				response.Error(w, http.StatusRequestEntityTooLarge, "Request body exceeds "+strconv.FormatInt(tooLarge.Limit, 10)+" bytes")
+				return
+			}
+			validationErr := validationError(err)
+			response.ErrorWithCode(w, http.StatusBadRequest, string(validationErr.Code), validationErr.Message, validationErr.Fields)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// takesUpload reports whether the operation's body is a multipart/form-data upload
+func takesUpload(route *routers.Route) bool {
+	body := route.Operation.RequestBody
+	if body == nil || body.Value == nil {
+		return false
+	}
+	_, ok := body.Value.Content["multipart/form-data"]
+	return ok
+}
+
+// validationError turns a kin-openapi rejection into a domain validation error naming the
+// parameter or body field, without the schema dump kin-openapi appends to its messages
+func validationError(err error) *domain.Error {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return domain.NewValidationError("", err.Error())
+	}
+	if requestErr.Parameter != nil {
+		reason := requestErr.Reason
+		if reason == "" {
+			_, reason = schemaReason(requestErr)
+		}
+		return &domain.Error{
+			Code:    domain.ErrorCodeValidation,
+			Message: "parameter " + requestErr.Parameter.Name + ": " + reason,
+			Fields:  []domain.FieldError{{Field: requestErr.Parameter.Name, Message: reason}},
+		}
+	}
+	if requestErr.RequestBody != nil {
+		field, reason := schemaReason(requestErr)
+		message := "request body: " + reason
+		if field != "" {
+			message = "request body: " + field + ": " + reason
+		}
+		return &domain.Error{
+			Code:    domain.ErrorCodeValidation,
+			Message: message,
+			Fields:  []domain.FieldError{{Field: field, Message: reason}},
+		}
+	}
+	return domain.NewValidationError("", requestErr.Reason)
+}
+
+// schemaReason returns the dotted path of the offending value and why it was rejected
+func schemaReason(err *openapi3filter.RequestError) (string, string) {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err.Err, &schemaErr) {
+		// allOf and friends wrap the failing subschema's error; report that one
+		var innerErr *openapi3.SchemaError
+		for errors.As(schemaErr.Origin, &innerErr) {
+			schemaErr = innerErr
+		}
+		return strings.Join(schemaErr.JSONPointer(), "."), schemaErr.Reason
+	}
+	if err.Err != nil {
+		return "", err.Err.Error()
+	}
+	return "", err.Reason
+}
@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMiddlewareValidatesBodiesWhateverTheirContentType checks that calling a JSON body an
+// upload does not skip its validation, while real uploads are left to their handler
+func TestMiddlewareValidatesBodiesWhateverTheirContentType(t *testing.T) {
+	spec, err := NewSpec()
+	if err != nil {
+		t.Fatalf("loading OpenAPI spec: %v", err)
+	}
+	handler := spec.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"json body", "/api/categories", "application/json", `{"name": 42}`, http.StatusBadRequest},
+		{"json body sent as an upload", "/api/categories", "multipart/form-data; boundary=x", `{"name": 42}`, http.StatusBadRequest},
+		{"upload", "/api/imports/categories", "multipart/form-data; boundary=x", "--x--\r\n", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -1,22 +1,69 @@
 package route
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
 	"github.com/shirloin/stockhub/internal/delivery/http/handler"
+	"github.com/shirloin/stockhub/internal/delivery/http/openapi"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/tracing"
 )
 
 type RouteConfig struct {
 	Router   *mux.Router
 	Handlers *handler.Handler
+	OpenAPI  *openapi.Spec
+	// Middlewares run on every /api request after authentication and ahead of request validation,
+	// such as rate and body limits
+	Middlewares []mux.MiddlewareFunc
 }
 
 func (c *RouteConfig) Setup(mux *mux.Router) {
+	mux.Use(tracing.RouteMiddleware)
+	c.SetupMetricsRoutes(mux)
+	c.SetupHealthRoutes(mux)
 	router := mux.PathPrefix("/api").Subrouter()
+	router.Use(c.authenticate)
+	router.Use(c.Middlewares...)
+	c.SetupDocumentationRoutes(router)
 	c.SetupProductRoutes(router)
 	c.SetupCategoryRoutes(router)
 	c.SetupSupplierRoutes(router)
 	c.SetupWarehouseRoutes(router)
 	c.SetupStockMovementRoutes(router)
+	c.SetupImportRoutes(router)
+	c.SetupExportRoutes(router)
+	c.SetupStockDocumentRoutes(router)
+	c.SetupNotificationRoutes(router)
+	c.SetupAlertRoutes(router)
+	c.SetupAdminRoutes(router)
+}
+
+// authenticate defers to the API key handler when the request runs, so building the router needs no handlers
+func (c *RouteConfig) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Handlers.APIKeyHandler.Authenticate(next).ServeHTTP(w, r)
+	})
+}
+
+// SetupMetricsRoutes counts and times every request and serves the Prometheus metrics
+func (c *RouteConfig) SetupMetricsRoutes(mux *mux.Router) {
+	mux.Use(metrics.HTTPMiddleware)
+	mux.Handle("/metrics", metrics.Handler()).Methods("GET")
+}
+
+// SetupHealthRoutes registers the liveness and readiness probes outside /api, so they skip request validation
+func (c *RouteConfig) SetupHealthRoutes(mux *mux.Router) {
+	mux.HandleFunc("/healthz", c.Handlers.HealthHandler.Live).Methods("GET")
+	mux.HandleFunc("/readyz", c.Handlers.HealthHandler.Ready).Methods("GET")
+}
+
+func (c *RouteConfig) SetupDocumentationRoutes(mux *mux.Router) {
+	// Every route below is validated against the OpenAPI document before it reaches its handler
+	mux.Use(c.OpenAPI.Middleware)
+	mux.HandleFunc("/openapi.json", c.OpenAPI.ServeJSON).Methods("GET")
+	mux.HandleFunc("/docs", c.OpenAPI.ServeDocs).Methods("GET")
 }
 
 func (c *RouteConfig) SetupProductRoutes(mux *mux.Router) {
@@ -78,3 +125,86 @@ func (c *RouteConfig) SetupStockMovementRoutes(mux *mux.Router) {
 	mux.HandleFunc("/stock-adjustments", c.Handlers.StockAdjustmentHandler.GetAll).Methods("GET")
 	mux.HandleFunc("/stock-adjustments/warehouse/{uuid}", c.Handlers.StockAdjustmentHandler.GetByWarehouse).Methods("GET")
 }
+
+func (c *RouteConfig) SetupImportRoutes(mux *mux.Router) {
+	// Bulk CSV/XLSX import: products, categories, suppliers, warehouses, stock
+	mux.HandleFunc("/imports/{entity}", c.Handlers.ImportHandler.Import).Methods("POST")
+}
+
+func (c *RouteConfig) SetupExportRoutes(mux *mux.Router) {
+	// CSV/XLSX exports, format chosen by ?format= or the Accept header
+	mux.HandleFunc("/stock-movements/export", c.Handlers.ExportHandler.StockMovements).Methods("GET")
+	mux.HandleFunc("/stock-in/export", c.Handlers.ExportHandler.StockIns).Methods("GET")
+	mux.HandleFunc("/stock-out/export", c.Handlers.ExportHandler.StockOuts).Methods("GET")
+	mux.HandleFunc("/stock-adjustments/export", c.Handlers.ExportHandler.StockAdjustments).Methods("GET")
+	mux.HandleFunc("/warehouses/{uuid}/stock/export", c.Handlers.ExportHandler.WarehouseStock).Methods("GET")
+}
+
+func (c *RouteConfig) SetupStockDocumentRoutes(mux *mux.Router) {
+	// Multi-line goods receipts, posted atomically
+	mux.HandleFunc("/receipts", c.Handlers.StockDocumentHandler.CreateReceipt).Methods("POST")
+	mux.HandleFunc("/receipts", c.Handlers.StockDocumentHandler.GetReceipts).Methods("GET")
+	mux.HandleFunc("/receipts/{uuid}", c.Handlers.StockDocumentHandler.GetReceipt).Methods("GET")
+
+	// Multi-line shipments, posted atomically
+	mux.HandleFunc("/shipments", c.Handlers.StockDocumentHandler.CreateShipment).Methods("POST")
+	mux.HandleFunc("/shipments", c.Handlers.StockDocumentHandler.GetShipments).Methods("GET")
+	mux.HandleFunc("/shipments/{uuid}", c.Handlers.StockDocumentHandler.GetShipment).Methods("GET")
+}
+
+func (c *RouteConfig) SetupNotificationRoutes(mux *mux.Router) {
+	// Emails to warehouse managers and how each manager wants them
+	mux.HandleFunc("/notifications", c.Handlers.NotificationHandler.GetAll).Methods("GET")
+	mux.HandleFunc("/notification-preferences", c.Handlers.NotificationHandler.GetPreferences).Methods("GET")
+	mux.HandleFunc("/notification-preferences/{email}", c.Handlers.NotificationHandler.GetPreference).Methods("GET")
+	mux.HandleFunc("/notification-preferences/{email}", c.Handlers.NotificationHandler.SavePreference).Methods("PUT")
+	mux.HandleFunc("/notification-preferences/{email}", c.Handlers.NotificationHandler.DeletePreference).Methods("DELETE")
+}
+
+func (c *RouteConfig) SetupAlertRoutes(mux *mux.Router) {
+	// Conditions that open alerts, and the alerts with their history
+	mux.HandleFunc("/alert-rules", c.Handlers.AlertHandler.CreateRule).Methods("POST")
+	mux.HandleFunc("/alert-rules", c.Handlers.AlertHandler.GetRules).Methods("GET")
+	mux.HandleFunc("/alert-rules/{uuid}", c.Handlers.AlertHandler.GetRule).Methods("GET")
+	mux.HandleFunc("/alert-rules/{uuid}", c.Handlers.AlertHandler.UpdateRule).Methods("PUT")
+	mux.HandleFunc("/alert-rules/{uuid}", c.Handlers.AlertHandler.DeleteRule).Methods("DELETE")
+	mux.HandleFunc("/alerts", c.Handlers.AlertHandler.GetAll).Methods("GET")
+	mux.HandleFunc("/alerts/{uuid}", c.Handlers.AlertHandler.GetById).Methods("GET")
+	mux.HandleFunc("/alerts/{uuid}/acknowledge", c.Handlers.AlertHandler.Acknowledge).Methods("POST")
+	mux.HandleFunc("/alerts/{uuid}/snooze", c.Handlers.AlertHandler.Snooze).Methods("POST")
+	mux.HandleFunc("/alerts/{uuid}/resolve", c.Handlers.AlertHandler.Resolve).Methods("POST")
+}
+
+func (c *RouteConfig) SetupAdminRoutes(mux *mux.Router) {
+	// Admin API, behind the admin bearer token
+	admin := mux.PathPrefix("/admin").Subrouter()
+	admin.Use(c.requireAdmin)
+
+	// Organisations, each with its own catalog, stock, API keys and webhooks
+	admin.HandleFunc("/organizations", c.Handlers.OrganizationHandler.Create).Methods("POST")
+	admin.HandleFunc("/organizations", c.Handlers.OrganizationHandler.GetAll).Methods("GET")
+	admin.HandleFunc("/organizations/{uuid}", c.Handlers.OrganizationHandler.GetById).Methods("GET")
+	admin.HandleFunc("/organizations/{uuid}", c.Handlers.OrganizationHandler.Update).Methods("PUT")
+
+	// API keys
+	admin.HandleFunc("/api-keys", c.Handlers.APIKeyHandler.Create).Methods("POST")
+	admin.HandleFunc("/api-keys", c.Handlers.APIKeyHandler.GetAll).Methods("GET")
+	admin.HandleFunc("/api-keys/{uuid}", c.Handlers.APIKeyHandler.GetById).Methods("GET")
+	admin.HandleFunc("/api-keys/{uuid}", c.Handlers.APIKeyHandler.Revoke).Methods("DELETE")
+	admin.HandleFunc("/api-keys/{uuid}/rotate", c.Handlers.APIKeyHandler.Rotate).Methods("POST")
+
+	// Webhook subscriptions and their delivery logs
+	admin.HandleFunc("/webhooks", c.Handlers.WebhookHandler.Create).Methods("POST")
+	admin.HandleFunc("/webhooks", c.Handlers.WebhookHandler.GetAll).Methods("GET")
+	admin.HandleFunc("/webhooks/{uuid}", c.Handlers.WebhookHandler.GetById).Methods("GET")
+	admin.HandleFunc("/webhooks/{uuid}", c.Handlers.WebhookHandler.Update).Methods("PUT")
+	admin.HandleFunc("/webhooks/{uuid}", c.Handlers.WebhookHandler.Delete).Methods("DELETE")
+	admin.HandleFunc("/webhooks/{uuid}/deliveries", c.Handlers.WebhookHandler.GetDeliveries).Methods("GET")
+	admin.HandleFunc("/webhooks/{uuid}/deliveries/{deliveryUuid}/redeliver", c.Handlers.WebhookHandler.Redeliver).Methods("POST")
+}
+
+func (c *RouteConfig) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Handlers.APIKeyHandler.RequireAdmin(next).ServeHTTP(w, r)
+	})
+}
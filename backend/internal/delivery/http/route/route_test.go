@@ -0,0 +1,51 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/internal/delivery/http/handler"
+	"github.com/shirloin/stockhub/internal/delivery/http/openapi"
+)
+
+// TestRoutesAreDocumented fails when a route is registered on the router but missing from openapi.yaml
+func TestRoutesAreDocumented(t *testing.T) {
+	spec, err := openapi.NewSpec()
+	if err != nil {
+		t.Fatalf("loading OpenAPI spec: %v", err)
+	}
+
+	router := mux.NewRouter()
+	routeConfig := RouteConfig{
+		Router:   router,
+		Handlers: &handler.Handler{},
+		OpenAPI:  spec,
+	}
+	routeConfig.Setup(router)
+
+	checked := 0
+	err = router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Subrouters and prefixes carry no methods
+			return nil
+		}
+		for _, method := range methods {
+			checked++
+			if !spec.HasOperation(method, path) {
+				t.Errorf("%s %s is routed but not described in openapi.yaml", method, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking routes: %v", err)
+	}
+	if checked == 0 {
+		t.Fatal("no routes were registered")
+	}
+}
@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AlertRuleType is the condition an alert rule watches for
+type AlertRuleType string
+
+const (
+	AlertStockBelow           AlertRuleType = "stock_below"           // A product's stock in a warehouse is below Threshold units
+	AlertWarehouseUtilization AlertRuleType = "warehouse_utilization" // A warehouse holds more than Threshold percent of its capacity
+	AlertLargeAdjustment      AlertRuleType = "large_adjustment"      // A single adjustment adds or removes more than Threshold units
+	AlertNoMovement           AlertRuleType = "no_movement"           // A product has not moved for Threshold days
+	AlertStockDrift           AlertRuleType = "stock_drift"           // The warehouses hold more than Threshold units fewer of a product than its catalog stock
+)
+
+var AlertRuleTypes = []AlertRuleType{AlertStockBelow, AlertWarehouseUtilization, AlertLargeAdjustment, AlertNoMovement, AlertStockDrift}
+
+func (t AlertRuleType) IsValid() bool {
+	return slices.Contains(AlertRuleTypes, t)
+}
+
+// AlertRule is a condition, defined by a user, that opens an alert for every product or
+// warehouse it holds for. Rules can be narrowed to one product and one warehouse.
+type AlertRule struct {
+	Owned
+	UUID          string        `gorm:"type:uuid;primaryKey" json:"uuid"`
+	Name          string        `gorm:"size:100;not null" json:"name"`
+	Type          AlertRuleType `gorm:"size:30;not null" json:"type"`
+	ProductUUID   *string       `gorm:"type:uuid" json:"productUuid"`   // Only this product; every product when empty
+	WarehouseUUID *string       `gorm:"type:uuid" json:"warehouseUuid"` // Only this warehouse; every warehouse when empty
+	Threshold     int           `gorm:"not null" json:"threshold"`      // Units, percent or days, depending on the type
+	Enabled       bool          `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt     time.Time     `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time     `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (r *AlertRule) BeforeCreate(tx *gorm.DB) (err error) {
+	r.UUID = uuid.New().String()
+	return
+}
+
+// AlertStatus is where an alert stands in its lifecycle
+type AlertStatus string
+
+const (
+	AlertOpen         AlertStatus = "open"
+	AlertAcknowledged AlertStatus = "acknowledged" // Someone is on it; it stays until resolved
+	AlertSnoozed      AlertStatus = "snoozed"      // Silenced until SnoozedUntil, when it opens again if still firing
+	AlertResolved     AlertStatus = "resolved"     // The condition cleared, or someone resolved it
+)
+
+var AlertStatuses = []AlertStatus{AlertOpen, AlertAcknowledged, AlertSnoozed, AlertResolved}
+
+func (s AlertStatus) IsValid() bool {
+	return slices.Contains(AlertStatuses, s)
+}
+
+// AlertActorSystem is the actor of the transitions the rules engine makes itself
+const AlertActorSystem = "system"
+
+// Alert is one occurrence of a rule's condition for one product, warehouse or adjustment.
+// A rule has at most one unresolved alert per subject; once resolved, the next occurrence opens
+// a new alert.
+type Alert struct {
+	Owned
+	UUID           string            `gorm:"type:uuid;primaryKey" json:"uuid"`
+	RuleUUID       string            `gorm:"type:uuid;not null" json:"ruleUuid"`
+	Rule           *AlertRule        `gorm:"foreignKey:RuleUUID;references:UUID" json:"rule,omitempty"`
+	Type           AlertRuleType     `gorm:"size:30;not null" json:"type"`
+	SubjectKey     string            `gorm:"size:100;not null" json:"-"` // What the alert is about, unique among the rule's unresolved alerts
+	ProductUUID    *string           `gorm:"type:uuid" json:"productUuid"`
+	WarehouseUUID  *string           `gorm:"type:uuid" json:"warehouseUuid"`
+	MovementUUID   *string           `gorm:"type:uuid" json:"movementUuid,omitempty"` // The adjustment of a large_adjustment alert
+	Status         AlertStatus       `gorm:"size:20;not null" json:"status"`
+	Message        string            `gorm:"size:255;not null" json:"message"`
+	Value          int               `gorm:"not null" json:"value"`     // The stock, percent, units, days or drift last measured
+	Threshold      int               `gorm:"not null" json:"threshold"` // The rule's threshold when last measured
+	SnoozedUntil   *time.Time        `json:"snoozedUntil"`
+	AcknowledgedAt *time.Time        `json:"acknowledgedAt"`
+	AcknowledgedBy string            `gorm:"size:100" json:"acknowledgedBy,omitempty"`
+	ResolvedAt     *time.Time        `json:"resolvedAt"`
+	History        []AlertTransition `gorm:"foreignKey:AlertUUID;references:UUID" json:"history,omitempty"`
+	CreatedAt      time.Time         `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time         `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (a *Alert) BeforeCreate(tx *gorm.DB) (err error) {
+	a.UUID = uuid.New().String()
+	return
+}
+
+// Active reports whether the alert is unresolved
+func (a *Alert) Active() bool {
+	return a.Status != AlertResolved
+}
+
+// AlertTransition is one step in an alert's history: opened, acknowledged, snoozed, woken from
+// a snooze or resolved. ID orders the transitions of an organisation, so streams pick up from
+// the last one they sent.
+type AlertTransition struct {
+	Owned
+	ID        int64       `gorm:"primaryKey;autoIncrement" json:"id"`
+	AlertUUID string      `gorm:"type:uuid;not null" json:"alertUuid"`
+	Alert     *Alert      `gorm:"foreignKey:AlertUUID;references:UUID" json:"alert,omitempty"`
+	From      AlertStatus `gorm:"column:from_status;size:20" json:"from,omitempty"` // Empty when the alert was opened
+	To        AlertStatus `gorm:"column:to_status;size:20;not null" json:"to"`
+	Actor     string      `gorm:"size:100;not null" json:"actor"` // Who made the change; system for the rules engine
+	Note      string      `gorm:"size:255" json:"note,omitempty"`
+	Value     int         `gorm:"not null" json:"value"` // The alert's value at the time
+	CreatedAt time.Time   `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+}
+
+// AlertAction is a change someone asks for on an alert
+type AlertAction struct {
+	By    string     `json:"by"`    // Who is acting; the API key's name when empty
+	Note  string     `json:"note"`  // Why, kept in the history
+	Until *time.Time `json:"until"` // When a snooze ends
+}
+
+// AlertViolation is a product, warehouse or adjustment a rule's condition holds for
+type AlertViolation struct {
+	SubjectKey    string
+	ProductUUID   *string
+	WarehouseUUID *string
+	MovementUUID  *string
+	Value         int
+	Message       string
+}
+
+// AlertScope narrows an evaluation to what an event touched; empty fields match everything
+type AlertScope struct {
+	ProductUUID   string
+	WarehouseUUID string
+	MovementUUID  string
+}
+
+// AlertFilter narrows down alert listings; zero values are ignored
+type AlertFilter struct {
+	Status        AlertStatus
+	Type          AlertRuleType
+	RuleUUID      string
+	ProductUUID   string
+	WarehouseUUID string
+}
+
+func (f *AlertFilter) Validate() error {
+	if f.Status != "" && !f.Status.IsValid() {
+		return ErrAlertStatusInvalid
+	}
+	if f.Type != "" && !f.Type.IsValid() {
+		return ErrAlertRuleTypeInvalid
+	}
+	return nil
+}
+
+type AlertRepository interface {
+	CreateRule(ctx context.Context, rule *AlertRule) error
+	GetRules(ctx context.Context) ([]AlertRule, error)
+	GetEnabledRules(ctx context.Context, types ...AlertRuleType) ([]AlertRule, error)
+	GetRuleByID(ctx context.Context, uuid string) (*AlertRule, error)
+	UpdateRule(ctx context.Context, rule *AlertRule) error
+	DeleteRule(ctx context.Context, uuid string) error
+	Violations(ctx context.Context, rule *AlertRule, scope AlertScope, now time.Time) ([]AlertViolation, error)
+	GetActive(ctx context.Context, ruleUUID string, scope AlertScope) ([]Alert, error)
+	GetAll(ctx context.Context, filter AlertFilter, page, limit int) ([]Alert, int64, error)
+	GetByID(ctx context.Context, uuid string) (*Alert, error)
+	Open(ctx context.Context, alert *Alert) (bool, error)
+	Transition(ctx context.Context, uuid string, change func(*Alert) (*AlertTransition, error)) (*Alert, error)
+	Remeasure(ctx context.Context, alert *Alert) error
+	GetDueSnoozes(ctx context.Context, now time.Time) ([]Alert, error)
+	GetTransitionsAfter(ctx context.Context, id int64, limit int) ([]AlertTransition, error)
+	LastTransitionID(ctx context.Context) (int64, error)
+	FindTransitionsSince(ctx context.Context, since time.Time) ([]AlertTransition, error)
+}
+
+type AlertUsecase interface {
+	CreateRule(ctx context.Context, rule *AlertRule) error
+	GetRules(ctx context.Context) ([]AlertRule, error)
+	GetRuleByID(ctx context.Context, uuid string) (*AlertRule, error)
+	UpdateRule(ctx context.Context, uuid string, rule *AlertRule) error
+	DeleteRule(ctx context.Context, uuid string) error
+	GetAll(ctx context.Context, filter AlertFilter, page, limit int) ([]Alert, int64, error)
+	GetByID(ctx context.Context, uuid string) (*Alert, error)
+	Acknowledge(ctx context.Context, uuid string, action AlertAction) (*Alert, error)
+	Snooze(ctx context.Context, uuid string, action AlertAction) (*Alert, error)
+	Resolve(ctx context.Context, uuid string, action AlertAction) (*Alert, error)
+}
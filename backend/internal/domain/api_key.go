@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyScope is an operation an API key is allowed to perform
+type APIKeyScope string
+
+const (
+	ScopeCatalogRead   APIKeyScope = "catalog:read"   // Products, categories, suppliers and warehouses
+	ScopeCatalogWrite  APIKeyScope = "catalog:write"  // Creating, updating and deleting them
+	ScopeStockRead     APIKeyScope = "stock:read"     // Movements, stock documents and exports
+	ScopeStockIn       APIKeyScope = "stock:in"       // Stock in, receipts and direct stock additions
+	ScopeStockOut      APIKeyScope = "stock:out"      // Stock out and shipments
+	ScopeStockAdjust   APIKeyScope = "stock:adjust"   // Stock adjustments
+	ScopeStockTransfer APIKeyScope = "stock:transfer" // Transfers between warehouses
+	ScopeImport        APIKeyScope = "import"         // Bulk CSV/XLSX imports
+)
+
+// APIKeyScopes lists every scope a key can be granted
+var APIKeyScopes = []APIKeyScope{
+	ScopeCatalogRead, ScopeCatalogWrite, ScopeStockRead, ScopeStockIn,
+	ScopeStockOut, ScopeStockAdjust, ScopeStockTransfer, ScopeImport,
+}
+
+func (s APIKeyScope) IsValid() bool {
+	return slices.Contains(APIKeyScopes, s)
+}
+
+// APIKey lets an integration such as a POS terminal call the API without a human login.
+// It acts for one organisation. Only a hash of the key is stored; the key itself is shown once,
+// when it is created or rotated.
+type APIKey struct {
+	UUID             string        `gorm:"type:uuid;primaryKey" json:"uuid"`
+	OrganizationUUID string        `gorm:"type:uuid;not null" json:"organizationUuid"` // Defaults to the default organisation
+	Name             string        `gorm:"size:100;not null" json:"name"`
+	Prefix           string        `gorm:"size:32;not null;uniqueIndex" json:"prefix"` // Public start of the key, to tell keys apart
+	KeyHash          string        `gorm:"size:64;not null" json:"-"`
+	Scopes           []APIKeyScope `gorm:"serializer:json;type:jsonb;not null" json:"scopes"`
+	WarehouseUUIDs   []string      `gorm:"serializer:json;type:jsonb;not null" json:"warehouseUuids"` // Empty allows every warehouse
+	ExpiresAt        *time.Time    `json:"expiresAt"`
+	LastUsedAt       *time.Time    `json:"lastUsedAt"`
+	RevokedAt        *time.Time    `json:"revokedAt"`
+	CreatedAt        time.Time     `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt        time.Time     `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (k *APIKey) BeforeCreate(tx *gorm.DB) (err error) {
+	k.UUID = uuid.New().String()
+	return
+}
+
+// IssuedAPIKey is an API key together with its secret, returned only when it is created or rotated
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Active reports whether the key can still be used at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// RequireScope fails with FORBIDDEN unless the key was granted scope
+func (k *APIKey) RequireScope(scope APIKeyScope) error {
+	if !slices.Contains(k.Scopes, scope) {
+		return NewForbiddenError("API key lacks the " + string(scope) + " scope")
+	}
+	return nil
+}
+
+// AllowsWarehouse reports whether the key may post stock to the warehouse
+func (k *APIKey) AllowsWarehouse(warehouseUUID string) bool {
+	return len(k.WarehouseUUIDs) == 0 || slices.Contains(k.WarehouseUUIDs, warehouseUUID)
+}
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns a context carrying the key that authenticated the request
+func WithAPIKey(ctx context.Context, key *APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// APIKeyFromContext returns the key that authenticated the request, or nil for requests
+// made without one
+func APIKeyFromContext(ctx context.Context) *APIKey {
+	key, _ := ctx.Value(apiKeyContextKey{}).(*APIKey)
+	return key
+}
+
+// CheckWarehouseAccess fails with FORBIDDEN when the request was made with an API key
+// restricted to other warehouses
+func CheckWarehouseAccess(ctx context.Context, warehouseUUIDs ...string) error {
+	key := APIKeyFromContext(ctx)
+	if key == nil {
+		return nil
+	}
+	for _, warehouseUUID := range warehouseUUIDs {
+		if !key.AllowsWarehouse(warehouseUUID) {
+			return NewForbiddenError("API key is not allowed to post stock to warehouse " + warehouseUUID)
+		}
+	}
+	return nil
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetAll(ctx context.Context) ([]APIKey, error)
+	GetByID(ctx context.Context, uuid string) (*APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	UpdateSecret(ctx context.Context, uuid string, prefix string, keyHash string) error
+	Revoke(ctx context.Context, uuid string, at time.Time) error
+	TouchLastUsed(ctx context.Context, uuid string, at time.Time) error
+}
+
+type APIKeyUsecase interface {
+	Create(ctx context.Context, key *APIKey) (*IssuedAPIKey, error)
+	GetAll(ctx context.Context) ([]APIKey, error)
+	GetByID(ctx context.Context, uuid string) (*APIKey, error)
+	Rotate(ctx context.Context, uuid string) (*IssuedAPIKey, error)
+	Revoke(ctx context.Context, uuid string) error
+	Authenticate(ctx context.Context, key string) (*APIKey, error)
+}
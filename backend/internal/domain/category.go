@@ -9,8 +9,9 @@ import (
 )
 
 type Category struct {
+	Owned
 	UUID        string    `gorm:"type:uuid;primaryKey" json:"uuid"`
-	Name        string    `gorm:"size:100;not null;uniqueIndex" json:"name"`
+	Name        string    `gorm:"size:100;not null" json:"name"` // Unique within the organisation
 	Description string    `gorm:"type:text" json:"description"`
 	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
 	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
@@ -31,7 +32,7 @@ type CategoryRepository interface {
 
 type CategoryUsecase interface {
 	Create(ctx context.Context, category *Category) error
-	GetAll(ctx context.Context) ([]Category, error)
+	GetAll(ctx context.Context, limit int) ([]Category, error)
 	GetByID(ctx context.Context, uuid string) (*Category, error)
 	Update(ctx context.Context, uuid string, category *Category) error
 	Delete(ctx context.Context, uuid string) error
@@ -0,0 +1,95 @@
+package domain
+
+import "errors"
+
+// ErrorCode is the machine-readable kind of a domain error. It is returned to REST clients in
+// response.Response and to gRPC clients in the status details, so they can branch on it
+// without parsing messages.
+type ErrorCode string
+
+const (
+	ErrorCodeNotFound          ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation        ErrorCode = "VALIDATION_FAILED"
+	ErrorCodeConflict          ErrorCode = "CONFLICT"
+	ErrorCodeInsufficientStock ErrorCode = "INSUFFICIENT_STOCK"
+	ErrorCodeCapacityExceeded  ErrorCode = "CAPACITY_EXCEEDED"
+	ErrorCodeUnauthenticated   ErrorCode = "UNAUTHENTICATED" // Missing, unknown, expired or revoked credentials
+	ErrorCodeForbidden         ErrorCode = "FORBIDDEN"       // Valid credentials without the required scope or warehouse
+	ErrorCodeInternal          ErrorCode = "INTERNAL_SERVER_ERROR"
+)
+
+// FieldError names one invalid input field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a typed domain error. Err keeps the underlying cause, such as a GORM error,
+// reachable through errors.Is and errors.As.
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any error with the same code and message, so a NotFound built by a repository
+// matches the corresponding sentinel such as ErrWarehouseNotFound
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+// NewNotFoundError reports that no entity with the requested key exists
+func NewNotFoundError(entity string, err error) *Error {
+	return &Error{Code: ErrorCodeNotFound, Message: entity + " not found", Err: err}
+}
+
+// NewValidationError rejects the value of one input field
+func NewValidationError(field, message string) *Error {
+	return &Error{
+		Code:    ErrorCodeValidation,
+		Message: message,
+		Fields:  []FieldError{{Field: field, Message: message}},
+	}
+}
+
+// NewConflictError reports that the request clashes with existing data, e.g. a unique key
+func NewConflictError(message string, err error) *Error {
+	return &Error{Code: ErrorCodeConflict, Message: message, Err: err}
+}
+
+// NewForbiddenError reports that the caller may not perform the operation
+func NewForbiddenError(message string) *Error {
+	return &Error{Code: ErrorCodeForbidden, Message: message}
+}
+
+// AsError returns the domain error in err's chain, or nil when there is none
+func AsError(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return nil
+}
+
+// ErrorCodeOf returns the code of the domain error in err's chain; anything else is internal
+func ErrorCodeOf(err error) ErrorCode {
+	if domainErr := AsError(err); domainErr != nil {
+		return domainErr.Code
+	}
+	return ErrorCodeInternal
+}
+
+// IsNotFound reports whether err means the requested entity does not exist
+func IsNotFound(err error) bool {
+	return ErrorCodeOf(err) == ErrorCodeNotFound
+}
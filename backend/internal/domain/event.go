@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names something that happened to stock or the catalog, as published to webhooks
+type EventType string
+
+const (
+	EventMovementCreated   EventType = "movement.created"   // Any stock movement: stock in/out, adjustments, transfers, documents and imports
+	EventTransferCompleted EventType = "transfer.completed" // A transfer between warehouses, after both of its movements
+	EventProductCreated    EventType = "product.created"
+	EventProductUpdated    EventType = "product.updated"
+	EventProductDeleted    EventType = "product.deleted"
+	EventProductLowStock   EventType = "product.low_stock" // A product's stock fell to or below its low stock threshold
+	EventWarehouseCreated  EventType = "warehouse.created"
+	EventWarehouseUpdated  EventType = "warehouse.updated"
+	EventWarehouseDeleted  EventType = "warehouse.deleted" // A warehouse was deactivated
+	EventAlertChanged      EventType = "alert.changed"     // An alert was opened, acknowledged, snoozed, reopened or resolved
+)
+
+// EventTypes lists every event a webhook can subscribe to
+var EventTypes = []EventType{
+	EventMovementCreated, EventTransferCompleted, EventProductCreated,
+	EventProductUpdated, EventProductDeleted, EventProductLowStock,
+	EventWarehouseCreated, EventWarehouseUpdated, EventWarehouseDeleted,
+	EventAlertChanged,
+}
+
+func (t EventType) IsValid() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// Event is the JSON body delivered to webhooks. ID is the same for every delivery of the
+// event, redeliveries included, so receivers can drop duplicates.
+type Event struct {
+	ID               string    `json:"id"`
+	Type             EventType `json:"type"`
+	OrganizationUUID string    `json:"organizationUuid"` // Only this organisation's webhooks and streams see the event
+	OccurredAt       time.Time `json:"occurredAt"`
+	Data             any       `json:"data"`
+}
+
+// NewEvent describes something that happened to data, on behalf of the organisation owning it
+func NewEvent(eventType EventType, data any) Event {
+	event := Event{ID: uuid.New().String(), Type: eventType, OccurredAt: time.Now().UTC(), Data: data}
+	if owned, ok := data.(interface{ Organization() string }); ok {
+		event.OrganizationUUID = owned.Organization()
+	}
+	return event
+}
+
+// Aggregates group events about one record; the outbox delivers the events of an aggregate in
+// the order they were written
+const (
+	AggregateProduct   = "product" // A product, its movements and transfers
+	AggregateWarehouse = "warehouse"
+	AggregateAlert     = "alert" // An alert and its transitions
+)
+
+// Aggregate returns the type and ID of the record the event is about
+func (e Event) Aggregate() (string, string) {
+	switch data := e.Data.(type) {
+	case *Product:
+		return AggregateProduct, data.UUID
+	case StockMovement:
+		return AggregateProduct, data.ProductUUID
+	case *StockTransfer:
+		return AggregateProduct, data.ProductUUID
+	case *Warehouse:
+		return AggregateWarehouse, data.UUID
+	case *AlertTransition:
+		return AggregateAlert, data.AlertUUID
+	}
+	return "", ""
+}
+
+// EventSink receives the events dispatched from the outbox. An event reaches a sink at least
+// once: after a failure in any sink it is delivered to all of them again, so sinks drop
+// duplicates by Event.ID when that matters.
+type EventSink interface {
+	Name() string
+	Deliver(ctx context.Context, event Event) error
+}
+
+// ProductEvents describes a change of a product from before to after; before is nil for a
+// created product and after is nil for a deleted one
+func ProductEvents(before, after *Product) []Event {
+	if after == nil {
+		return []Event{NewEvent(EventProductDeleted, before)}
+	}
+	if before == nil {
+		events := []Event{NewEvent(EventProductCreated, after)}
+		if after.IsLowStock() {
+			events = append(events, NewEvent(EventProductLowStock, after))
+		}
+		return events
+	}
+	events := []Event{NewEvent(EventProductUpdated, after)}
+	// Only when the product crosses its threshold, not on every update while it stays low
+	if after.IsLowStock() && !before.IsLowStock() {
+		events = append(events, NewEvent(EventProductLowStock, after))
+	}
+	return events
+}
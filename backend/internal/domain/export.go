@@ -0,0 +1,41 @@
+package domain
+
+// Export rows flatten a record together with the names of the entities it references,
+// so a spreadsheet can be read without looking up UUIDs.
+
+type StockMovementExport struct {
+	StockMovement
+	ProductSKU      string
+	ProductTitle    string
+	WarehouseName   string
+	ToWarehouseName string
+}
+
+type StockInExport struct {
+	StockIn
+	ProductSKU    string
+	ProductTitle  string
+	WarehouseName string
+	SupplierName  string
+}
+
+type StockOutExport struct {
+	StockOut
+	ProductSKU    string
+	ProductTitle  string
+	WarehouseName string
+}
+
+type StockAdjustmentExport struct {
+	StockAdjustment
+	ProductSKU    string
+	ProductTitle  string
+	WarehouseName string
+}
+
+type WarehouseStockExport struct {
+	WarehouseStock
+	ProductSKU    string
+	ProductTitle  string
+	WarehouseName string
+}
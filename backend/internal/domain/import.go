@@ -0,0 +1,52 @@
+package domain
+
+// ImportEntity identifies what kind of records a bulk import file contains
+type ImportEntity string
+
+const (
+	ImportEntityProducts   ImportEntity = "products"
+	ImportEntityCategories ImportEntity = "categories"
+	ImportEntitySuppliers  ImportEntity = "suppliers"
+	ImportEntityWarehouses ImportEntity = "warehouses"
+	ImportEntityStock      ImportEntity = "stock" // Opening stock balances per warehouse
+)
+
+const DefaultImportBatchSize = 500
+
+var (
+	ErrImportEntityUnknown   = NewValidationError("entity", "unknown import entity")
+	ErrImportMissingColumns  = NewValidationError("file", "import file is missing required columns")
+	ErrImportQuantityInvalid = NewValidationError("quantity", "quantity cannot be negative")
+)
+
+type ImportOptions struct {
+	DryRun    bool   // Validate every row without writing anything
+	BatchSize int    // Rows committed per transaction
+	CreatedBy string // Recorded on stock movements created by the import
+}
+
+// ImportRowError describes why a single row of the file was rejected.
+// Row numbers are 1-based and count the header, so they match what a spreadsheet shows.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Entity    ImportEntity     `json:"entity"`
+	DryRun    bool             `json:"dryRun"`
+	TotalRows int              `json:"totalRows"`
+	ValidRows int              `json:"validRows"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Failed    int              `json:"failed"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// OpeningBalance sets the absolute quantity of a product held in a warehouse
+type OpeningBalance struct {
+	ProductUUID   string
+	WarehouseUUID string
+	Quantity      int
+}
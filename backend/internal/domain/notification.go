@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationKind is what a notification emailed to a warehouse manager is about
+type NotificationKind string
+
+const (
+	NotificationLowStock        NotificationKind = "low_stock"        // A product stocked in the manager's warehouse fell to or below its threshold
+	NotificationCapacityWarning NotificationKind = "capacity_warning" // The manager's warehouse filled to the warning level of its capacity
+)
+
+// NotificationKinds lists every kind a manager can choose to receive
+var NotificationKinds = []NotificationKind{NotificationLowStock, NotificationCapacityWarning}
+
+func (k NotificationKind) IsValid() bool {
+	return slices.Contains(NotificationKinds, k)
+}
+
+// NotificationFrequency is how often a manager is emailed: at once, or in an hourly or daily digest
+type NotificationFrequency string
+
+const (
+	NotificationImmediate NotificationFrequency = "immediate"
+	NotificationHourly    NotificationFrequency = "hourly" // Digest at the top of every hour
+	NotificationDaily     NotificationFrequency = "daily"  // Digest once a day at the configured hour
+	NotificationOff       NotificationFrequency = "off"    // Nothing is queued
+)
+
+var NotificationFrequencies = []NotificationFrequency{NotificationImmediate, NotificationHourly, NotificationDaily, NotificationOff}
+
+func (f NotificationFrequency) IsValid() bool {
+	return slices.Contains(NotificationFrequencies, f)
+}
+
+// Due returns when a notification queued at now is sent: now, the next full hour, or the next
+// dailyHour o'clock UTC
+func (f NotificationFrequency) Due(now time.Time, dailyHour int) time.Time {
+	now = now.UTC()
+	switch f {
+	case NotificationHourly:
+		return now.Truncate(time.Hour).Add(time.Hour)
+	case NotificationDaily:
+		due := time.Date(now.Year(), now.Month(), now.Day(), dailyHour, 0, 0, 0, time.UTC)
+		if !due.After(now) {
+			due = due.AddDate(0, 0, 1)
+		}
+		return due
+	}
+	return now
+}
+
+// NotificationPreference is how one manager, identified by email address, wants to be notified.
+// Managers without one get every kind immediately.
+type NotificationPreference struct {
+	Owned
+	UUID      string                `gorm:"type:uuid;primaryKey" json:"uuid"`
+	Email     string                `gorm:"size:255;not null" json:"email"` // Unique within the organisation
+	Frequency NotificationFrequency `gorm:"size:20;not null" json:"frequency"`
+	Kinds     []NotificationKind    `gorm:"serializer:json;type:jsonb;not null" json:"kinds"` // Empty receives every kind
+	CreatedAt time.Time             `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time             `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (p *NotificationPreference) BeforeCreate(tx *gorm.DB) (err error) {
+	p.UUID = uuid.New().String()
+	return
+}
+
+// DefaultNotificationPreference is the preference of a manager who has not set one
+func DefaultNotificationPreference(email string) *NotificationPreference {
+	return &NotificationPreference{Email: email, Frequency: NotificationImmediate, Kinds: []NotificationKind{}}
+}
+
+func (p *NotificationPreference) Receives(kind NotificationKind) bool {
+	return p.Frequency != NotificationOff && (len(p.Kinds) == 0 || slices.Contains(p.Kinds, kind))
+}
+
+// NotificationStatus is where a notification stands
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending" // Waiting for its digest, its first attempt or a retry
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed" // Every attempt failed
+)
+
+// Notification is one thing a manager is told about by email, alone or in a digest, and the
+// log of how sending it went. It records the figures as they were when it was queued.
+type Notification struct {
+	Owned
+	UUID          string                `gorm:"type:uuid;primaryKey" json:"uuid"`
+	Recipient     string                `gorm:"size:255;not null" json:"recipient"`
+	Kind          NotificationKind      `gorm:"size:30;not null" json:"kind"`
+	SubjectUUID   string                `gorm:"type:uuid;not null" json:"subjectUuid"` // The product or warehouse it is about
+	SubjectName   string                `gorm:"size:100;not null" json:"subjectName"`  // Product title or warehouse name
+	SKU           string                `gorm:"size:50" json:"sku,omitempty"`          // For products
+	Quantity      int                   `gorm:"not null" json:"quantity"`              // The product's stock, or the warehouse's total stock
+	Threshold     int                   `gorm:"not null" json:"threshold"`             // The product's low stock threshold, or the warehouse's capacity
+	EventID       string                `gorm:"type:uuid;not null" json:"eventId"`     // The event it was queued for
+	Frequency     NotificationFrequency `gorm:"size:20;not null" json:"frequency"`     // The recipient's preference when it was queued
+	Status        NotificationStatus    `gorm:"size:20;not null" json:"status"`
+	Attempts      int                   `gorm:"not null;default:0" json:"attempts"`
+	Error         string                `gorm:"type:text" json:"error,omitempty"` // Why the last attempt failed
+	NextAttemptAt *time.Time            `json:"nextAttemptAt"`
+	SentAt        *time.Time            `json:"sentAt"`
+	CreatedAt     time.Time             `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time             `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	n.UUID = uuid.New().String()
+	return
+}
+
+// Utilization is the percentage of the threshold the quantity reached, as shown in capacity warnings
+func (n *Notification) Utilization() int {
+	if n.Threshold <= 0 {
+		return 0
+	}
+	return n.Quantity * 100 / n.Threshold
+}
+
+// NotificationEmail is one email to a recipient: a single notification, or a digest of several
+type NotificationEmail struct {
+	To            string
+	Frequency     NotificationFrequency
+	Notifications []Notification
+}
+
+// NotificationFilter narrows down the notification log; zero values are ignored
+type NotificationFilter struct {
+	Recipient string
+	Status    NotificationStatus
+}
+
+type NotificationRepository interface {
+	Queue(ctx context.Context, notification *Notification, since time.Time) (bool, error)
+	GetAll(ctx context.Context, filter NotificationFilter, page, limit int) ([]Notification, int64, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Notification, error)
+	Save(ctx context.Context, notifications []Notification) error
+	GetPreferences(ctx context.Context) ([]NotificationPreference, error)
+	GetPreference(ctx context.Context, email string) (*NotificationPreference, error)
+	SavePreference(ctx context.Context, preference *NotificationPreference) error
+	DeletePreference(ctx context.Context, email string) error
+}
+
+type NotificationUsecase interface {
+	GetAll(ctx context.Context, filter NotificationFilter, page, limit int) ([]Notification, int64, error)
+	GetPreferences(ctx context.Context) ([]NotificationPreference, error)
+	GetPreference(ctx context.Context, email string) (*NotificationPreference, error)
+	SavePreference(ctx context.Context, email string, preference *NotificationPreference) error
+	DeletePreference(ctx context.Context, email string) error
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultOrganizationUUID owns the data that existed before organisations were introduced, and
+// everything done by requests made without an API key
+const DefaultOrganizationUUID = "00000000-0000-0000-0000-000000000001"
+
+// Organization is a client company using StockHub. Its catalog, stock and movements are only
+// visible to requests made on its behalf.
+type Organization struct {
+	UUID      string    `gorm:"type:uuid;primaryKey" json:"uuid"`
+	Name      string    `gorm:"size:100;not null;uniqueIndex" json:"name"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (o *Organization) BeforeCreate(tx *gorm.DB) (err error) {
+	o.UUID = uuid.New().String()
+	return
+}
+
+// Owned is embedded in every record that belongs to an organisation. The tenant GORM plugin
+// fills it in on create and limits every other statement to the organisation in its context.
+type Owned struct {
+	OrganizationUUID string `gorm:"type:uuid;not null" json:"-"`
+}
+
+func (o Owned) Organization() string {
+	return o.OrganizationUUID
+}
+
+// OwnedModels lists the records stored per organisation, besides API keys and webhooks, which
+// carry their organisation explicitly
+var OwnedModels = []any{
+	&Category{}, &Supplier{}, &Product{}, &Warehouse{}, &WarehouseStock{}, &StockTransfer{},
+	&StockMovement{}, &StockIn{}, &StockOut{}, &StockAdjustment{}, &StockDocument{},
+	&StockDocumentLine{}, &NotificationPreference{}, &Notification{}, &APIKey{}, &Webhook{},
+	&AlertRule{}, &Alert{}, &AlertTransition{},
+}
+
+// organizationScope is what a context's repository calls may see: one organisation, or all of them
+type organizationScope struct {
+	uuid string
+	all  bool
+}
+
+type organizationContextKey struct{}
+
+// WithOrganization returns a context whose repository calls only see and change the records of
+// the organisation
+func WithOrganization(ctx context.Context, organizationUUID string) context.Context {
+	return context.WithValue(ctx, organizationContextKey{}, organizationScope{uuid: organizationUUID})
+}
+
+// WithAllOrganizations returns a context whose repository calls see the records of every
+// organisation, for the admin API and background work not done on behalf of one
+func WithAllOrganizations(ctx context.Context) context.Context {
+	return context.WithValue(ctx, organizationContextKey{}, organizationScope{all: true})
+}
+
+// OrganizationFromContext returns the organisation ctx is limited to; ok is false when it is
+// not limited to one
+func OrganizationFromContext(ctx context.Context) (string, bool) {
+	scope, _ := ctx.Value(organizationContextKey{}).(organizationScope)
+	return scope.uuid, scope.uuid != ""
+}
+
+// AllOrganizations reports whether ctx may see the records of every organisation
+func AllOrganizations(ctx context.Context) bool {
+	scope, _ := ctx.Value(organizationContextKey{}).(organizationScope)
+	return scope.all
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *Organization) error
+	GetAll(ctx context.Context) ([]Organization, error)
+	GetByID(ctx context.Context, uuid string) (*Organization, error)
+	Update(ctx context.Context, uuid string, organization *Organization) error
+}
+
+type OrganizationUsecase interface {
+	Create(ctx context.Context, organization *Organization) error
+	GetAll(ctx context.Context) ([]Organization, error)
+	GetByID(ctx context.Context, uuid string) (*Organization, error)
+	Update(ctx context.Context, uuid string, organization *Organization) error
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxMessage is an event written in the same transaction as the change it describes, so the
+// event exists exactly when the change does. The dispatcher delivers it to every sink afterwards,
+// in ID order within its aggregate, and deletes it once it has been delivered for a while.
+type OutboxMessage struct {
+	ID               int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID          string          `gorm:"type:uuid;not null" json:"eventId"`
+	EventType        EventType       `gorm:"type:varchar(50);not null" json:"eventType"`
+	OrganizationUUID string          `gorm:"type:uuid;not null" json:"organizationUuid"`
+	AggregateType    string          `gorm:"type:varchar(50);not null" json:"aggregateType"`
+	AggregateID      string          `gorm:"type:uuid;not null" json:"aggregateId"`
+	Payload          json.RawMessage `gorm:"serializer:json;type:jsonb;not null" json:"payload"` // The event's data
+	OccurredAt       time.Time       `gorm:"not null" json:"occurredAt"`
+	Attempts         int             `gorm:"not null;default:0" json:"attempts"` // Failed deliveries so far
+	LastError        string          `json:"lastError"`
+	NextAttemptAt    time.Time       `gorm:"not null" json:"nextAttemptAt"`
+	DeliveredAt      *time.Time      `json:"deliveredAt"`
+	CreatedAt        time.Time       `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
+
+// NewOutboxMessage stores event for dispatching
+func NewOutboxMessage(event Event) (OutboxMessage, error) {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return OutboxMessage{}, err
+	}
+	aggregateType, aggregateID := event.Aggregate()
+	return OutboxMessage{
+		EventID:          event.ID,
+		EventType:        event.Type,
+		OrganizationUUID: event.OrganizationUUID,
+		AggregateType:    aggregateType,
+		AggregateID:      aggregateID,
+		Payload:          payload,
+		OccurredAt:       event.OccurredAt,
+		NextAttemptAt:    event.OccurredAt,
+	}, nil
+}
+
+// Event returns the stored event, with its data as the JSON it was stored as
+func (m *OutboxMessage) Event() Event {
+	return Event{ID: m.EventID, Type: m.EventType, OrganizationUUID: m.OrganizationUUID, OccurredAt: m.OccurredAt, Data: m.Payload}
+}
+
+// EnqueueEvents adds events to the outbox within tx, so they are only ever dispatched if the
+// transaction making the change they describe commits
+func EnqueueEvents(tx *gorm.DB, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	messages := make([]OutboxMessage, 0, len(events))
+	for _, event := range events {
+		message, err := NewOutboxMessage(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+	// A new statement on the same connection, so the caller's clauses and hooks don't carry over
+	return tx.Session(&gorm.Session{NewDB: true}).Create(&messages).Error
+}
+
+type OutboxRepository interface {
+	Dispatch(ctx context.Context, now time.Time, limit int, deliver func([]OutboxMessage) []OutboxMessage) (int, error)
+	DeleteDelivered(ctx context.Context, before time.Time) (int64, error)
+	Notify(ctx context.Context, channel string, payload string) error
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	pb "github.com/shirloin/stockhub/proto/product"
@@ -11,13 +12,14 @@ import (
 )
 
 type Product struct {
+	Owned
 	UUID              string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	Title             string    `gorm:"size:100;not null" json:"title"`
 	Description       string    `gorm:"type:text" json:"description"`
 	Price             int       `gorm:"not null" json:"price"`
 	Stock             int       `gorm:"not null;default:0" json:"stock"`
 	LowStockThreshold int       `gorm:"not null;default:10" json:"lowStockThreshold"`
-	SKU               string    `gorm:"size:50;uniqueIndex" json:"sku"`
+	SKU               string    `gorm:"size:50" json:"sku"` // Unique within the organisation
 	Barcode           string    `gorm:"size:100;index" json:"barcode"`
 	ImageURL          string    `gorm:"type:text" json:"imageUrl"` // Product image URL
 	CategoryUUID      string    `gorm:"type:uuid;index" json:"categoryUuid"`
@@ -32,6 +34,78 @@ func (p *Product) IsLowStock() bool {
 	return p.Stock <= p.LowStockThreshold
 }
 
+// ProductStockStatus buckets products the same way the low stock reports do
+type ProductStockStatus string
+
+const (
+	ProductStockStatusIn  ProductStockStatus = "in"  // Above the low stock threshold
+	ProductStockStatusLow ProductStockStatus = "low" // In stock but at or below the threshold
+	ProductStockStatusOut ProductStockStatus = "out" // No stock left
+)
+
+type ProductSortField string
+
+const (
+	ProductSortTitle     ProductSortField = "title"
+	ProductSortSKU       ProductSortField = "sku"
+	ProductSortPrice     ProductSortField = "price"
+	ProductSortStock     ProductSortField = "stock"
+	ProductSortCreatedAt ProductSortField = "createdAt"
+	ProductSortUpdatedAt ProductSortField = "updatedAt"
+)
+
+type ProductSort struct {
+	Field ProductSortField
+	Desc  bool
+}
+
+// ProductFilter narrows down and orders product queries; zero values are ignored.
+// Without an explicit sort, search results are ordered by relevance and everything else by newest first.
+type ProductFilter struct {
+	Search       string // Matched against title, description, SKU and barcode
+	CategoryUUID string
+	SupplierUUID string
+	MinPrice     *int
+	MaxPrice     *int
+	StockStatus  ProductStockStatus
+	Sort         []ProductSort
+}
+
+// ParseProductSort reads a comma separated list of fields, each optionally prefixed
+// with "-" for descending order, e.g. "price,-createdAt"
+func ParseProductSort(value string) ([]ProductSort, error) {
+	var sorts []ProductSort
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sort := ProductSort{Field: ProductSortField(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+		switch sort.Field {
+		case ProductSortTitle, ProductSortSKU, ProductSortPrice, ProductSortStock, ProductSortCreatedAt, ProductSortUpdatedAt:
+			sorts = append(sorts, sort)
+		default:
+			return nil, ErrProductSortInvalid
+		}
+	}
+	return sorts, nil
+}
+
+func (f *ProductFilter) Validate() error {
+	switch f.StockStatus {
+	case "", ProductStockStatusIn, ProductStockStatusLow, ProductStockStatusOut:
+	default:
+		return ErrProductStockStatusInvalid
+	}
+	if (f.MinPrice != nil && *f.MinPrice < 0) || (f.MaxPrice != nil && *f.MaxPrice < 0) {
+		return ErrProductPriceRangeInvalid
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return ErrProductPriceRangeInvalid
+	}
+	return nil
+}
+
 func (p *Product) BeforeCreate(tx *gorm.DB) (err error) {
 	p.UUID = uuid.New().String()
 	return
@@ -49,6 +123,25 @@ func (p *Product) ToProto() *pb.Product {
 		Barcode:           p.Barcode,
 		UpdatedAt:         p.UpdatedAt.Format(time.RFC3339),
 		CreatedAt:         p.CreatedAt.Format(time.RFC3339),
+		ImageUrl:          p.ImageURL,
+		CategoryUuid:      p.CategoryUUID,
+		SupplierUuid:      p.SupplierUUID,
+	}
+}
+
+// ProductFromProto copies the editable fields of a proto product; uuid and timestamps are ignored
+func ProductFromProto(p *pb.Product) *Product {
+	return &Product{
+		Title:             p.GetTitle(),
+		Description:       p.GetDescription(),
+		Price:             int(p.GetPrice()),
+		Stock:             int(p.GetStock()),
+		LowStockThreshold: int(p.GetLowStockThreshold()),
+		SKU:               p.GetSku(),
+		Barcode:           p.GetBarcode(),
+		ImageURL:          p.GetImageUrl(),
+		CategoryUUID:      p.GetCategoryUuid(),
+		SupplierUUID:      p.GetSupplierUuid(),
 	}
 }
 
@@ -58,7 +151,6 @@ type ProductRepository interface {
 	GetByID(ctx context.Context, uuid string) (*Product, error)
 	Update(ctx context.Context, uuid string, product *Product) error
 	Delete(ctx context.Context, uuid string) error
-	SubscribeToChanges() <-chan []Product
 }
 
 type ProductUsecase interface {
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockDocumentType distinguishes goods receipts from shipments
+type StockDocumentType string
+
+const (
+	StockDocumentReceipt  StockDocumentType = "RECEIPT"  // Goods received into a warehouse
+	StockDocumentShipment StockDocumentType = "SHIPMENT" // Goods shipped out of a warehouse
+)
+
+// StockDocument is a receipt or shipment with many lines, posted as a single unit.
+// Each line produces its own StockMovement that points back to the document.
+type StockDocument struct {
+	Owned
+	UUID            string              `gorm:"type:uuid;primaryKey" json:"uuid"`
+	DocumentType    StockDocumentType   `gorm:"type:varchar(20);not null;uniqueIndex:idx_stock_documents_reference,priority:1" json:"documentType"`
+	ReferenceNumber string              `gorm:"size:100;not null;uniqueIndex:idx_stock_documents_reference,priority:2" json:"referenceNumber"` // PO number for receipts, SO number for shipments
+	WarehouseUUID   string              `gorm:"type:uuid;not null;index" json:"warehouseUuid"`
+	Warehouse       Warehouse           `gorm:"foreignKey:WarehouseUUID;references:UUID" json:"warehouse,omitempty"`
+	SupplierUUID    string              `gorm:"type:uuid;index" json:"supplierUuid"` // Receipts only
+	Supplier        Supplier            `gorm:"foreignKey:SupplierUUID;references:UUID" json:"supplier,omitempty"`
+	CustomerName    string              `gorm:"size:100" json:"customerName"` // Shipments only
+	DocumentDate    time.Time           `gorm:"not null;index" json:"documentDate"`
+	CreatedBy       string              `gorm:"size:100" json:"createdBy"`
+	Notes           string              `gorm:"type:text" json:"notes"`
+	TotalQuantity   int                 `gorm:"not null;default:0" json:"totalQuantity"`
+	Lines           []StockDocumentLine `gorm:"foreignKey:DocumentUUID;references:UUID" json:"lines"`
+	CreatedAt       time.Time           `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt       time.Time           `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (d *StockDocument) BeforeCreate(tx *gorm.DB) (err error) {
+	d.UUID = uuid.New().String()
+	if d.DocumentDate.IsZero() {
+		d.DocumentDate = time.Now()
+	}
+	return
+}
+
+type StockDocumentLine struct {
+	Owned
+	UUID         string    `gorm:"type:uuid;primaryKey" json:"uuid"`
+	DocumentUUID string    `gorm:"type:uuid;not null;index" json:"documentUuid"`
+	LineNumber   int       `gorm:"not null" json:"lineNumber"`
+	ProductUUID  string    `gorm:"type:uuid;not null;index" json:"productUuid"`
+	Product      Product   `gorm:"foreignKey:ProductUUID;references:UUID" json:"product,omitempty"`
+	Quantity     int       `gorm:"not null" json:"quantity"`            // Always positive; direction comes from the document type
+	MovementUUID string    `gorm:"type:uuid;index" json:"movementUuid"` // Movement created when the line was posted
+	Notes        string    `gorm:"type:text" json:"notes"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (l *StockDocumentLine) BeforeCreate(tx *gorm.DB) (err error) {
+	l.UUID = uuid.New().String()
+	return
+}
+
+// StockDocumentLineError ties a posting failure to the document line that caused it
+type StockDocumentLineError struct {
+	Line int
+	Err  error
+}
+
+func (e *StockDocumentLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *StockDocumentLineError) Unwrap() error {
+	return e.Err
+}
+
+func (d *StockDocument) Validate() error {
+	if d.DocumentType != StockDocumentReceipt && d.DocumentType != StockDocumentShipment {
+		return ErrStockDocumentTypeInvalid
+	}
+	if strings.TrimSpace(d.ReferenceNumber) == "" {
+		return ErrStockDocumentReferenceRequired
+	}
+	if len(d.ReferenceNumber) > 100 {
+		return ErrStockDocumentReferenceTooLong
+	}
+	if d.WarehouseUUID == "" {
+		return ErrStockDocumentWarehouseRequired
+	}
+	if len(d.Lines) == 0 {
+		return ErrStockDocumentLinesRequired
+	}
+	for i, line := range d.Lines {
+		if line.ProductUUID == "" {
+			return &StockDocumentLineError{Line: i + 1, Err: ErrStockDocumentProductRequired}
+		}
+		if line.Quantity <= 0 {
+			return &StockDocumentLineError{Line: i + 1, Err: ErrQuantityInvalid}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,17 +27,19 @@ const (
 type AdjustmentReason string
 
 const (
-	AdjustmentReasonDamage     AdjustmentReason = "DAMAGE"
-	AdjustmentReasonLoss       AdjustmentReason = "LOSS"
-	AdjustmentReasonExpired    AdjustmentReason = "EXPIRED"
-	AdjustmentReasonCorrection AdjustmentReason = "CORRECTION"
-	AdjustmentReasonTheft      AdjustmentReason = "THEFT"
-	AdjustmentReasonOther      AdjustmentReason = "OTHER"
+	AdjustmentReasonDamage         AdjustmentReason = "DAMAGE"
+	AdjustmentReasonLoss           AdjustmentReason = "LOSS"
+	AdjustmentReasonExpired        AdjustmentReason = "EXPIRED"
+	AdjustmentReasonCorrection     AdjustmentReason = "CORRECTION"
+	AdjustmentReasonTheft          AdjustmentReason = "THEFT"
+	AdjustmentReasonOther          AdjustmentReason = "OTHER"
+	AdjustmentReasonOpeningBalance AdjustmentReason = "OPENING_BALANCE" // Initial balance loaded by an import
 )
 
 // StockMovement represents all stock movements (IN, OUT, TRANSFER, ADJUSTMENT)
 type StockMovement struct {
-	UUID             string            `gorm:"type:uuid;primaryKey" json:"uuid"`
+	Owned
+	UUID             string            `gorm:"type:uuid;primaryKey;index:idx_stock_movements_ledger,priority:3" json:"uuid"`
 	ProductUUID      string            `gorm:"type:uuid;not null;index" json:"productUuid"`
 	WarehouseUUID    string            `gorm:"type:uuid;not null;index" json:"warehouseUuid"`
 	Product          Product           `gorm:"foreignKey:ProductUUID;references:UUID" json:"product,omitempty"`
@@ -46,10 +51,12 @@ type StockMovement struct {
 	ReferenceNumber  string            `gorm:"size:100;index" json:"referenceNumber"`    // PO number, SO number, etc.
 	ToWarehouseUUID  string            `gorm:"type:uuid;index" json:"toWarehouseUuid"`   // For transfers
 	AdjustmentReason AdjustmentReason  `gorm:"type:varchar(20)" json:"adjustmentReason"` // For adjustments
+	DocumentUUID     string            `gorm:"type:uuid;index" json:"documentUuid"`      // Receipt or shipment this movement belongs to
 	Notes            string            `gorm:"type:text" json:"notes"`
-	CreatedBy        string            `gorm:"size:100" json:"createdBy"` // User who created the movement
-	MovementDate     time.Time         `gorm:"not null;index" json:"movementDate"`
-	CreatedAt        time.Time         `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	CreatedBy        string            `gorm:"size:100" json:"createdBy"`                   // User who created the movement
+	APIKeyUUID       *string           `gorm:"type:uuid;index" json:"apiKeyUuid,omitempty"` // API key the movement was posted with
+	MovementDate     time.Time         `gorm:"not null;index;index:idx_stock_movements_ledger,priority:1" json:"movementDate"`
+	CreatedAt        time.Time         `gorm:"column:created_at;autoCreateTime;index:idx_stock_movements_ledger,priority:2" json:"createdAt"`
 	UpdatedAt        time.Time         `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
 }
 
@@ -58,11 +65,22 @@ func (sm *StockMovement) BeforeCreate(tx *gorm.DB) (err error) {
 	if sm.MovementDate.IsZero() {
 		sm.MovementDate = time.Now()
 	}
+	// Every movement, however it is posted, is attributed to the API key of the request
+	if key := APIKeyFromContext(tx.Statement.Context); key != nil && sm.APIKeyUUID == nil {
+		sm.APIKeyUUID = &key.UUID
+	}
 	return
 }
 
+// AfterCreate queues movement.created in the transaction creating the movement, whichever
+// repository creates it
+func (sm *StockMovement) AfterCreate(tx *gorm.DB) (err error) {
+	return EnqueueEvents(tx, NewEvent(EventMovementCreated, *sm))
+}
+
 // StockIn represents receiving goods (Stock IN)
 type StockIn struct {
+	Owned
 	UUID            string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	ProductUUID     string    `gorm:"type:uuid;not null;index" json:"productUuid"`
 	WarehouseUUID   string    `gorm:"type:uuid;not null;index" json:"warehouseUuid"`
@@ -89,6 +107,7 @@ func (si *StockIn) BeforeCreate(tx *gorm.DB) (err error) {
 
 // StockOut represents shipments/sales (Stock OUT)
 type StockOut struct {
+	Owned
 	UUID          string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	ProductUUID   string    `gorm:"type:uuid;not null;index" json:"productUuid"`
 	WarehouseUUID string    `gorm:"type:uuid;not null;index" json:"warehouseUuid"`
@@ -114,6 +133,7 @@ func (so *StockOut) BeforeCreate(tx *gorm.DB) (err error) {
 
 // StockAdjustment represents stock adjustments (damage, loss, corrections)
 type StockAdjustment struct {
+	Owned
 	UUID           string           `gorm:"type:uuid;primaryKey" json:"uuid"`
 	ProductUUID    string           `gorm:"type:uuid;not null;index" json:"productUuid"`
 	WarehouseUUID  string           `gorm:"type:uuid;not null;index" json:"warehouseUuid"`
@@ -138,14 +158,130 @@ func (sa *StockAdjustment) BeforeCreate(tx *gorm.DB) (err error) {
 	return
 }
 
+// QuantitySign selects movements that added or removed stock
+type QuantitySign string
+
+const (
+	QuantitySignPositive QuantitySign = "positive"
+	QuantitySignNegative QuantitySign = "negative"
+)
+
+// StockMovementFilter narrows down stock movement queries; zero values are ignored
+type StockMovementFilter struct {
+	WarehouseUUID    string
+	ProductUUID      string
+	MovementTypes    []StockMovementType // Any of these types
+	StartDate        time.Time           // Inclusive
+	EndDate          time.Time           // Exclusive
+	ReferenceNumber  string
+	AdjustmentReason AdjustmentReason
+	CreatedBy        string
+	APIKeyUUID       string
+	QuantitySign     QuantitySign
+}
+
+func (f *StockMovementFilter) Validate() error {
+	for _, movementType := range f.MovementTypes {
+		switch movementType {
+		case MovementTypeStockIn, MovementTypeStockOut, MovementTypeTransfer, MovementTypeAdjustment, MovementTypeReservation, MovementTypeRelease:
+		default:
+			return ErrMovementTypeInvalid
+		}
+	}
+	switch f.QuantitySign {
+	case "", QuantitySignPositive, QuantitySignNegative:
+	default:
+		return ErrQuantitySignInvalid
+	}
+	if !f.StartDate.IsZero() && !f.EndDate.IsZero() && !f.StartDate.Before(f.EndDate) {
+		return ErrDateRangeInvalid
+	}
+	return nil
+}
+
+type StockMovementSortField string
+
+const (
+	MovementSortMovementDate StockMovementSortField = "movementDate"
+	MovementSortCreatedAt    StockMovementSortField = "createdAt"
+	MovementSortQuantity     StockMovementSortField = "quantity"
+)
+
+type StockMovementSort struct {
+	Field StockMovementSortField
+	Desc  bool
+}
+
+// ParseStockMovementSort reads a comma separated list of fields, each optionally prefixed
+// with "-" for descending order, e.g. "-quantity,movementDate"
+func ParseStockMovementSort(value string) ([]StockMovementSort, error) {
+	var sorts []StockMovementSort
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		sort := StockMovementSort{Field: StockMovementSortField(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+		switch sort.Field {
+		case MovementSortMovementDate, MovementSortCreatedAt, MovementSortQuantity:
+			sorts = append(sorts, sort)
+		default:
+			return nil, ErrMovementSortInvalid
+		}
+	}
+	return sorts, nil
+}
+
+// StockMovementCursor marks a position in the ledger, which is ordered newest first by
+// (movement_date, created_at, uuid). Backward cursors page towards newer movements.
+type StockMovementCursor struct {
+	MovementDate time.Time `json:"d"`
+	CreatedAt    time.Time `json:"c"`
+	UUID         string    `json:"u"`
+	Backward     bool      `json:"b,omitempty"`
+}
+
+// Encode returns the opaque form handed to clients
+func (c StockMovementCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeStockMovementCursor(value string) (*StockMovementCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrCursorInvalid
+	}
+	var cursor StockMovementCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.UUID == "" || cursor.MovementDate.IsZero() {
+		return nil, ErrCursorInvalid
+	}
+	return &cursor, nil
+}
+
+// StockMovementPage is one cursor-paginated slice of the ledger. Cursors are empty when
+// there is nothing further in that direction.
+type StockMovementPage struct {
+	Items      []StockMovement
+	NextCursor string // Older movements
+	PrevCursor string // Newer movements
+	Total      *int64 // Planner estimate of matching movements, only when requested
+}
+
+// StockRecordFilter narrows down stock in/out/adjustment queries; zero values are ignored
+type StockRecordFilter struct {
+	WarehouseUUID string
+	Reason        AdjustmentReason // Adjustments only
+	StartDate     time.Time        // Inclusive
+	EndDate       time.Time        // Exclusive
+}
+
 // StockMovementRepository interface
 type StockMovementRepository interface {
 	Create(ctx context.Context, movement *StockMovement) error
-	GetAll(ctx context.Context) ([]StockMovement, error)
-	GetByWarehouse(ctx context.Context, warehouseUUID string, limit int) ([]StockMovement, error)
-	GetByProduct(ctx context.Context, productUUID string, limit int) ([]StockMovement, error)
-	GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]StockMovement, error)
-	GetByType(ctx context.Context, movementType StockMovementType, limit int) ([]StockMovement, error)
+	GetAll(ctx context.Context, limit int) ([]StockMovement, error)
+	GetFiltered(ctx context.Context, filter StockMovementFilter, sorts []StockMovementSort, page, limit int) ([]StockMovement, error)
+	CountFiltered(ctx context.Context, filter StockMovementFilter) (int64, error)
 }
 
 // StockInRepository interface
@@ -9,6 +9,7 @@ import (
 )
 
 type Supplier struct {
+	Owned
 	UUID        string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	Name        string    `gorm:"size:100;not null" json:"name"`
 	Email       string    `gorm:"size:100;index" json:"email"`
@@ -34,7 +35,7 @@ type SupplierRepository interface {
 
 type SupplierUsecase interface {
 	Create(ctx context.Context, supplier *Supplier) error
-	GetAll(ctx context.Context) ([]Supplier, error)
+	GetAll(ctx context.Context, limit int) ([]Supplier, error)
 	GetByID(ctx context.Context, uuid string) (*Supplier, error)
 	Update(ctx context.Context, uuid string, supplier *Supplier) error
 	Delete(ctx context.Context, uuid string) error
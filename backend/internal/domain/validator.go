@@ -1,32 +1,107 @@
 package domain
 
 import (
-	"errors"
+	"net/url"
 	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 var (
-	ErrProductTitleRequired   = errors.New("product title is required")
-	ErrProductTitleTooLong    = errors.New("product title must be less than 100 characters")
-	ErrProductPriceInvalid    = errors.New("product price must be greater than 0")
-	ErrProductStockInvalid    = errors.New("product stock cannot be negative")
-	ErrProductLowStockThresholdInvalid = errors.New("product low stock threshold cannot be negative")
-	ErrProductSKURequired     = errors.New("product SKU is required")
-	ErrProductSKUTooLong     = errors.New("product SKU must be less than 50 characters")
-	ErrProductBarcodeTooLong  = errors.New("product barcode must be less than 100 characters")
-
-	ErrCategoryNameRequired   = errors.New("category name is required")
-	ErrCategoryNameTooLong    = errors.New("category name must be less than 100 characters")
-
-	ErrSupplierNameRequired   = errors.New("supplier name is required")
-	ErrSupplierNameTooLong    = errors.New("supplier name must be less than 100 characters")
-	ErrSupplierEmailInvalid   = errors.New("supplier email is invalid")
-
-	ErrInsufficientStock      = errors.New("insufficient stock available")
-	ErrQuantityInvalid        = errors.New("quantity must be greater than 0")
-
-	ErrWarehouseNameRequired  = errors.New("warehouse name is required")
-	ErrWarehouseCapacityExceeded = errors.New("warehouse capacity exceeded")
+	ErrProductTitleRequired            = NewValidationError("title", "product title is required")
+	ErrProductTitleTooLong             = NewValidationError("title", "product title must be less than 100 characters")
+	ErrProductPriceInvalid             = NewValidationError("price", "product price must be greater than 0")
+	ErrProductStockInvalid             = NewValidationError("stock", "product stock cannot be negative")
+	ErrProductLowStockThresholdInvalid = NewValidationError("lowStockThreshold", "product low stock threshold cannot be negative")
+	ErrProductSKURequired              = NewValidationError("sku", "product SKU is required")
+	ErrProductSKUTooLong               = NewValidationError("sku", "product SKU must be less than 50 characters")
+	ErrProductBarcodeTooLong           = NewValidationError("barcode", "product barcode must be less than 100 characters")
+	ErrProductSortInvalid              = NewValidationError("sort", "product sort must be a comma separated list of title, sku, price, stock, createdAt or updatedAt")
+	ErrProductStockStatusInvalid       = NewValidationError("stockStatus", "product stock status must be in, low or out")
+	ErrProductPriceRangeInvalid        = NewValidationError("minPrice", "product price range is invalid")
+
+	ErrCategoryNameRequired = NewValidationError("name", "category name is required")
+	ErrCategoryNameTooLong  = NewValidationError("name", "category name must be less than 100 characters")
+
+	ErrSupplierNameRequired = NewValidationError("name", "supplier name is required")
+	ErrSupplierNameTooLong  = NewValidationError("name", "supplier name must be less than 100 characters")
+	ErrSupplierEmailInvalid = NewValidationError("email", "supplier email is invalid")
+
+	ErrInsufficientStock = &Error{Code: ErrorCodeInsufficientStock, Message: "insufficient stock available"}
+	ErrQuantityInvalid   = NewValidationError("quantity", "quantity must be greater than 0")
+
+	ErrWarehouseNameRequired     = NewValidationError("name", "warehouse name is required")
+	ErrWarehouseCapacityExceeded = &Error{Code: ErrorCodeCapacityExceeded, Message: "warehouse capacity exceeded"}
+	ErrWarehouseNameTooLong      = NewValidationError("name", "warehouse name must be less than 100 characters")
+	ErrWarehouseCapacityInvalid  = NewValidationError("capacity", "warehouse capacity cannot be negative")
+
+	ErrStockDocumentTypeInvalid       = NewValidationError("documentType", "document type must be RECEIPT or SHIPMENT")
+	ErrStockDocumentReferenceRequired = NewValidationError("referenceNumber", "document reference number is required")
+	ErrStockDocumentReferenceTooLong  = NewValidationError("referenceNumber", "document reference number must be less than 100 characters")
+	ErrStockDocumentWarehouseRequired = NewValidationError("warehouseUuid", "document warehouse is required")
+	ErrStockDocumentLinesRequired     = NewValidationError("lines", "document must have at least one line")
+	ErrStockDocumentProductRequired   = NewValidationError("productUuid", "document line product is required")
+	ErrStockDocumentDuplicate         = NewConflictError("a document with this reference number already exists", nil)
+	ErrProductNotFound                = NewNotFoundError("product", nil)
+	ErrWarehouseNotFound              = NewNotFoundError("warehouse", nil)
+
+	ErrCursorInvalid = NewValidationError("cursor", "cursor is invalid")
+
+	ErrAPIKeyNameRequired     = NewValidationError("name", "API key name is required")
+	ErrAPIKeyNameTooLong      = NewValidationError("name", "API key name must be less than 100 characters")
+	ErrAPIKeyScopesRequired   = NewValidationError("scopes", "API key must have at least one scope")
+	ErrAPIKeyScopeInvalid     = NewValidationError("scopes", "API key scopes must be catalog:read, catalog:write, stock:read, stock:in, stock:out, stock:adjust, stock:transfer or import")
+	ErrAPIKeyWarehouseInvalid = NewValidationError("warehouseUuids", "API key warehouses must be warehouse UUIDs")
+	ErrAPIKeyExpiryInvalid    = NewValidationError("expiresAt", "API key expiry must be in the future")
+	ErrAPIKeyNotFound         = NewNotFoundError("API key", nil)
+	ErrAPIKeyRevoked          = NewConflictError("API key is revoked", nil)
+	ErrAPIKeyInvalid          = &Error{Code: ErrorCodeUnauthenticated, Message: "API key is invalid, expired or revoked"}
+	ErrAPIKeyRequired         = &Error{Code: ErrorCodeUnauthenticated, Message: "API key is required"}
+	ErrAdminTokenInvalid      = &Error{Code: ErrorCodeUnauthenticated, Message: "admin token is missing or invalid"}
+
+	ErrWebhookURLInvalid         = NewValidationError("url", "webhook URL must be an absolute http or https URL")
+	ErrWebhookURLPrivate         = NewValidationError("url", "webhook URL must not point at a loopback, private or link-local address")
+	ErrWebhookURLUnresolved      = NewValidationError("url", "webhook URL host does not resolve")
+	ErrWebhookDescriptionTooLong = NewValidationError("description", "webhook description must be less than 255 characters")
+	ErrWebhookSecretInvalid      = NewValidationError("secret", "webhook secret must be between 16 and 255 characters")
+	ErrWebhookEventsRequired     = NewValidationError("eventTypes", "webhook must subscribe to at least one event type")
+	ErrWebhookEventInvalid       = NewValidationError("eventTypes", "webhook event types must be movement.created, transfer.completed, product.created, product.updated, product.deleted, product.low_stock, warehouse.created, warehouse.updated, warehouse.deleted or alert.changed")
+	ErrWebhookNotFound           = NewNotFoundError("webhook", nil)
+	ErrWebhookDisabled           = NewConflictError("webhook is disabled; enable it before redelivering", nil)
+	ErrAdminDisabled             = NewForbiddenError("the admin API is disabled; set auth.admin_token to enable it")
+
+	ErrOrganizationNameRequired = NewValidationError("name", "organization name is required")
+	ErrOrganizationNameTooLong  = NewValidationError("name", "organization name must be less than 100 characters")
+	ErrOrganizationNotFound     = NewNotFoundError("organization", nil)
+	ErrOrganizationInvalid      = NewValidationError("organizationUuid", "organization not found")
+
+	ErrNotificationEmailInvalid       = NewValidationError("email", "notification email must be an email address of less than 255 characters")
+	ErrNotificationFrequencyInvalid   = NewValidationError("frequency", "notification frequency must be immediate, hourly, daily or off")
+	ErrNotificationKindInvalid        = NewValidationError("kinds", "notification kinds must be low_stock or capacity_warning")
+	ErrNotificationStatusInvalid      = NewValidationError("status", "notification status must be pending, sent or failed")
+	ErrNotificationPreferenceNotFound = NewNotFoundError("notification preference", nil)
+
+	ErrAlertRuleNameRequired     = NewValidationError("name", "alert rule name is required")
+	ErrAlertRuleNameTooLong      = NewValidationError("name", "alert rule name must be less than 100 characters")
+	ErrAlertRuleTypeInvalid      = NewValidationError("type", "alert rule type must be stock_below, warehouse_utilization, large_adjustment, no_movement or stock_drift")
+	ErrAlertRuleThresholdInvalid = NewValidationError("threshold", "alert rule threshold must be at least 1, or 0 for large_adjustment and stock_drift, and at most 100 for warehouse_utilization or 3650 for no_movement")
+	ErrAlertRuleProductInvalid   = NewValidationError("productUuid", "alert rule product must be a product UUID, and warehouse_utilization rules cannot have one")
+	ErrAlertRuleWarehouseInvalid = NewValidationError("warehouseUuid", "alert rule warehouse must be a warehouse UUID, and no_movement and stock_drift rules cannot have one")
+	ErrAlertRuleNotFound         = NewNotFoundError("alert rule", nil)
+	ErrAlertNotFound             = NewNotFoundError("alert", nil)
+	ErrAlertStatusInvalid        = NewValidationError("status", "alert status must be open, acknowledged, snoozed or resolved")
+	ErrAlertResolved             = NewConflictError("alert is already resolved", nil)
+	ErrAlertAlreadyAcknowledged  = NewConflictError("alert is already acknowledged", nil)
+	ErrAlertSnoozeInvalid        = NewValidationError("until", "alert snooze must end in the future, within 30 days")
+	ErrAlertActionNoteTooLong    = NewValidationError("note", "alert note must be less than 255 characters")
+	ErrAlertActionActorTooLong   = NewValidationError("by", "alert actor must be less than 100 characters")
+
+	ErrMovementTypeInvalid = NewValidationError("type", "movement type must be STOCK_IN, STOCK_OUT, TRANSFER, ADJUSTMENT, RESERVATION or RELEASE")
+	ErrMovementSortInvalid = NewValidationError("sort", "movement sort must be a comma separated list of movementDate, createdAt or quantity")
+	ErrMovementSortCursor  = NewValidationError("sort", "custom sorting cannot be combined with cursor pagination")
+	ErrQuantitySignInvalid = NewValidationError("sign", "quantity sign must be positive or negative")
+	ErrDateRangeInvalid    = NewValidationError("startDate", "startDate must be before endDate")
 )
 
 func (p *Product) Validate() error {
@@ -80,3 +155,127 @@ func (s *Supplier) Validate() error {
 	return nil
 }
 
+func (w *Warehouse) Validate() error {
+	if strings.TrimSpace(w.Name) == "" {
+		return ErrWarehouseNameRequired
+	}
+	if len(w.Name) > 100 {
+		return ErrWarehouseNameTooLong
+	}
+	if w.Capacity < 0 {
+		return ErrWarehouseCapacityInvalid
+	}
+	return nil
+}
+
+func (o *Organization) Validate() error {
+	if strings.TrimSpace(o.Name) == "" {
+		return ErrOrganizationNameRequired
+	}
+	if len(o.Name) > 100 {
+		return ErrOrganizationNameTooLong
+	}
+	return nil
+}
+
+func (k *APIKey) Validate() error {
+	if strings.TrimSpace(k.Name) == "" {
+		return ErrAPIKeyNameRequired
+	}
+	if len(k.Name) > 100 {
+		return ErrAPIKeyNameTooLong
+	}
+	if len(k.Scopes) == 0 {
+		return ErrAPIKeyScopesRequired
+	}
+	for _, scope := range k.Scopes {
+		if !scope.IsValid() {
+			return ErrAPIKeyScopeInvalid
+		}
+	}
+	for _, warehouseUUID := range k.WarehouseUUIDs {
+		if _, err := uuid.Parse(warehouseUUID); err != nil {
+			return ErrAPIKeyWarehouseInvalid
+		}
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		return ErrAPIKeyExpiryInvalid
+	}
+	return nil
+}
+
+func (w *Webhook) Validate() error {
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(w.URL) > 2048 {
+		return ErrWebhookURLInvalid
+	}
+	if len(w.Description) > 255 {
+		return ErrWebhookDescriptionTooLong
+	}
+	if len(w.Secret) < 16 || len(w.Secret) > 255 {
+		return ErrWebhookSecretInvalid
+	}
+	if len(w.EventTypes) == 0 {
+		return ErrWebhookEventsRequired
+	}
+	for _, eventType := range w.EventTypes {
+		if !eventType.IsValid() {
+			return ErrWebhookEventInvalid
+		}
+	}
+	return nil
+}
+
+func (p *NotificationPreference) Validate() error {
+	if !strings.Contains(p.Email, "@") || len(p.Email) > 255 {
+		return ErrNotificationEmailInvalid
+	}
+	if !p.Frequency.IsValid() {
+		return ErrNotificationFrequencyInvalid
+	}
+	for _, kind := range p.Kinds {
+		if !kind.IsValid() {
+			return ErrNotificationKindInvalid
+		}
+	}
+	return nil
+}
+
+func (r *AlertRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return ErrAlertRuleNameRequired
+	}
+	if len(r.Name) > 100 {
+		return ErrAlertRuleNameTooLong
+	}
+	if !r.Type.IsValid() {
+		return ErrAlertRuleTypeInvalid
+	}
+	switch {
+	case r.Threshold < 0,
+		r.Threshold == 0 && r.Type != AlertLargeAdjustment && r.Type != AlertStockDrift,
+		r.Type == AlertWarehouseUtilization && r.Threshold > 100,
+		r.Type == AlertNoMovement && r.Threshold > 3650:
+		return ErrAlertRuleThresholdInvalid
+	}
+	if r.ProductUUID != nil {
+		if _, err := uuid.Parse(*r.ProductUUID); err != nil || r.Type == AlertWarehouseUtilization {
+			return ErrAlertRuleProductInvalid
+		}
+	}
+	if r.WarehouseUUID != nil {
+		if _, err := uuid.Parse(*r.WarehouseUUID); err != nil || r.Type == AlertNoMovement || r.Type == AlertStockDrift {
+			return ErrAlertRuleWarehouseInvalid
+		}
+	}
+	return nil
+}
+
+func (a *AlertAction) Validate() error {
+	if len(a.By) > 100 {
+		return ErrAlertActionActorTooLong
+	}
+	if len(a.Note) > 255 {
+		return ErrAlertActionNoteTooLong
+	}
+	return nil
+}
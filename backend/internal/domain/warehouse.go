@@ -9,6 +9,7 @@ import (
 )
 
 type Warehouse struct {
+	Owned
 	UUID         string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	Name         string    `gorm:"size:100;not null" json:"name"`
 	Address      string    `gorm:"type:text" json:"address"`
@@ -31,6 +32,7 @@ func (w *Warehouse) BeforeCreate(tx *gorm.DB) (err error) {
 }
 
 type WarehouseStock struct {
+	Owned
 	UUID          string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	ProductUUID   string    `gorm:"type:uuid;not null;index" json:"productUuid"`
 	WarehouseUUID string    `gorm:"type:uuid;not null;index" json:"warehouseUuid"`
@@ -47,6 +49,7 @@ func (ws *WarehouseStock) BeforeCreate(tx *gorm.DB) (err error) {
 }
 
 type StockTransfer struct {
+	Owned
 	UUID              string    `gorm:"type:uuid;primaryKey" json:"uuid"`
 	ProductUUID       string    `gorm:"type:uuid;not null;index" json:"productUuid"`
 	FromWarehouseUUID string    `gorm:"type:uuid;not null;index" json:"fromWarehouseUuid"`
@@ -67,6 +70,7 @@ type WarehouseRepository interface {
 	Create(ctx context.Context, warehouse *Warehouse) error
 	GetAll(ctx context.Context) ([]Warehouse, error)
 	GetByID(ctx context.Context, uuid string) (*Warehouse, error)
+	GetStocking(ctx context.Context, productUUID string) ([]Warehouse, error)
 	Update(ctx context.Context, uuid string, warehouse *Warehouse) error
 	Delete(ctx context.Context, uuid string) error
 }
@@ -78,7 +82,7 @@ type WarehouseStockRepository interface {
 	GetByProduct(ctx context.Context, productUUID string) ([]WarehouseStock, error)
 	GetTotalStockByProduct(ctx context.Context, productUUID string) (int, error)
 	GetTotalStockByWarehouse(ctx context.Context, warehouseUUID string) (int, error)
-	Transfer(ctx context.Context, transfer *StockTransfer) error
+	Transfer(ctx context.Context, transfer *StockTransfer, from *StockMovement, to *StockMovement) error
 }
 
 // WarehouseWithMetrics includes warehouse data with utilization metrics
@@ -90,7 +94,7 @@ type WarehouseWithMetrics struct {
 
 type WarehouseUsecase interface {
 	Create(ctx context.Context, warehouse *Warehouse) error
-	GetAll(ctx context.Context) ([]Warehouse, error)
+	GetAll(ctx context.Context, limit int) ([]Warehouse, error)
 	GetAllWithMetrics(ctx context.Context) ([]WarehouseWithMetrics, error)
 	GetByID(ctx context.Context, uuid string) (*Warehouse, error)
 	Update(ctx context.Context, uuid string, warehouse *Warehouse) error
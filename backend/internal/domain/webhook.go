@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook subscribes an external system, such as an ERP, to the events of one organisation. Every
+// delivery is a JSON POST signed with HMAC-SHA256 using Secret, which is only shown when the
+// webhook is created.
+type Webhook struct {
+	UUID                string      `gorm:"type:uuid;primaryKey" json:"uuid"`
+	OrganizationUUID    string      `gorm:"type:uuid;not null" json:"organizationUuid"` // Defaults to the default organisation
+	URL                 string      `gorm:"size:2048;not null" json:"url"`
+	Description         string      `gorm:"size:255" json:"description"`
+	Secret              string      `gorm:"size:255;not null" json:"secret,omitempty"`
+	EventTypes          []EventType `gorm:"serializer:json;type:jsonb;not null" json:"eventTypes"`
+	Enabled             bool        `gorm:"not null;default:true" json:"enabled"`
+	ConsecutiveFailures int         `gorm:"not null;default:0" json:"consecutiveFailures"` // Failed attempts since the last success
+	DisabledAt          *time.Time  `json:"disabledAt"`                                    // When the webhook was disabled for failing
+	CreatedAt           time.Time   `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt           time.Time   `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) (err error) {
+	w.UUID = uuid.New().String()
+	return
+}
+
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return slices.Contains(w.EventTypes, eventType)
+}
+
+// WebhookDeliveryStatus is where a delivery stands
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its first attempt or a retry
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // The endpoint answered 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // Every attempt failed; it can still be redelivered
+)
+
+// WebhookDelivery is one event on its way to one webhook, and the log of how that went
+type WebhookDelivery struct {
+	UUID           string                `gorm:"type:uuid;primaryKey" json:"uuid"`
+	WebhookUUID    string                `gorm:"type:uuid;not null;index" json:"webhookUuid"`
+	EventID        string                `gorm:"type:uuid;not null;index" json:"eventId"`
+	EventType      EventType             `gorm:"size:50;not null" json:"eventType"`
+	Payload        json.RawMessage       `gorm:"serializer:json;type:jsonb;not null" json:"payload"` // The event, as sent
+	Status         WebhookDeliveryStatus `gorm:"size:20;not null" json:"status"`
+	Attempts       int                   `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus int                   `json:"responseStatus,omitempty"` // HTTP status of the last attempt; 0 when there was no response
+	ResponseBody   string                `gorm:"type:text" json:"responseBody,omitempty"`
+	Error          string                `gorm:"type:text" json:"error,omitempty"` // Why the last attempt failed
+	NextAttemptAt  *time.Time            `gorm:"index" json:"nextAttemptAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt"`
+	RedeliveryOf   *string               `gorm:"type:uuid" json:"redeliveryOf,omitempty"` // The delivery this one repeats
+	CreatedAt      time.Time             `gorm:"column:created_at;autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time             `gorm:"column:updated_at;autoUpdateTime" json:"updatedAt"`
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) (err error) {
+	d.UUID = uuid.New().String()
+	return
+}
+
+// WebhookAttempt is the outcome of sending a delivery once
+type WebhookAttempt struct {
+	ResponseStatus int
+	ResponseBody   string
+	Err            error
+}
+
+func (a WebhookAttempt) Succeeded() bool {
+	return a.Err == nil && a.ResponseStatus >= 200 && a.ResponseStatus < 300
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetAll(ctx context.Context) ([]Webhook, error)
+	GetByID(ctx context.Context, uuid string) (*Webhook, error)
+	GetEnabled(ctx context.Context) ([]Webhook, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, uuid string) error
+	CreateDeliveries(ctx context.Context, deliveries []WebhookDelivery) error
+	GetDeliveries(ctx context.Context, webhookUUID string, page, limit int) ([]WebhookDelivery, int64, error)
+	GetDelivery(ctx context.Context, webhookUUID string, uuid string) (*WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]WebhookDelivery, error)
+	SaveDelivery(ctx context.Context, delivery *WebhookDelivery) error
+	RecordSuccess(ctx context.Context, uuid string) error
+	RecordFailure(ctx context.Context, uuid string, disableAfter int, now time.Time) (bool, error)
+}
+
+type WebhookUsecase interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	GetAll(ctx context.Context) ([]Webhook, error)
+	GetByID(ctx context.Context, uuid string) (*Webhook, error)
+	Update(ctx context.Context, uuid string, webhook *Webhook) error
+	Delete(ctx context.Context, uuid string) error
+	GetDeliveries(ctx context.Context, webhookUUID string, page, limit int) ([]WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, webhookUUID string, deliveryUUID string) (*WebhookDelivery, error)
+	Deliver(ctx context.Context, event Event) error
+}
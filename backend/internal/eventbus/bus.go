@@ -0,0 +1,110 @@
+// Package eventbus wakes in-process subscribers, such as Watch streams, when stock or the catalog
+// changes. It is the outbox's in-process sink.
+package eventbus
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+// Bus tells subscribers that events of their organisation they care about happened. Subscribers
+// re-read the state they watch when woken, so a wake-up stands for every event since the previous one: one that has not
+// been taken yet is not repeated, and no change is ever missed.
+type Bus struct {
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func New() *Bus {
+	return &Bus{subscriptions: make(map[*Subscription]struct{})}
+}
+
+// Subscription wakes its subscriber through C
+type Subscription struct {
+	organization string
+	types        []domain.EventType
+	c            chan struct{}
+}
+
+// C receives a value when subscribed events happened since the last one was received
+func (s *Subscription) C() <-chan struct{} {
+	return s.c
+}
+
+// Subscribe wakes the subscription on any of the given event types of the organisation ctx is
+// limited to, until Unsubscribe
+func (b *Bus) Subscribe(ctx context.Context, types ...domain.EventType) *Subscription {
+	organization, _ := domain.OrganizationFromContext(ctx)
+	s := &Subscription{organization: organization, types: types, c: make(chan struct{}, 1)}
+	b.mu.Lock()
+	b.subscriptions[s] = struct{}{}
+	b.mu.Unlock()
+	return s
+}
+
+func (b *Bus) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	delete(b.subscriptions, s)
+	b.mu.Unlock()
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Deliver wakes the subscribers of the event's organisation and type; it never blocks or fails
+func (b *Bus) Deliver(_ context.Context, event domain.Event) error {
+	b.Notify(event.OrganizationUUID, event.Type)
+	return nil
+}
+
+// Notify wakes the subscribers of the organisation to any of the given event types
+func (b *Bus) Notify(organization string, types ...domain.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscriptions {
+		if s.organization != organization || !slices.ContainsFunc(types, func(t domain.EventType) bool { return slices.Contains(s.types, t) }) {
+			continue
+		}
+		select {
+		case s.c <- struct{}{}:
+		default:
+			// Already woken; the subscriber has yet to re-read, and will see this change too
+		}
+	}
+}
+
+// Poll wakes subscribers of eventType in the organisations whose records changedSince finds
+// changed since its last look, until ctx is cancelled. Events are dispatched by one replica at
+// a time, so this is how Watch streams on the other replicas notice changes.
+func Poll[T interface{ Organization() string }](ctx context.Context, b *Bus, interval time.Duration, changedSince func(context.Context, time.Time) ([]T, error), eventType domain.EventType) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastCheck := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		checked := time.Now()
+		changes, err := changedSince(ctx, lastCheck)
+		if err != nil {
+			continue
+		}
+		notified := map[string]bool{}
+		for _, change := range changes {
+			if organization := change.Organization(); !notified[organization] {
+				b.Notify(organization, eventType)
+				notified[organization] = true
+			}
+		}
+		lastCheck = checked
+	}
+}
@@ -0,0 +1,63 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+const otherOrganization = "00000000-0000-0000-0000-000000000002"
+
+func TestBusWakesSubscribersOfTheEventType(t *testing.T) {
+	bus := New()
+	ctx := domain.WithOrganization(context.Background(), domain.DefaultOrganizationUUID)
+	products := bus.Subscribe(ctx, domain.EventProductCreated, domain.EventProductUpdated)
+	movements := bus.Subscribe(ctx, domain.EventMovementCreated)
+	otherProducts := bus.Subscribe(domain.WithOrganization(context.Background(), otherOrganization), domain.EventProductUpdated)
+	defer bus.Unsubscribe(products)
+	defer bus.Unsubscribe(movements)
+	defer bus.Unsubscribe(otherProducts)
+
+	// Several events before the subscriber looks coalesce into one wake-up
+	product := &domain.Product{Owned: domain.Owned{OrganizationUUID: domain.DefaultOrganizationUUID}}
+	for range 3 {
+		if err := bus.Deliver(context.Background(), domain.NewEvent(domain.EventProductUpdated, product)); err != nil {
+			t.Fatalf("Deliver: %v", err)
+		}
+	}
+
+	select {
+	case <-products.C():
+	default:
+		t.Fatal("product subscriber was not woken")
+	}
+	select {
+	case <-products.C():
+		t.Fatal("product subscriber was woken twice")
+	default:
+	}
+	select {
+	case <-movements.C():
+		t.Fatal("movement subscriber was woken by a product event")
+	default:
+	}
+	select {
+	case <-otherProducts.C():
+		t.Fatal("subscriber was woken by another organisation's event")
+	default:
+	}
+}
+
+func TestBusForgetsUnsubscribed(t *testing.T) {
+	bus := New()
+	subscription := bus.Subscribe(domain.WithOrganization(context.Background(), domain.DefaultOrganizationUUID), domain.EventMovementCreated)
+	bus.Unsubscribe(subscription)
+
+	bus.Notify(domain.DefaultOrganizationUUID, domain.EventMovementCreated)
+	select {
+	case <-subscription.C():
+		t.Fatal("unsubscribed subscriber was woken")
+	default:
+	}
+}
@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger sends GORM's logs to slog: failed statements as errors, statements slower than
+// SlowThreshold as warnings and every other statement at debug level
+type GormLogger struct {
+	SlowThreshold time.Duration
+}
+
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{SlowThreshold: slowThreshold}
+}
+
+// LogMode is a no-op; the level is that of the slog handler
+func (l *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	slog.InfoContext(ctx, "gorm: "+msg, "args", args)
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	slog.WarnContext(ctx, "gorm: "+msg, "args", args)
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	slog.ErrorContext(ctx, "gorm: "+msg, "args", args)
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !expectedError(err):
+		sql, rows := fc()
+		slog.ErrorContext(ctx, "Query failed", "error", err, "duration", elapsed, "rows", rows, "sql", sql)
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold:
+		sql, rows := fc()
+		slog.WarnContext(ctx, "Slow query", "duration", elapsed, "threshold", l.SlowThreshold, "rows", rows, "sql", sql)
+	case slog.Default().Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		slog.DebugContext(ctx, "Query", "duration", elapsed, "rows", rows, "sql", sql)
+	}
+}
+
+// expectedError reports errors the repositories turn into not found or conflict responses
+func expectedError(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound) ||
+		errors.Is(err, gorm.ErrDuplicatedKey) ||
+		errors.Is(err, gorm.ErrForeignKeyViolated)
+}
@@ -0,0 +1,74 @@
+// Package logging configures log/slog and carries the request ID through contexts
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose log records carry the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID stored by WithRequestID, or ""
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ParseLevel accepts debug, info, warn or error
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q: use debug, info, warn or error", level)
+	}
+	return l, nil
+}
+
+// New builds a logger writing JSON or text records at or above level. Records logged with a
+// context (slog.InfoContext and friends) get request_id, trace_id and span_id attributes when
+// the context has them.
+func New(w io.Writer, level slog.Level, format string) (*slog.Logger, error) {
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text", "":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: use json or text", format)
+	}
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+// contextHandler adds the request ID and trace from the record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String("trace_id", spanContext.TraceID().String()), slog.String("span_id", spanContext.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// RequestIDHeader is read from incoming requests and echoed on every response
+const RequestIDHeader = "X-Request-ID"
+
+// NewRequestID keeps a caller's request ID when it is safe to log, otherwise generates one
+func NewRequestID(incoming string) string {
+	if validRequestID(incoming) {
+		return incoming
+	}
+	return uuid.NewString()
+}
+
+// validRequestID accepts up to 128 printable ASCII characters
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > 128 {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// HTTPMiddleware assigns every request an ID, honouring X-Request-ID, puts it on the request
+// context and the response, and logs the request once it completes
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := NewRequestID(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, requestID)
+		ctx := WithRequestID(r.Context(), requestID)
+
+		start := time.Now()
+		recorder := response.NewStatusRecorder(w)
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		level := slog.LevelInfo
+		if recorder.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(ctx, level, "HTTP request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", recorder.Status,
+			"duration", time.Since(start),
+		)
+	})
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "metrics:query_start"
+
+// InstrumentDB times every GORM statement and exports the connection pool statistics
+func InstrumentDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	if err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, "stockhub")); err != nil {
+		return err
+	}
+
+	start := func(tx *gorm.DB) {
+		tx.InstanceSet(queryStartKey, time.Now())
+	}
+	observe := func(operation string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			started, ok := tx.InstanceGet(queryStartKey)
+			if !ok {
+				return
+			}
+			table := tx.Statement.Table
+			if table == "" {
+				table = "unknown"
+			}
+			DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(started.(time.Time)).Seconds())
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("metrics:before_create", start),
+		callbacks.Create().After("gorm:create").Register("metrics:after_create", observe("create")),
+		callbacks.Query().Before("gorm:query").Register("metrics:before_query", start),
+		callbacks.Query().After("gorm:query").Register("metrics:after_query", observe("query")),
+		callbacks.Update().Before("gorm:update").Register("metrics:before_update", start),
+		callbacks.Update().After("gorm:update").Register("metrics:after_update", observe("update")),
+		callbacks.Delete().Before("gorm:delete").Register("metrics:before_delete", start),
+		callbacks.Delete().After("gorm:delete").Register("metrics:after_delete", observe("delete")),
+		callbacks.Row().Before("gorm:row").Register("metrics:before_row", start),
+		callbacks.Row().After("gorm:row").Register("metrics:after_row", observe("row")),
+		callbacks.Raw().Before("gorm:raw").Register("metrics:before_raw", start),
+		callbacks.Raw().After("gorm:raw").Register("metrics:after_raw", observe("raw")),
+	)
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// HTTPMiddleware counts and times requests per route template, so /api/products/{uuid}
+// is one series however many products there are
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unmatched"
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		start := time.Now()
+		recorder := response.NewStatusRecorder(w)
+		next.ServeHTTP(recorder, r)
+
+		HTTPRequests.WithLabelValues(r.Method, route, strconv.Itoa(recorder.Status)).Inc()
+		HTTPRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,132 @@
+// Package metrics holds the Prometheus collectors served at /metrics
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "stockhub"
+
+// Service labels for the streaming metrics, matching the gRPC service names
+const (
+	ProductService   = "product.ProductService"
+	WarehouseService = "warehouse.WarehouseService"
+	MovementService  = "movement.MovementService"
+)
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	StreamsActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "streams_active",
+		Help:      "Open Watch streams by service and method, over gRPC and Connect.",
+	}, []string{"service", "method"})
+
+	StreamUpdates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "stream_updates_total",
+		Help:      "Watch stream updates sent by service.",
+	}, []string{"service", "result"})
+
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "GORM statement latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	MovementsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "stock",
+		Name:      "movements_created_total",
+		Help:      "Stock movements recorded by movement type.",
+	}, []string{"type"})
+
+	StockRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "stock",
+		Name:      "rejections_total",
+		Help:      "Stock operations refused for insufficient stock or exceeded capacity, by warehouse.",
+	}, []string{"reason", "warehouse"})
+
+	RateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests and streams refused by the rate limiter, by transport (http, grpc, connect) and budget.",
+	}, []string{"transport", "class"})
+
+	WebhookAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "attempts_total",
+		Help:      "Webhook delivery attempts by event type and result (succeeded, failed).",
+	}, []string{"event", "result"})
+
+	WebhooksDisabled = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "disabled_total",
+		Help:      "Webhooks disabled after failing too many times in a row.",
+	})
+
+	OutboxDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "deliveries_total",
+		Help:      "Outbox events handed to each sink by result (succeeded, failed).",
+	}, []string{"sink", "result"})
+
+	OutboxDeleted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "outbox",
+		Name:      "deleted_total",
+		Help:      "Delivered outbox events deleted once their retention passed.",
+	})
+
+	NotificationsQueued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "queued_total",
+		Help:      "Notifications for warehouse managers by kind and result (queued, duplicate).",
+	}, []string{"kind", "result"})
+
+	NotificationEmails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notification",
+		Name:      "emails_total",
+		Help:      "Notification emails and digests sent by result (sent, failed).",
+	}, []string{"result"})
+
+	AlertTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "alert",
+		Name:      "transitions_total",
+		Help:      "Alerts opened, acknowledged, snoozed, reopened and resolved by rule type and new status.",
+	}, []string{"type", "status"})
+)
+
+// Handler serves every registered collector in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
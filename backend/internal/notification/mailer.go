@@ -0,0 +1,235 @@
+// Package notification emails notifications to warehouse managers over SMTP
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+// TLS modes for the connection to the SMTP server
+const (
+	TLSStartTLS = "starttls" // Upgrade with STARTTLS when the server offers it
+	TLSImplicit = "tls"      // Connect over TLS, usually on port 465
+	TLSNone     = "none"     // Plain text, for a local SMTP catcher
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Config is the SMTP server emails are sent through and how they are addressed
+type Config struct {
+	Host         string
+	Port         int
+	Username     string // Authenticates with PLAIN when set; only over TLS or to localhost
+	Password     string
+	TLS          string // starttls, tls or none
+	From         string // Sender address, optionally with a name: StockHub <alerts@example.com>
+	DashboardURL string // Linked from every email when set
+	Timeout      time.Duration
+}
+
+// Mailer renders notification emails and sends them over SMTP
+type Mailer struct {
+	cfg  Config
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+func NewMailer(cfg Config) (*Mailer, error) {
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return nil, fmt.Errorf("notification sender %q: %w", cfg.From, err)
+	}
+	html, err := htmltemplate.ParseFS(templates, "templates/email.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	text, err := texttemplate.ParseFS(templates, "templates/email.txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	return &Mailer{cfg: cfg, html: html, text: text}, nil
+}
+
+// emailData is what the templates render
+type emailData struct {
+	Subject      string
+	Digest       bool
+	Frequency    domain.NotificationFrequency
+	LowStock     []domain.Notification
+	Capacity     []domain.Notification
+	DashboardURL string
+}
+
+// Send emails the notifications to their recipient, as one message with text and HTML parts
+func (m *Mailer) Send(ctx context.Context, email *domain.NotificationEmail) error {
+	message, err := m.Render(email)
+	if err != nil {
+		return err
+	}
+	return m.deliver(ctx, email.To, message)
+}
+
+// Render returns the email as a MIME message ready to send
+func (m *Mailer) Render(email *domain.NotificationEmail) ([]byte, error) {
+	data := emailData{
+		Subject:      Subject(email),
+		Digest:       email.Frequency == domain.NotificationHourly || email.Frequency == domain.NotificationDaily,
+		Frequency:    email.Frequency,
+		DashboardURL: m.cfg.DashboardURL,
+	}
+	for _, notification := range email.Notifications {
+		switch notification.Kind {
+		case domain.NotificationLowStock:
+			data.LowStock = append(data.LowStock, notification)
+		case domain.NotificationCapacityWarning:
+			data.Capacity = append(data.Capacity, notification)
+		}
+	}
+
+	var text, html bytes.Buffer
+	if err := m.text.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := m.html.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	var message bytes.Buffer
+	header := func(key, value string) {
+		fmt.Fprintf(&message, "%s: %s\r\n", key, sanitizeHeader(value))
+	}
+	header("From", m.cfg.From)
+	header("To", email.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", data.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", "<"+uuid.New().String()+"@stockhub>")
+	header("Auto-Submitted", "auto-generated")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	message.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{{"text/plain; charset=utf-8", text.Bytes()}, {"text/html; charset=utf-8", html.Bytes()}} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+// Subject is the subject line of the email: what a single notification says, or how many
+// alerts it holds
+func Subject(email *domain.NotificationEmail) string {
+	if len(email.Notifications) == 1 {
+		n := email.Notifications[0]
+		switch n.Kind {
+		case domain.NotificationLowStock:
+			return fmt.Sprintf("Low stock: %s (%s) is down to %d", n.SubjectName, n.SKU, n.Quantity)
+		case domain.NotificationCapacityWarning:
+			return fmt.Sprintf("Warehouse %s is at %d%% of capacity", n.SubjectName, n.Utilization())
+		}
+	}
+	if email.Frequency == domain.NotificationHourly || email.Frequency == domain.NotificationDaily {
+		return fmt.Sprintf("StockHub %s digest: %d alerts", email.Frequency, len(email.Notifications))
+	}
+	return fmt.Sprintf("StockHub: %d stock alerts", len(email.Notifications))
+}
+
+// deliver sends one message to one recipient, giving the whole exchange the configured timeout
+func (m *Mailer) deliver(ctx context.Context, to string, message []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}
+	if m.cfg.TLS == TLSImplicit {
+		conn = tls.Client(conn, tlsConfig)
+	}
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if m.cfg.TLS == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	from, _ := mail.ParseAddress(m.cfg.From)
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// sanitizeHeader keeps a header value on one line
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+// smtpCatcher accepts one message on a local port, like the SMTP catchers used in development
+type smtpCatcher struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     chan string
+}
+
+func newSMTPCatcher(t *testing.T) *smtpCatcher {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &smtpCatcher{listener: listener, data: make(chan string, 1)}
+	go c.serve()
+	t.Cleanup(func() { listener.Close() })
+	return c
+}
+
+func (c *smtpCatcher) port() int {
+	return c.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (c *smtpCatcher) serve() {
+	conn, err := c.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 catcher ready")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250 catcher")
+		case "MAIL":
+			c.from = arg
+			_ = text.PrintfLine("250 OK")
+		case "RCPT":
+			c.to = append(c.to, arg)
+			_ = text.PrintfLine("250 OK")
+		case "DATA":
+			_ = text.PrintfLine("354 Go ahead")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			c.data <- string(data)
+			_ = text.PrintfLine("250 Queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 Bye")
+			return
+		default:
+			_ = text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func lowStock(name, sku string, quantity, threshold int) domain.Notification {
+	return domain.Notification{
+		Kind:        domain.NotificationLowStock,
+		SubjectUUID: "9a3d5e1c-7b2f-4c8a-a1d6-3e5f7b9c1d20",
+		SubjectName: name,
+		SKU:         sku,
+		Quantity:    quantity,
+		Threshold:   threshold,
+	}
+}
+
+// readMessage parses a multipart/alternative message, returning the decoded body of each part
+// by content type
+func readMessage(t *testing.T, raw string) (*mail.Message, map[string]string) {
+	t.Helper()
+	message, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q, %v", mediaType, err)
+	}
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	bodies := map[string]string{}
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[contentType] = string(body)
+	}
+	return message, bodies
+}
+
+func TestMailerSendsToSMTPCatcher(t *testing.T) {
+	catcher := newSMTPCatcher(t)
+	mailer, err := NewMailer(Config{
+		Host:         "127.0.0.1",
+		Port:         catcher.port(),
+		TLS:          TLSNone,
+		From:         "StockHub <alerts@stockhub.test>",
+		DashboardURL: "https://stockhub.test",
+		Timeout:      5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	email := &domain.NotificationEmail{
+		To:            "manager@stockhub.test",
+		Frequency:     domain.NotificationImmediate,
+		Notifications: []domain.Notification{lowStock("Cordless drill", "DRL-18V", 3, 10)},
+	}
+	if err := mailer.Send(context.Background(), email); err != nil {
+		t.Fatal(err)
+	}
+	if catcher.from != "FROM:<alerts@stockhub.test>" || len(catcher.to) != 1 || catcher.to[0] != "TO:<manager@stockhub.test>" {
+		t.Errorf("envelope = %s %v", catcher.from, catcher.to)
+	}
+
+	message, bodies := readMessage(t, <-catcher.data)
+	subject, _ := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if want := "Low stock: Cordless drill (DRL-18V) is down to 3"; subject != want {
+		t.Errorf("subject = %q, want %q", subject, want)
+	}
+	if message.Header.Get("To") != "manager@stockhub.test" || message.Header.Get("Auto-Submitted") != "auto-generated" {
+		t.Errorf("headers = %v", message.Header)
+	}
+	for _, contentType := range []string{"text/plain", "text/html"} {
+		body := bodies[contentType]
+		for _, want := range []string{"Cordless drill", "DRL-18V", "https://stockhub.test"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("%s part does not mention %q:\n%s", contentType, want, body)
+			}
+		}
+	}
+}
+
+func TestSubject(t *testing.T) {
+	capacity := domain.Notification{Kind: domain.NotificationCapacityWarning, SubjectName: "North", Quantity: 920, Threshold: 1000}
+	for _, test := range []struct {
+		email *domain.NotificationEmail
+		want  string
+	}{
+		{&domain.NotificationEmail{Frequency: domain.NotificationImmediate, Notifications: []domain.Notification{capacity}}, "Warehouse North is at 92% of capacity"},
+		{&domain.NotificationEmail{Frequency: domain.NotificationImmediate, Notifications: []domain.Notification{capacity, lowStock("Saw", "SAW-1", 0, 5)}}, "StockHub: 2 stock alerts"},
+		{&domain.NotificationEmail{Frequency: domain.NotificationDaily, Notifications: []domain.Notification{capacity, lowStock("Saw", "SAW-1", 0, 5)}}, "StockHub daily digest: 2 alerts"},
+	} {
+		if got := Subject(test.email); got != test.want {
+			t.Errorf("Subject = %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestRenderEscapesHTMLAndKeepsHeadersOnOneLine(t *testing.T) {
+	mailer, err := NewMailer(Config{From: "alerts@stockhub.test", TLS: TLSNone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := mailer.Render(&domain.NotificationEmail{
+		To:            "manager@stockhub.test\r\nBcc: everyone@stockhub.test",
+		Frequency:     domain.NotificationImmediate,
+		Notifications: []domain.Notification{lowStock("<script>alert(1)</script>", "X-1", 1, 2)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, bodies := readMessage(t, string(message))
+	if parsed.Header.Get("Bcc") != "" {
+		t.Error("recipient injected a header")
+	}
+	if strings.Contains(bodies["text/html"], "<script>") {
+		t.Error("product name rendered as HTML")
+	}
+}
+
+func TestNewMailerRejectsBadSender(t *testing.T) {
+	if _, err := NewMailer(Config{From: "not an address"}); err == nil {
+		t.Fatal("sender without an address accepted")
+	}
+}
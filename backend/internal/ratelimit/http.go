@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/pkg/response"
+)
+
+// ClientIP returns the address of the client, or the first address of X-Forwarded-For when
+// the server sits behind a proxy that sets it
+func ClientIP(header http.Header, remoteAddr string, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := ForwardedIP(header.Get("X-Forwarded-For")); forwarded != "" {
+			return forwarded
+		}
+	}
+	return AddrIP(remoteAddr)
+}
+
+// ForwardedIP returns the original client of an X-Forwarded-For value
+func ForwardedIP(forwarded string) string {
+	first, _, _ := strings.Cut(forwarded, ",")
+	return strings.TrimSpace(first)
+}
+
+// AddrIP drops the port from a host:port address
+func AddrIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// HeaderKey identifies the client of an HTTP, Connect or gRPC-Web request that has been
+// authenticated with ctx, see Key
+func HeaderKey(ctx context.Context, header http.Header, remoteAddr string, trustForwardedFor bool) string {
+	return Key(ctx, ClientIP(header, remoteAddr, trustForwardedFor))
+}
+
+// RetryAfterSeconds rounds a wait up to the whole seconds of a Retry-After header
+func RetryAfterSeconds(wait time.Duration) int {
+	return int(math.Max(1, math.Ceil(wait.Seconds())))
+}
+
+// HTTPMiddleware charges GET and HEAD requests to the read budget and everything else to
+// writes, answering 429 Too Many Requests with Retry-After once a budget is spent. It runs
+// after authentication, so requests are charged to the API key that authenticated them.
+func HTTPMiddleware(limiter *Limiter, trustForwardedFor bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			class := ClassWrite
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				class = ClassRead
+			}
+			if ok, retryAfter := limiter.Allow(HeaderKey(r.Context(), r.Header, r.RemoteAddr, trustForwardedFor), class); !ok {
+				metrics.RateLimited.WithLabelValues("http", string(class)).Inc()
+				seconds := RetryAfterSeconds(retryAfter)
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				response.Error(w, http.StatusTooManyRequests, "Rate limit exceeded, retry in "+strconv.Itoa(seconds)+"s")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// BodyLimitMiddleware rejects bodies larger than maxBytes with 413 Request Entity Too Large.
+// Requests under uploadPrefix, the bulk import uploads, are held to uploadMaxBytes instead.
+func BodyLimitMiddleware(maxBytes int64, uploadPrefix string, uploadMaxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := maxBytes
+			if uploadPrefix != "" && strings.HasPrefix(r.URL.Path, uploadPrefix) {
+				limit = uploadMaxBytes
+			}
+			if limit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.ContentLength > limit {
+				response.Error(w, http.StatusRequestEntityTooLarge, "Request body exceeds "+strconv.FormatInt(limit, 10)+" bytes")
+				return
+			}
+			// Bodies without a declared length fail while being read instead
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+func TestHTTPMiddlewareKeysOnAuthenticatedKeyOrIP(t *testing.T) {
+	limiter := New(map[Class]Budget{ClassRead: {Rate: 0.001, Burst: 2}}, 0)
+	handler := HTTPMiddleware(limiter, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	get := func(remoteAddr string, header string, value string, key *domain.APIKey) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+		req.RemoteAddr = remoteAddr
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		if key != nil {
+			req = req.WithContext(domain.WithAPIKey(req.Context(), key))
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Made-up credentials that did not authenticate are charged to the client's IP
+	for i, header := range []string{"Authorization", "X-API-Key", "Authorization"} {
+		want := http.StatusNoContent
+		if i == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if code := get("10.0.0.1:1234", header, "Bearer bogus-"+strconv.Itoa(i), nil); code != want {
+			t.Fatalf("request %d with a rotated bogus key: status %d, want %d", i+1, code, want)
+		}
+	}
+
+	// An authenticated key has its own budget, wherever it calls from
+	key := &domain.APIKey{UUID: "4c2a3e0e-5a6f-4f1e-9f44-2d7b1c9a0b11"}
+	for i := 0; i < 2; i++ {
+		if code := get("10.0.0.1:1234", "X-API-Key", "sk_live", key); code != http.StatusNoContent {
+			t.Fatalf("request %d with an authenticated key: status %d, want %d", i+1, code, http.StatusNoContent)
+		}
+	}
+	if code := get("10.0.0.2:1234", "X-API-Key", "sk_live", key); code != http.StatusTooManyRequests {
+		t.Fatalf("authenticated key past its burst from another IP: status %d, want %d", code, http.StatusTooManyRequests)
+	}
+}
+
+func TestBodyLimitMiddlewareExemptsOnlyUploadsByPath(t *testing.T) {
+	handler := BodyLimitMiddleware(8, "/api/imports/", 64)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		size        int
+		want        int
+	}{
+		{"small body", "/api/categories", "application/json", 8, http.StatusNoContent},
+		{"large body", "/api/categories", "application/json", 9, http.StatusRequestEntityTooLarge},
+		{"large body sent as an upload", "/api/categories", "multipart/form-data; boundary=x", 9, http.StatusRequestEntityTooLarge},
+		{"upload", "/api/imports/products", "multipart/form-data; boundary=x", 64, http.StatusNoContent},
+		{"upload past its own limit", "/api/imports/products", "multipart/form-data; boundary=x", 65, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		for _, declared := range []bool{true, false} {
+			t.Run(tt.name, func(t *testing.T) {
+				body := io.Reader(strings.NewReader(strings.Repeat("x", tt.size)))
+				if !declared {
+					body = io.MultiReader(body) // Hides the length, so the body is cut off while read
+				}
+				req := httptest.NewRequest(http.MethodPost, tt.path, body)
+				req.Header.Set("Content-Type", tt.contentType)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				if rec.Code != tt.want {
+					t.Errorf("status = %d with declared length %v, want %d", rec.Code, declared, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package ratelimit keeps a token bucket per client and kind of request
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"golang.org/x/time/rate"
+)
+
+// Class is the budget a request is charged to
+type Class string
+
+const (
+	ClassRead   Class = "read"
+	ClassWrite  Class = "write"
+	ClassStream Class = "stream" // Opening a Watch stream
+)
+
+// Budget refills Rate tokens per second up to Burst
+type Budget struct {
+	Rate  float64
+	Burst int
+}
+
+// Limiter holds one bucket per client and class. A client is an API credential when the
+// request carries one and its IP address otherwise.
+type Limiter struct {
+	budgets    map[Class]Budget
+	maxStreams int // Concurrent streams per client; 0 is unlimited
+	mu         sync.Mutex
+	clients    map[string]*client
+}
+
+type client struct {
+	buckets  map[Class]*rate.Limiter
+	streams  int
+	lastSeen time.Time
+}
+
+func New(budgets map[Class]Budget, maxStreams int) *Limiter {
+	return &Limiter{budgets: budgets, maxStreams: maxStreams, clients: map[string]*client{}}
+}
+
+func (l *Limiter) client(key string) *client {
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{buckets: map[Class]*rate.Limiter{}}
+		l.clients[key] = c
+	}
+	c.lastSeen = time.Now()
+	return c
+}
+
+// take removes a token from the bucket, or returns how long until one is available
+func (l *Limiter) take(c *client, class Class) (bool, time.Duration) {
+	budget, ok := l.budgets[class]
+	if !ok {
+		return true, 0
+	}
+	bucket, ok := c.buckets[class]
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(budget.Rate), budget.Burst)
+		c.buckets[class] = bucket
+	}
+	reservation := bucket.Reserve()
+	if !reservation.OK() {
+		// Burst 0 never admits anything
+		return false, time.Minute
+	}
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return false, delay
+	}
+	return true, 0
+}
+
+// Allow charges one request of class to key; when the budget is spent it returns false and
+// how long the client should wait before retrying
+func (l *Limiter) Allow(key string, class Class) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.take(l.client(key), class)
+}
+
+// OpenStream charges a stream to key and counts it as open until release is called. It
+// fails when the stream budget is spent or key already has the maximum number open.
+func (l *Limiter) OpenStream(key string) (release func(), retryAfter time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.client(key)
+	if l.maxStreams > 0 && c.streams >= l.maxStreams {
+		// A slot frees up only when another stream ends; suggest a short wait
+		return nil, time.Second, false
+	}
+	if ok, retryAfter := l.take(c, ClassStream); !ok {
+		return nil, retryAfter, false
+	}
+	c.streams++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			c.streams--
+			c.lastSeen = time.Now()
+			l.mu.Unlock()
+		})
+	}, 0, true
+}
+
+// Cleanup forgets clients without open streams that have been idle for longer than idle,
+// checking every idle until ctx is cancelled. A forgotten client starts again with full buckets.
+func (l *Limiter) Cleanup(ctx context.Context, idle time.Duration) {
+	ticker := time.NewTicker(idle)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.mu.Lock()
+			for key, c := range l.clients {
+				if c.streams == 0 && now.Sub(c.lastSeen) > idle {
+					delete(l.clients, key)
+				}
+			}
+			l.mu.Unlock()
+		}
+	}
+}
+
+// ClassifyProcedure charges gRPC and Connect methods that change data, such as
+// /product.ProductService/CreateProduct, to the write budget and the rest to reads
+func ClassifyProcedure(procedure string) Class {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	for _, prefix := range []string{"Create", "Update", "Delete", "Transfer"} {
+		if strings.HasPrefix(method, prefix) {
+			return ClassWrite
+		}
+	}
+	return ClassRead
+}
+
+// Exempt reports infrastructure services, such as grpc.health.v1 probes and server
+// reflection, that are not charged to any budget
+func Exempt(procedure string) bool {
+	return strings.HasPrefix(procedure, "/grpc.")
+}
+
+// Key identifies the client of a request: the API key that authenticated it, and otherwise
+// its IP address. Credentials that did not authenticate are ignored, so rotating made-up keys
+// does not earn fresh budgets.
+func Key(ctx context.Context, ip string) string {
+	if key := domain.APIKeyFromContext(ctx); key != nil {
+		return "key:" + key.UUID
+	}
+	return "ip:" + ip
+}
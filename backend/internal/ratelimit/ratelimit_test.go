@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"testing"
+)
+
+func TestLimiterBudgetsAreSeparate(t *testing.T) {
+	limiter := New(map[Class]Budget{
+		ClassRead:  {Rate: 0.001, Burst: 2},
+		ClassWrite: {Rate: 0.001, Burst: 1},
+	}, 0)
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := limiter.Allow("ip:10.0.0.1", ClassRead); !ok {
+			t.Fatalf("read %d refused within burst", i+1)
+		}
+	}
+	ok, retryAfter := limiter.Allow("ip:10.0.0.1", ClassRead)
+	if ok || retryAfter <= 0 {
+		t.Fatalf("read past burst: got ok=%v retryAfter=%v, want refused with a wait", ok, retryAfter)
+	}
+	if ok, _ := limiter.Allow("ip:10.0.0.1", ClassWrite); !ok {
+		t.Fatal("write refused after reads spent the read budget")
+	}
+	if ok, _ := limiter.Allow("ip:10.0.0.2", ClassRead); !ok {
+		t.Fatal("another client refused after the first spent its budget")
+	}
+}
+
+func TestLimiterCapsOpenStreams(t *testing.T) {
+	limiter := New(map[Class]Budget{ClassStream: {Rate: 100, Burst: 100}}, 1)
+
+	release, _, ok := limiter.OpenStream("ip:10.0.0.1")
+	if !ok {
+		t.Fatal("first stream refused")
+	}
+	if _, _, ok := limiter.OpenStream("ip:10.0.0.1"); ok {
+		t.Fatal("second concurrent stream allowed past the cap of 1")
+	}
+	release()
+	release() // Releasing twice must not free a second slot
+	if _, _, ok := limiter.OpenStream("ip:10.0.0.1"); !ok {
+		t.Fatal("stream refused after the first was released")
+	}
+	if _, _, ok := limiter.OpenStream("ip:10.0.0.1"); ok {
+		t.Fatal("double release freed an extra slot")
+	}
+}
+
+func TestClassifyProcedure(t *testing.T) {
+	for procedure, want := range map[string]Class{
+		"/product.ProductService/CreateProduct":   ClassWrite,
+		"/movement.MovementService/TransferStock": ClassWrite,
+		"/product.ProductService/ListProducts":    ClassRead,
+		"/product.ProductService/GetProduct":      ClassRead,
+	} {
+		if got := ClassifyProcedure(procedure); got != want {
+			t.Errorf("ClassifyProcedure(%q) = %s, want %s", procedure, got, want)
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AlertRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertRepository(db *gorm.DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+func (r *AlertRepository) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	return wrapError("alert rule", r.db.WithContext(ctx).Create(rule).Error)
+}
+
+func (r *AlertRepository) GetRules(ctx context.Context) ([]domain.AlertRule, error) {
+	var rules []domain.AlertRule
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetEnabledRules returns the enabled rules of the given types, or of every type when none are given
+func (r *AlertRepository) GetEnabledRules(ctx context.Context, types ...domain.AlertRuleType) ([]domain.AlertRule, error) {
+	var rules []domain.AlertRule
+	query := r.db.WithContext(ctx).Where("enabled = ?", true)
+	if len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+	if err := query.Order("created_at ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *AlertRepository) GetRuleByID(ctx context.Context, uuid string) (*domain.AlertRule, error) {
+	var rule domain.AlertRule
+	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&rule).Error; err != nil {
+		return nil, wrapError("alert rule", err)
+	}
+	return &rule, nil
+}
+
+func (r *AlertRepository) UpdateRule(ctx context.Context, rule *domain.AlertRule) error {
+	result := r.db.WithContext(ctx).Model(rule).
+		Select("name", "type", "product_uuid", "warehouse_uuid", "threshold", "enabled", "updated_at").
+		Updates(rule)
+	if result.Error != nil {
+		return wrapError("alert rule", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAlertRuleNotFound
+	}
+	return nil
+}
+
+// DeleteRule deletes the rule along with its alerts and their history
+func (r *AlertRepository) DeleteRule(ctx context.Context, uuid string) error {
+	result := r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.AlertRule{})
+	if result.Error != nil {
+		return wrapError("alert rule", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAlertRuleNotFound
+	}
+	return nil
+}
+
+// Violations returns what the rule's condition holds for at now, within the rule's product and
+// warehouse and the scope
+func (r *AlertRepository) Violations(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope, now time.Time) ([]domain.AlertViolation, error) {
+	switch rule.Type {
+	case domain.AlertStockBelow:
+		return r.stockBelow(ctx, rule, scope)
+	case domain.AlertWarehouseUtilization:
+		return r.warehouseUtilization(ctx, rule, scope)
+	case domain.AlertLargeAdjustment:
+		return r.largeAdjustments(ctx, rule, scope)
+	case domain.AlertNoMovement:
+		return r.noMovement(ctx, rule, scope, now)
+	case domain.AlertStockDrift:
+		return r.stockDrift(ctx, rule, scope)
+	}
+	return nil, domain.ErrAlertRuleTypeInvalid
+}
+
+// narrow limits query to the rule's product and warehouse and to the scope; an empty column
+// means the rule type has no such subject
+func narrow(query *gorm.DB, rule *domain.AlertRule, scope domain.AlertScope, productColumn, warehouseColumn string) *gorm.DB {
+	if productColumn != "" {
+		if rule.ProductUUID != nil {
+			query = query.Where(productColumn+" = ?", *rule.ProductUUID)
+		}
+		if scope.ProductUUID != "" {
+			query = query.Where(productColumn+" = ?", scope.ProductUUID)
+		}
+	}
+	if warehouseColumn != "" {
+		if rule.WarehouseUUID != nil {
+			query = query.Where(warehouseColumn+" = ?", *rule.WarehouseUUID)
+		}
+		if scope.WarehouseUUID != "" {
+			query = query.Where(warehouseColumn+" = ?", scope.WarehouseUUID)
+		}
+	}
+	return query
+}
+
+func (r *AlertRepository) stockBelow(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope) ([]domain.AlertViolation, error) {
+	var rows []struct {
+		ProductUUID   string
+		WarehouseUUID string
+		Quantity      int
+		Title         string
+		Name          string
+	}
+	query := r.db.WithContext(ctx).
+		Table("warehouse_stocks AS ws").
+		Select("ws.product_uuid, ws.warehouse_uuid, ws.quantity, p.title, w.name").
+		Joins("JOIN products p ON p.uuid = ws.product_uuid").
+		Joins("JOIN warehouses w ON w.uuid = ws.warehouse_uuid").
+		Where("w.is_active = ? AND ws.quantity < ?", true, rule.Threshold)
+	if err := narrow(query, rule, scope, "ws.product_uuid", "ws.warehouse_uuid").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	violations := make([]domain.AlertViolation, len(rows))
+	for i, row := range rows {
+		violations[i] = domain.AlertViolation{
+			SubjectKey:    "product:" + row.ProductUUID + "/warehouse:" + row.WarehouseUUID,
+			ProductUUID:   &row.ProductUUID,
+			WarehouseUUID: &row.WarehouseUUID,
+			Value:         row.Quantity,
+			Message:       fmt.Sprintf("%s has %d units in %s, below %d", row.Title, row.Quantity, row.Name, rule.Threshold),
+		}
+	}
+	return violations, nil
+}
+
+func (r *AlertRepository) warehouseUtilization(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope) ([]domain.AlertViolation, error) {
+	var rows []struct {
+		UUID     string
+		Name     string
+		Capacity int
+		Total    int
+	}
+	query := r.db.WithContext(ctx).
+		Table("warehouses AS w").
+		Select("w.uuid, w.name, w.capacity, COALESCE(SUM(ws.quantity), 0) AS total").
+		Joins("LEFT JOIN warehouse_stocks ws ON ws.warehouse_uuid = w.uuid").
+		Where("w.is_active = ? AND w.capacity > 0", true).
+		Group("w.uuid").
+		Having("COALESCE(SUM(ws.quantity), 0) * 100 > w.capacity * ?", rule.Threshold)
+	if err := narrow(query, rule, scope, "", "w.uuid").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	violations := make([]domain.AlertViolation, len(rows))
+	for i, row := range rows {
+		percent := row.Total * 100 / row.Capacity
+		violations[i] = domain.AlertViolation{
+			SubjectKey:    "warehouse:" + row.UUID,
+			WarehouseUUID: &row.UUID,
+			Value:         percent,
+			Message:       fmt.Sprintf("%s is %d%% full, above %d%%", row.Name, percent, rule.Threshold),
+		}
+	}
+	return violations, nil
+}
+
+// largeAdjustments returns the adjustments in scope made since the rule was created that add or
+// remove more than its threshold. Opening balances loaded by imports are not adjustments anyone made.
+func (r *AlertRepository) largeAdjustments(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope) ([]domain.AlertViolation, error) {
+	var rows []struct {
+		UUID          string
+		ProductUUID   string
+		WarehouseUUID string
+		Quantity      int
+		Title         string
+		Name          string
+	}
+	query := r.db.WithContext(ctx).
+		Table("stock_movements AS sm").
+		Select("sm.uuid, sm.product_uuid, sm.warehouse_uuid, sm.quantity, p.title, w.name").
+		Joins("JOIN products p ON p.uuid = sm.product_uuid").
+		Joins("JOIN warehouses w ON w.uuid = sm.warehouse_uuid").
+		Where("sm.movement_type = ? AND ABS(sm.quantity) > ? AND sm.created_at >= ?",
+			domain.MovementTypeAdjustment, rule.Threshold, rule.CreatedAt).
+		Where("COALESCE(sm.adjustment_reason, '') <> ?", domain.AdjustmentReasonOpeningBalance)
+	if scope.MovementUUID != "" {
+		query = query.Where("sm.uuid = ?", scope.MovementUUID)
+	}
+	if err := narrow(query, rule, scope, "sm.product_uuid", "sm.warehouse_uuid").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	violations := make([]domain.AlertViolation, len(rows))
+	for i, row := range rows {
+		violations[i] = domain.AlertViolation{
+			SubjectKey:    "movement:" + row.UUID,
+			ProductUUID:   &row.ProductUUID,
+			WarehouseUUID: &row.WarehouseUUID,
+			MovementUUID:  &row.UUID,
+			Value:         row.Quantity,
+			Message:       fmt.Sprintf("%s was adjusted by %+d units in %s, more than %d", row.Title, row.Quantity, row.Name, rule.Threshold),
+		}
+	}
+	return violations, nil
+}
+
+// noMovement returns the products whose last movement, or creation when they never moved, is
+// more than the rule's threshold in days before now
+func (r *AlertRepository) noMovement(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope, now time.Time) ([]domain.AlertViolation, error) {
+	var rows []struct {
+		UUID        string
+		Title       string
+		LastMovedAt time.Time
+	}
+	query := r.db.WithContext(ctx).
+		Table("products AS p").
+		Select("p.uuid, p.title, COALESCE(MAX(sm.movement_date), p.created_at) AS last_moved_at").
+		Joins("LEFT JOIN stock_movements sm ON sm.product_uuid = p.uuid").
+		Group("p.uuid").
+		Having("COALESCE(MAX(sm.movement_date), p.created_at) < ?", now.AddDate(0, 0, -rule.Threshold))
+	if err := narrow(query, rule, scope, "p.uuid", "").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	violations := make([]domain.AlertViolation, len(rows))
+	for i, row := range rows {
+		days := int(now.Sub(row.LastMovedAt).Hours() / 24)
+		violations[i] = domain.AlertViolation{
+			SubjectKey:  "product:" + row.UUID,
+			ProductUUID: &row.UUID,
+			Value:       days,
+			Message:     fmt.Sprintf("%s has not moved for %d days", row.Title, days),
+		}
+	}
+	return violations, nil
+}
+
+// stockDrift returns the products whose warehouses together hold more than the rule's threshold
+// fewer units than their catalog stock
+func (r *AlertRepository) stockDrift(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope) ([]domain.AlertViolation, error) {
+	var rows []struct {
+		UUID           string
+		Title          string
+		Stock          int
+		WarehouseStock int
+	}
+	query := r.db.WithContext(ctx).
+		Table("products AS p").
+		Select("p.uuid, p.title, p.stock, COALESCE(SUM(ws.quantity), 0) AS warehouse_stock").
+		Joins("LEFT JOIN warehouse_stocks ws ON ws.product_uuid = p.uuid").
+		Group("p.uuid").
+		Having("p.stock - COALESCE(SUM(ws.quantity), 0) > ?", rule.Threshold)
+	if err := narrow(query, rule, scope, "p.uuid", "").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	violations := make([]domain.AlertViolation, len(rows))
+	for i, row := range rows {
+		short := row.Stock - row.WarehouseStock
+		violations[i] = domain.AlertViolation{
+			SubjectKey:  "product:" + row.UUID,
+			ProductUUID: &row.UUID,
+			Value:       short,
+			Message:     fmt.Sprintf("%s has %d units in warehouses but %d in the catalog, %d short", row.Title, row.WarehouseStock, row.Stock, short),
+		}
+	}
+	return violations, nil
+}
+
+// GetActive returns the unresolved alerts of the rule, or of every rule when ruleUUID is empty,
+// about the scope's product and warehouse, newest first
+func (r *AlertRepository) GetActive(ctx context.Context, ruleUUID string, scope domain.AlertScope) ([]domain.Alert, error) {
+	var alerts []domain.Alert
+	query := r.db.WithContext(ctx).Where("status <> ?", domain.AlertResolved)
+	if ruleUUID != "" {
+		query = query.Where("rule_uuid = ?", ruleUUID)
+	}
+	if scope.ProductUUID != "" {
+		query = query.Where("product_uuid = ?", scope.ProductUUID)
+	}
+	if scope.WarehouseUUID != "" {
+		query = query.Where("warehouse_uuid = ?", scope.WarehouseUUID)
+	}
+	if scope.MovementUUID != "" {
+		query = query.Where("movement_uuid = ?", scope.MovementUUID)
+	}
+	if err := query.Order("created_at DESC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// GetAll returns a page of alerts with their rules, newest first, and how many alerts match
+func (r *AlertRepository) GetAll(ctx context.Context, filter domain.AlertFilter, page, limit int) ([]domain.Alert, int64, error) {
+	var alerts []domain.Alert
+	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.Alert{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.RuleUUID != "" {
+		query = query.Where("rule_uuid = ?", filter.RuleUUID)
+	}
+	if filter.ProductUUID != "" {
+		query = query.Where("product_uuid = ?", filter.ProductUUID)
+	}
+	if filter.WarehouseUUID != "" {
+		query = query.Where("warehouse_uuid = ?", filter.WarehouseUUID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.
+		Preload("Rule").
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&alerts).Error; err != nil {
+		return nil, 0, err
+	}
+	return alerts, total, nil
+}
+
+// GetByID returns the alert with its rule and history, oldest transition first
+func (r *AlertRepository) GetByID(ctx context.Context, uuid string) (*domain.Alert, error) {
+	var alert domain.Alert
+	if err := r.db.WithContext(ctx).
+		Preload("Rule").
+		Preload("History", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("uuid = ?", uuid).
+		First(&alert).Error; err != nil {
+		return nil, wrapError("alert", err)
+	}
+	return &alert, nil
+}
+
+// Open stores the alert as open, with its first transition and an alert.changed event, unless
+// its rule already has an unresolved alert about the same subject. An adjustment is only ever
+// alerted about once, resolved or not. It reports whether the alert was opened.
+func (r *AlertRepository) Open(ctx context.Context, alert *domain.Alert) (bool, error) {
+	opened := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		query := tx.Model(&domain.Alert{}).Where("rule_uuid = ? AND subject_key = ?", alert.RuleUUID, alert.SubjectKey)
+		if alert.MovementUUID == nil {
+			query = query.Where("status <> ?", domain.AlertResolved)
+		}
+		if err := query.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		alert.Status = domain.AlertOpen
+		if err := tx.Create(alert).Error; err != nil {
+			return err
+		}
+		opened = true
+		return record(tx, alert, &domain.AlertTransition{To: domain.AlertOpen, Actor: domain.AlertActorSystem})
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// Opened at the same time by another event or replica
+		return false, nil
+	}
+	return opened, wrapError("alert", err)
+}
+
+// Transition locks the alert and lets change move it to another status. The change and the
+// transition change returns are stored with an alert.changed event in one transaction; a nil
+// transition leaves the alert as it was.
+func (r *AlertRepository) Transition(ctx context.Context, uuid string, change func(*domain.Alert) (*domain.AlertTransition, error)) (*domain.Alert, error) {
+	var alert domain.Alert
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", uuid).First(&alert).Error; err != nil {
+			return err
+		}
+		transition, err := change(&alert)
+		if err != nil || transition == nil {
+			return err
+		}
+		if err := tx.Model(&alert).
+			Select("status", "snoozed_until", "acknowledged_at", "acknowledged_by", "resolved_at", "updated_at").
+			Updates(&alert).Error; err != nil {
+			return err
+		}
+		return record(tx, &alert, transition)
+	})
+	if err != nil {
+		return nil, wrapError("alert", err)
+	}
+	return &alert, nil
+}
+
+// record stores the transition the alert just made and the alert.changed event describing it
+func record(tx *gorm.DB, alert *domain.Alert, transition *domain.AlertTransition) error {
+	transition.OrganizationUUID = alert.OrganizationUUID
+	transition.AlertUUID = alert.UUID
+	transition.To = alert.Status
+	transition.Value = alert.Value
+	if err := tx.Create(transition).Error; err != nil {
+		return err
+	}
+	transition.Alert = alert
+	return domain.EnqueueEvents(tx, domain.NewEvent(domain.EventAlertChanged, transition))
+}
+
+// Remeasure stores the alert's latest value, threshold and message without changing its status
+func (r *AlertRepository) Remeasure(ctx context.Context, alert *domain.Alert) error {
+	return wrapError("alert", r.db.WithContext(ctx).Model(alert).
+		Select("value", "threshold", "message", "updated_at").
+		Updates(alert).Error)
+}
+
+// GetDueSnoozes returns the snoozed alerts whose snooze ended by now
+func (r *AlertRepository) GetDueSnoozes(ctx context.Context, now time.Time) ([]domain.Alert, error) {
+	var alerts []domain.Alert
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND snoozed_until <= ?", domain.AlertSnoozed, now).
+		Order("snoozed_until ASC").
+		Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// GetTransitionsAfter returns up to limit transitions following the one with the given ID, oldest
+// first, with their alerts
+func (r *AlertRepository) GetTransitionsAfter(ctx context.Context, id int64, limit int) ([]domain.AlertTransition, error) {
+	var transitions []domain.AlertTransition
+	if err := r.db.WithContext(ctx).
+		Preload("Alert").
+		Where("id > ?", id).
+		Order("id ASC").
+		Limit(limit).
+		Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// LastTransitionID returns the ID of the latest transition, or 0 when there are none
+func (r *AlertRepository) LastTransitionID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.AlertTransition{}).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// FindTransitionsSince returns the transitions made after since, so Watch streams on other
+// replicas hear about them
+func (r *AlertRepository) FindTransitionsSince(ctx context.Context, since time.Time) ([]domain.AlertTransition, error) {
+	var transitions []domain.AlertTransition
+	if err := r.db.WithContext(ctx).
+		Where("created_at > ?", since).
+		Order("id DESC").
+		Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
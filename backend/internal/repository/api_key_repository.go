@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	return wrapError("API key", r.db.WithContext(ctx).Create(key).Error)
+}
+
+func (r *APIKeyRepository) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) GetByID(ctx context.Context, uuid string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&key).Error; err != nil {
+		return nil, wrapError("API key", err)
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		return nil, wrapError("API key", err)
+	}
+	return &key, nil
+}
+
+// UpdateSecret replaces the key of an API key that has not been revoked; the old key stops
+// working at once
+func (r *APIKeyRepository) UpdateSecret(ctx context.Context, uuid string, prefix string, keyHash string) error {
+	result := r.db.WithContext(ctx).Model(&domain.APIKey{}).
+		Where("uuid = ? AND revoked_at IS NULL", uuid).
+		Updates(map[string]any{"prefix": prefix, "key_hash": keyHash, "last_used_at": nil})
+	if result.Error != nil {
+		return wrapError("API key", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAPIKeyRevoked
+	}
+	return nil
+}
+
+// Revoke disables an API key for good. The key is kept so movements stay attributed to it.
+func (r *APIKeyRepository) Revoke(ctx context.Context, uuid string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.APIKey{}).
+		Where("uuid = ? AND revoked_at IS NULL", uuid).
+		Update("revoked_at", at).Error
+}
+
+// TouchLastUsed records when the key was last used, without bumping updated_at
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, uuid string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.APIKey{}).
+		Where("uuid = ?", uuid).
+		UpdateColumn("last_used_at", at).Error
+}
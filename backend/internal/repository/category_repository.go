@@ -16,7 +16,7 @@ func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
 }
 
 func (r *CategoryRepository) Create(ctx context.Context, category *domain.Category) error {
-	return r.db.WithContext(ctx).Create(category).Error
+	return wrapError("category", r.db.WithContext(ctx).Create(category).Error)
 }
 
 func (r *CategoryRepository) GetAll(ctx context.Context) ([]domain.Category, error) {
@@ -53,15 +53,31 @@ func (r *CategoryRepository) Count(ctx context.Context) (int64, error) {
 func (r *CategoryRepository) GetByID(ctx context.Context, uuid string) (*domain.Category, error) {
 	var category domain.Category
 	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&category).Error; err != nil {
-		return nil, err
+		return nil, wrapError("category", err)
 	}
 	return &category, nil
 }
 
 func (r *CategoryRepository) Update(ctx context.Context, uuid string, category *domain.Category) error {
-	return r.db.WithContext(ctx).Model(&domain.Category{}).Where("uuid = ?", uuid).Updates(category).Error
+	return wrapError("category", r.db.WithContext(ctx).Model(&domain.Category{}).Where("uuid = ?", uuid).Updates(category).Error)
 }
 
 func (r *CategoryRepository) Delete(ctx context.Context, uuid string) error {
-	return r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.Category{}).Error
+	return wrapError("category", r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.Category{}).Error)
+}
+
+// UpsertByName creates categories whose name does not exist yet and updates the given columns
+// of those that do, all within one transaction. Names are matched case-insensitively.
+func (r *CategoryRepository) UpsertByName(ctx context.Context, categories []domain.Category, columns [][]string) (int, int, error) {
+	var created, updated int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		created, updated, err = upsertByName(tx, categories, func(category *domain.Category) (string, *string) {
+			return category.Name, &category.UUID
+		}, columns, nil)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
 }
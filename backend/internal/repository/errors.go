@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+)
+
+// wrapError turns GORM errors into domain errors naming entity. Errors that already are domain
+// errors, and errors GORM has no meaning for, are returned unchanged.
+func wrapError(entity string, err error) error {
+	if err == nil || domain.AsError(err) != nil {
+		return err
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.NewNotFoundError(entity, err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return domain.NewConflictError(entity+" already exists", err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return domain.NewConflictError(entity+" is linked to missing or dependent records", err)
+	}
+	return err
+}
+
+// errDryRun rolls back a transaction that only checks whether its writes would succeed
+var errDryRun = errors.New("dry run")
+
+// dryRun runs fn in a transaction that is rolled back whether or not fn succeeds, and returns
+// fn's error
+func dryRun(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
+}
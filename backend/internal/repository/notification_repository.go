@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Queue stores the notification unless its recipient was already told about the same subject,
+// for the same kind, since the given time. It reports whether the notification was queued.
+func (r *NotificationRepository) Queue(ctx context.Context, notification *domain.Notification, since time.Time) (bool, error) {
+	queued := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.Notification{}).
+			Where("recipient = ? AND kind = ? AND subject_uuid = ? AND created_at >= ?",
+				notification.Recipient, notification.Kind, notification.SubjectUUID, since).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		queued = true
+		return tx.Create(notification).Error
+	})
+	return queued, wrapError("notification", err)
+}
+
+// GetAll returns a page of the notification log, newest first, and how many notifications match
+func (r *NotificationRepository) GetAll(ctx context.Context, filter domain.NotificationFilter, page, limit int) ([]domain.Notification, int64, error) {
+	var notifications []domain.Notification
+	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.Notification{})
+	if filter.Recipient != "" {
+		query = query.Where("recipient = ?", filter.Recipient)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// ClaimDue returns up to limit pending notifications whose next attempt is due, oldest first,
+// pushing that attempt back by lease so other replicas skip them while they are sent. The
+// notifications of one recipient due together are claimed together unless limit cuts them off.
+func (r *NotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.NotificationPending, now).
+			Order("recipient ASC, next_attempt_at ASC").
+			Limit(limit).
+			Find(&notifications).Error; err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+		uuids := make([]string, len(notifications))
+		for i := range notifications {
+			uuids[i] = notifications[i].UUID
+		}
+		return tx.Model(&domain.Notification{}).
+			Where("uuid IN ?", uuids).
+			UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// Save records the outcome of an attempt at sending the notifications
+func (r *NotificationRepository) Save(ctx context.Context, notifications []domain.Notification) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range notifications {
+			if err := tx.Model(&notifications[i]).
+				Select("status", "attempts", "error", "next_attempt_at", "sent_at").
+				Updates(&notifications[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *NotificationRepository) GetPreferences(ctx context.Context) ([]domain.NotificationPreference, error) {
+	var preferences []domain.NotificationPreference
+	if err := r.db.WithContext(ctx).Order("email ASC").Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (r *NotificationRepository) GetPreference(ctx context.Context, email string) (*domain.NotificationPreference, error) {
+	var preference domain.NotificationPreference
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&preference).Error; err != nil {
+		return nil, wrapError("notification preference", err)
+	}
+	return &preference, nil
+}
+
+// SavePreference creates the preference of its email address, or replaces the stored one
+func (r *NotificationRepository) SavePreference(ctx context.Context, preference *domain.NotificationPreference) error {
+	return wrapError("notification preference", r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing domain.NotificationPreference
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("email = ?", preference.Email).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(preference).Error
+		}
+		if err != nil {
+			return err
+		}
+		preference.UUID = existing.UUID
+		preference.OrganizationUUID = existing.OrganizationUUID
+		preference.CreatedAt = existing.CreatedAt
+		return tx.Select("frequency", "kinds", "updated_at").Updates(preference).Error
+	}))
+}
+
+// DeletePreference forgets the preference of the email address, so its defaults apply again
+func (r *NotificationRepository) DeletePreference(ctx context.Context, email string) error {
+	result := r.db.WithContext(ctx).Where("email = ?", email).Delete(&domain.NotificationPreference{})
+	if result.Error != nil {
+		return wrapError("notification preference", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrNotificationPreferenceNotFound
+	}
+	return nil
+}
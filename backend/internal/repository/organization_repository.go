@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	return wrapError("organization", r.db.WithContext(ctx).Create(organization).Error)
+}
+
+func (r *OrganizationRepository) GetAll(ctx context.Context) ([]domain.Organization, error) {
+	var organizations []domain.Organization
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&organizations).Error; err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, uuid string) (*domain.Organization, error) {
+	var organization domain.Organization
+	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&organization).Error; err != nil {
+		return nil, wrapError("organization", err)
+	}
+	return &organization, nil
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, uuid string, organization *domain.Organization) error {
+	return wrapError("organization", r.db.WithContext(ctx).Model(&domain.Organization{}).Where("uuid = ?", uuid).Updates(organization).Error)
+}
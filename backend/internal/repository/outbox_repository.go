@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+)
+
+// outboxLockKey is the Postgres advisory lock held by the replica dispatching the outbox, so
+// messages are delivered by one replica at a time and in order ("outbox" in ASCII)
+const outboxLockKey = 0x6f7574626f78
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Dispatch hands up to limit undelivered messages to deliver, oldest first, and saves the
+// messages it returns. Messages whose aggregate has an earlier message waiting for a retry are
+// left out, so an aggregate's events are never delivered out of order. It all happens in one
+// transaction holding the outbox lock; when another replica holds it nothing is dispatched.
+func (r *OutboxRepository) Dispatch(ctx context.Context, now time.Time, limit int, deliver func([]domain.OutboxMessage) []domain.OutboxMessage) (int, error) {
+	var dispatched int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var messages []domain.OutboxMessage
+		if err := tx.Where("delivered_at IS NULL").
+			Where(`NOT EXISTS (SELECT 1 FROM outbox waiting WHERE waiting.delivered_at IS NULL
+				AND waiting.aggregate_type = outbox.aggregate_type AND waiting.aggregate_id = outbox.aggregate_id
+				AND waiting.id <= outbox.id AND waiting.next_attempt_at > ?)`, now).
+			Order("id").
+			Limit(limit).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+		dispatched = len(messages)
+		if dispatched == 0 {
+			return nil
+		}
+
+		for _, message := range deliver(messages) {
+			if err := tx.Model(&message).
+				Select("attempts", "last_error", "next_attempt_at", "delivered_at").
+				Updates(&message).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return dispatched, err
+}
+
+// DeleteDelivered removes the messages delivered before the given time
+func (r *OutboxRepository) DeleteDelivered(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("delivered_at < ?", before).Delete(&domain.OutboxMessage{})
+	return result.RowsAffected, result.Error
+}
+
+// Notify sends payload to the listeners of a Postgres NOTIFY channel
+func (r *OutboxRepository) Notify(ctx context.Context, channel string, payload string) error {
+	return r.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, payload).Error
+}
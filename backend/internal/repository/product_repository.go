@@ -2,51 +2,48 @@ package repository
 
 import (
 	"context"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/shirloin/stockhub/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository struct {
-	db          *gorm.DB
-	subscribers []chan []domain.Product
+	db *gorm.DB
 }
 
 func NewProductRepository(db *gorm.DB) *ProductRepository {
-	repo := &ProductRepository{db: db, subscribers: make([]chan []domain.Product, 0)}
-	go repo.pollForChanges()
-	return repo
+	return &ProductRepository{db: db}
 }
 
+// Create stores the product and its product.created event in one transaction
 func (r *ProductRepository) Create(ctx context.Context, product *domain.Product) error {
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
-		return err
-	}
-	// Trigger immediate notification for new product
-	go func() {
-		allProducts, err := r.FindAll(context.Background())
-		if err == nil {
-			r.notifySubscribers(allProducts)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(product).Error; err != nil {
+			return err
 		}
-	}()
-	return nil
+		return domain.EnqueueEvents(tx, domain.ProductEvents(nil, product)...)
+	})
+	return wrapError("product", err)
 }
 
-func (r *ProductRepository) GetAll(ctx context.Context) ([]domain.Product, error) {
+func (r *ProductRepository) GetAll(ctx context.Context, filter domain.ProductFilter) ([]domain.Product, error) {
 	var products []domain.Product
-	if err := r.db.WithContext(ctx).Preload("Category").Preload("Supplier").Order("created_at DESC").Find(&products).Error; err != nil {
+	query := r.db.WithContext(ctx).Preload("Category").Preload("Supplier")
+	if err := orderProducts(filterProducts(query, filter), filter).Find(&products).Error; err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-func (r *ProductRepository) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.Product, error) {
+func (r *ProductRepository) GetAllPaginated(ctx context.Context, filter domain.ProductFilter, page, limit int) ([]domain.Product, error) {
 	var products []domain.Product
 	offset := (page - 1) * limit
-	if err := r.db.WithContext(ctx).
-		Preload("Category").Preload("Supplier").
-		Order("created_at DESC").
+	query := r.db.WithContext(ctx).Preload("Category").Preload("Supplier")
+	if err := orderProducts(filterProducts(query, filter), filter).
 		Offset(offset).
 		Limit(limit).
 		Find(&products).Error; err != nil {
@@ -55,105 +52,261 @@ func (r *ProductRepository) GetAllPaginated(ctx context.Context, page, limit int
 	return products, nil
 }
 
+// CountFiltered counts the products matching the same conditions GetAllPaginated applies
+func (r *ProductRepository) CountFiltered(ctx context.Context, filter domain.ProductFilter) (int64, error) {
+	var count int64
+	if err := filterProducts(r.db.WithContext(ctx).Model(&domain.Product{}), filter).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// productSearchVector must stay identical to the expression of idx_products_search
+// created by the baseline migration, otherwise Postgres cannot use the index
+const productSearchVector = "to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, '') || ' ' || coalesce(sku, '') || ' ' || coalesce(barcode, ''))"
+
+var productSortColumns = map[domain.ProductSortField]string{
+	domain.ProductSortTitle:     "title",
+	domain.ProductSortSKU:       "sku",
+	domain.ProductSortPrice:     "price",
+	domain.ProductSortStock:     "stock",
+	domain.ProductSortCreatedAt: "created_at",
+	domain.ProductSortUpdatedAt: "updated_at",
+}
+
+// productSearchQuery turns free text into a prefix-matching tsquery, e.g. "blue sh" -> "blue:* & sh:*".
+// Only letters and digits are kept so user input can never break the tsquery syntax.
+func productSearchQuery(search string) string {
+	terms := strings.FieldsFunc(search, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+func filterProducts(query *gorm.DB, filter domain.ProductFilter) *gorm.DB {
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		like := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(search) + "%"
+		if tsQuery := productSearchQuery(search); tsQuery != "" {
+			query = query.Where(productSearchVector+" @@ to_tsquery('simple', ?) OR sku ILIKE ? OR barcode = ?", tsQuery, like, search)
+		} else {
+			query = query.Where("sku ILIKE ? OR barcode = ?", like, search)
+		}
+	}
+	if filter.CategoryUUID != "" {
+		query = query.Where("category_uuid = ?", filter.CategoryUUID)
+	}
+	if filter.SupplierUUID != "" {
+		query = query.Where("supplier_uuid = ?", filter.SupplierUUID)
+	}
+	if filter.MinPrice != nil {
+		query = query.Where("price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query = query.Where("price <= ?", *filter.MaxPrice)
+	}
+	// Same rules as GetLowStockProducts, CountLowStockOnly and CountOutOfStock
+	switch filter.StockStatus {
+	case domain.ProductStockStatusIn:
+		query = query.Where("stock > COALESCE(low_stock_threshold, 10)")
+	case domain.ProductStockStatusLow:
+		query = query.Where("stock > 0 AND stock <= COALESCE(low_stock_threshold, 10)")
+	case domain.ProductStockStatusOut:
+		query = query.Where("stock = 0")
+	}
+	return query
+}
+
+// orderProducts applies the requested sort with uuid as a final tie-breaker so pages never overlap
+func orderProducts(query *gorm.DB, filter domain.ProductFilter) *gorm.DB {
+	if len(filter.Sort) == 0 {
+		if tsQuery := productSearchQuery(filter.Search); tsQuery != "" {
+			// A single expression, since GORM drops an expression once columns are merged into the ORDER BY
+			return query.Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "ts_rank(" + productSearchVector + ", to_tsquery('simple', ?)) DESC, created_at DESC, uuid ASC",
+				Vars: []any{tsQuery},
+			}})
+		}
+		return query.Order("created_at DESC").Order("uuid ASC")
+	}
+	for _, sort := range filter.Sort {
+		column, ok := productSortColumns[sort.Field]
+		if !ok {
+			continue
+		}
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: sort.Desc})
+	}
+	return query.Order("uuid ASC")
+}
+
 func (r *ProductRepository) GetById(ctx context.Context, uuid string) (*domain.Product, error) {
 	var product domain.Product
 	if err := r.db.WithContext(ctx).Preload("Category").Preload("Supplier").Where("uuid = ?", uuid).First(&product).Error; err != nil {
-		return nil, err
+		return nil, wrapError("product", err)
 	}
 	return &product, nil
 }
 
+// Update stores the changes and a product.updated event describing the stored product in one
+// transaction, with product.low_stock when the change takes it to its threshold
 func (r *ProductRepository) Update(ctx context.Context, uuid string, product *domain.Product) error {
-	if err := r.db.WithContext(ctx).Model(&domain.Product{}).Where("uuid = ?", uuid).Updates(product).Error; err != nil {
-		return err
-	}
-	// Trigger immediate notification for updated product
-	go func() {
-		allProducts, err := r.FindAll(context.Background())
-		if err == nil {
-			r.notifySubscribers(allProducts)
-		}
-	}()
-	return nil
+	return wrapError("product", r.change(ctx, uuid, func(tx *gorm.DB) error {
+		return tx.Model(&domain.Product{}).Where("uuid = ?", uuid).Updates(product).Error
+	}))
 }
 
 // UpdateStock updates only the stock field, ensuring zero values are persisted.
 func (r *ProductRepository) UpdateStock(ctx context.Context, uuid string, stock int) error {
-	if err := r.db.WithContext(ctx).
-		Model(&domain.Product{}).
-		Where("uuid = ?", uuid).
-		Update("stock", stock).Error; err != nil {
-		return err
-	}
-	// Trigger immediate notification for stock update
-	go func() {
-		allProducts, err := r.FindAll(context.Background())
-		if err == nil {
-			r.notifySubscribers(allProducts)
+	return wrapError("product", r.change(ctx, uuid, func(tx *gorm.DB) error {
+		return tx.Model(&domain.Product{}).Where("uuid = ?", uuid).Update("stock", stock).Error
+	}))
+}
+
+// change applies update to the product within a transaction that also queues the events describing it
+func (r *ProductRepository) change(ctx context.Context, uuid string, update func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before domain.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", uuid).First(&before).Error; err != nil {
+			return err
+		}
+		if err := update(tx); err != nil {
+			return err
 		}
-	}()
-	return nil
+		var after domain.Product
+		if err := tx.Preload("Category").Preload("Supplier").Where("uuid = ?", uuid).First(&after).Error; err != nil {
+			return err
+		}
+		return domain.EnqueueEvents(tx, domain.ProductEvents(&before, &after)...)
+	})
 }
 
+// Delete removes the product and queues product.deleted with what was removed, in one transaction
 func (r *ProductRepository) Delete(ctx context.Context, uuid string) error {
-	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.Product{}).Error; err != nil {
-		return err
-	}
-	// Trigger immediate notification for deleted product
-	go func() {
-		allProducts, err := r.FindAll(context.Background())
-		if err == nil {
-			r.notifySubscribers(allProducts)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var product domain.Product
+		if err := tx.Preload("Category").Preload("Supplier").Where("uuid = ?", uuid).First(&product).Error; err != nil {
+			return err
 		}
-	}()
-	return nil
+		if err := tx.Delete(&product).Error; err != nil {
+			return err
+		}
+		return domain.EnqueueEvents(tx, domain.ProductEvents(&product, nil)...)
+	})
+	return wrapError("product", err)
 }
 
-func (r *ProductRepository) SubscribeToChanges() <-chan []domain.Product {
-	ch := make(chan []domain.Product, 10)
-
-	r.subscribers = append(r.subscribers, ch)
-
-	return ch
+// UpsertBySKU creates products whose SKU does not exist yet and updates the given
+// columns of those that do, all within one transaction together with their events. columns[i]
+// lists the columns written for products[i]. UUIDs of updated products are filled in on the
+// passed slice.
+func (r *ProductRepository) UpsertBySKU(ctx context.Context, products []domain.Product, columns [][]string) (int, int, error) {
+	var created, updated int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		created, updated, err = upsertBySKU(tx, products, columns)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
 
+// PreviewUpsertBySKU fails as UpsertBySKU would, against the stored products, categories and
+// suppliers, without changing anything
+func (r *ProductRepository) PreviewUpsertBySKU(ctx context.Context, products []domain.Product, columns [][]string) error {
+	return dryRun(r.db.WithContext(ctx), func(tx *gorm.DB) error {
+		_, _, err := upsertBySKU(tx, products, columns)
+		return err
+	})
 }
 
-func (r *ProductRepository) pollForChanges() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+func upsertBySKU(tx *gorm.DB, products []domain.Product, columns [][]string) (int, int, error) {
+	var created, updated int
+	skus := make([]string, 0, len(products))
+	for _, product := range products {
+		skus = append(skus, product.SKU)
+	}
+
+	var existing []domain.Product
+	if err := tx.Where("sku IN ?", skus).Find(&existing).Error; err != nil {
+		return 0, 0, err
+	}
+	uuidBySKU := make(map[string]string, len(existing))
+	before := make(map[string]*domain.Product, len(existing))
+	for i, product := range existing {
+		uuidBySKU[product.SKU] = product.UUID
+		before[product.UUID] = &existing[i]
+	}
 
-	var lastUpdate time.Time
-	for range ticker.C {
-		products, err := r.getUpdatedProducts(lastUpdate)
-		if err != nil {
+	for i := range products {
+		product := &products[i]
+		if uuid, ok := uuidBySKU[product.SKU]; ok {
+			if err := tx.Model(&domain.Product{}).
+				Where("uuid = ?", uuid).
+				Select(append(columns[i][:len(columns[i]):len(columns[i])], "updated_at")).
+				Updates(product).Error; err != nil {
+				return 0, 0, err
+			}
+			product.UUID = uuid
+			updated++
 			continue
 		}
 
-		if len(products) > 0 {
-			allProducts, err := r.FindAll(context.Background())
-			if err != nil {
-				continue
-			}
-			r.notifySubscribers(allProducts)
-			lastUpdate = time.Now()
+		// PostgreSQL doesn't accept empty string for UUID columns
+		omit := []string{clause.Associations}
+		if product.CategoryUUID == "" {
+			omit = append(omit, "category_uuid")
+		}
+		if product.SupplierUUID == "" {
+			omit = append(omit, "supplier_uuid")
 		}
+		if err := tx.Omit(omit...).Create(product).Error; err != nil {
+			return 0, 0, err
+		}
+		created++
 	}
-}
-
-func (r *ProductRepository) getUpdatedProducts(since time.Time) ([]domain.Product, error) {
-	ctx := context.Background()
-	return r.FindUpdatedSince(ctx, since)
 
+	uuids := make([]string, 0, len(products))
+	for _, product := range products {
+		uuids = append(uuids, product.UUID)
+	}
+	var stored []domain.Product
+	if err := tx.Where("uuid IN ?", uuids).Find(&stored).Error; err != nil {
+		return 0, 0, err
+	}
+	var events []domain.Event
+	for i := range stored {
+		events = append(events, domain.ProductEvents(before[stored[i].UUID], &stored[i])...)
+	}
+	if err := domain.EnqueueEvents(tx, events...); err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
 }
 
-func (r *ProductRepository) notifySubscribers(products []domain.Product) {
+// GetByUUIDs returns the products among the given UUIDs that exist; missing ones are simply absent
+func (r *ProductRepository) GetByUUIDs(ctx context.Context, uuids []string) ([]domain.Product, error) {
+	var products []domain.Product
+	if err := r.db.WithContext(ctx).Where("uuid IN ?", uuids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
 
-	for _, ch := range r.subscribers {
-		select {
-		case ch <- products:
-		default:
-		}
+// GetUUIDsBySKU returns a lookup of product UUIDs keyed by SKU
+func (r *ProductRepository) GetUUIDsBySKU(ctx context.Context) (map[string]string, error) {
+	var products []domain.Product
+	if err := r.db.WithContext(ctx).Select("uuid", "sku").Find(&products).Error; err != nil {
+		return nil, err
+	}
+	uuids := make(map[string]string, len(products))
+	for _, product := range products {
+		uuids[product.SKU] = product.UUID
 	}
+	return uuids, nil
 }
 
 func (r *ProductRepository) FindAll(ctx context.Context) ([]domain.Product, error) {
@@ -14,6 +14,13 @@ type Repositories struct {
 	StockInRepository         *StockInRepository
 	StockOutRepository        *StockOutRepository
 	StockAdjustmentRepository *StockAdjustmentRepository
+	StockDocumentRepository   *StockDocumentRepository
+	APIKeyRepository          *APIKeyRepository
+	WebhookRepository         *WebhookRepository
+	OutboxRepository          *OutboxRepository
+	OrganizationRepository    *OrganizationRepository
+	NotificationRepository    *NotificationRepository
+	AlertRepository           *AlertRepository
 }
 
 func InitRepositories(db *gorm.DB) *Repositories {
@@ -26,6 +33,13 @@ func InitRepositories(db *gorm.DB) *Repositories {
 	stockInRepository := NewStockInRepository(db)
 	stockOutRepository := NewStockOutRepository(db)
 	stockAdjustmentRepository := NewStockAdjustmentRepository(db)
+	stockDocumentRepository := NewStockDocumentRepository(db)
+	apiKeyRepository := NewAPIKeyRepository(db)
+	webhookRepository := NewWebhookRepository(db)
+	outboxRepository := NewOutboxRepository(db)
+	organizationRepository := NewOrganizationRepository(db)
+	notificationRepository := NewNotificationRepository(db)
+	alertRepository := NewAlertRepository(db)
 
 	return &Repositories{
 		ProductRepository:          productRepository,
@@ -37,5 +51,33 @@ func InitRepositories(db *gorm.DB) *Repositories {
 		StockInRepository:           stockInRepository,
 		StockOutRepository:          stockOutRepository,
 		StockAdjustmentRepository:   stockAdjustmentRepository,
+		StockDocumentRepository:     stockDocumentRepository,
+		APIKeyRepository:            apiKeyRepository,
+		WebhookRepository:           webhookRepository,
+		OutboxRepository:            outboxRepository,
+		OrganizationRepository:      organizationRepository,
+		NotificationRepository:      notificationRepository,
+		AlertRepository:             alertRepository,
 	}
 }
+
+// streamRows scans a query row by row and hands each record to fn, so large result sets
+// are never held in memory at once
+func streamRows[T any](query *gorm.DB, fn func(*T) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record T
+		if err := query.ScanRows(rows, &record); err != nil {
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
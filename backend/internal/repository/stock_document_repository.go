@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewStockDocumentRepository(db *gorm.DB) *StockDocumentRepository {
+	return &StockDocumentRepository{db: db}
+}
+
+// Post writes the document, its lines, the matching stock in/out records and one movement
+// per line in a single transaction. The warehouse row is locked for the duration so that
+// capacity and stock levels are checked against a consistent view.
+func (r *StockDocumentRepository) Post(ctx context.Context, document *domain.StockDocument) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var warehouse domain.Warehouse
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ?", document.WarehouseUUID).
+			First(&warehouse).Error; err != nil {
+			return wrapError("warehouse", err)
+		}
+
+		if document.DocumentType == domain.StockDocumentReceipt && warehouse.Capacity > 0 {
+			var total int
+			if err := tx.Model(&domain.WarehouseStock{}).
+				Where("warehouse_uuid = ?", document.WarehouseUUID).
+				Select("COALESCE(SUM(quantity), 0)").
+				Scan(&total).Error; err != nil {
+				return err
+			}
+			if total+document.TotalQuantity > warehouse.Capacity {
+				return domain.ErrWarehouseCapacityExceeded
+			}
+		}
+
+		// Lines are created one by one below, once their movement exists
+		if err := tx.Omit(stockDocumentOmits(document.SupplierUUID)...).Create(document).Error; err != nil {
+			// Lost a race with another post of the same reference number
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return domain.ErrStockDocumentDuplicate
+			}
+			return err
+		}
+
+		for i := range document.Lines {
+			line := &document.Lines[i]
+			line.DocumentUUID = document.UUID
+			line.LineNumber = i + 1
+
+			var stock domain.WarehouseStock
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("product_uuid = ? AND warehouse_uuid = ?", line.ProductUUID, document.WarehouseUUID).
+				First(&stock).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				stock = domain.WarehouseStock{ProductUUID: line.ProductUUID, WarehouseUUID: document.WarehouseUUID}
+			} else if err != nil {
+				return err
+			}
+
+			previousQty := stock.Quantity
+			movement := &domain.StockMovement{
+				ProductUUID:     line.ProductUUID,
+				WarehouseUUID:   document.WarehouseUUID,
+				Quantity:        line.Quantity,
+				PreviousQty:     previousQty,
+				ReferenceNumber: document.ReferenceNumber,
+				DocumentUUID:    document.UUID,
+				Notes:           line.Notes,
+				CreatedBy:       document.CreatedBy,
+				MovementDate:    document.DocumentDate,
+			}
+
+			if document.DocumentType == domain.StockDocumentReceipt {
+				stock.Quantity += line.Quantity
+				movement.MovementType = domain.MovementTypeStockIn
+
+				stockIn := &domain.StockIn{
+					ProductUUID:     line.ProductUUID,
+					WarehouseUUID:   document.WarehouseUUID,
+					Quantity:        line.Quantity,
+					PurchaseOrderNo: document.ReferenceNumber,
+					SupplierUUID:    document.SupplierUUID,
+					ReceivedDate:    document.DocumentDate,
+					ReceivedBy:      document.CreatedBy,
+					Notes:           line.Notes,
+				}
+				if err := tx.Omit(stockDocumentOmits(stockIn.SupplierUUID)...).Create(stockIn).Error; err != nil {
+					return err
+				}
+			} else {
+				if stock.Quantity < line.Quantity {
+					return &domain.StockDocumentLineError{Line: line.LineNumber, Err: domain.ErrInsufficientStock}
+				}
+				stock.Quantity -= line.Quantity
+				movement.MovementType = domain.MovementTypeStockOut
+				movement.Quantity = -line.Quantity // Negative for out
+
+				stockOut := &domain.StockOut{
+					ProductUUID:   line.ProductUUID,
+					WarehouseUUID: document.WarehouseUUID,
+					Quantity:      line.Quantity,
+					SalesOrderNo:  document.ReferenceNumber,
+					CustomerName:  document.CustomerName,
+					ShippedDate:   document.DocumentDate,
+					ShippedBy:     document.CreatedBy,
+					Notes:         line.Notes,
+				}
+				if err := tx.Omit(clause.Associations).Create(stockOut).Error; err != nil {
+					return err
+				}
+			}
+
+			if stock.UUID == "" {
+				if err := tx.Create(&stock).Error; err != nil {
+					return err
+				}
+			} else if err := tx.Save(&stock).Error; err != nil {
+				return err
+			}
+
+			movement.NewQty = stock.Quantity
+			if err := tx.Omit(movementOmits(movement)...).Create(movement).Error; err != nil {
+				return err
+			}
+
+			line.MovementUUID = movement.UUID
+			if err := tx.Omit(clause.Associations).Create(line).Error; err != nil {
+				return err
+			}
+		}
+
+		// Update warehouse's updated_at timestamp to trigger real-time updates
+		return tx.Model(&domain.Warehouse{}).Where("uuid = ?", document.WarehouseUUID).Update("updated_at", time.Now()).Error
+	})
+}
+
+// stockDocumentOmits skips associations and, when empty, the optional supplier reference,
+// since PostgreSQL doesn't accept empty string for UUID
+func stockDocumentOmits(supplierUUID string) []string {
+	if supplierUUID == "" {
+		return []string{clause.Associations, "supplier_uuid"}
+	}
+	return []string{clause.Associations}
+}
+
+func (r *StockDocumentRepository) ExistsByReference(ctx context.Context, documentType domain.StockDocumentType, referenceNumber string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&domain.StockDocument{}).
+		Where("document_type = ? AND reference_number = ?", documentType, referenceNumber).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *StockDocumentRepository) GetAllPaginated(ctx context.Context, documentType domain.StockDocumentType, warehouseUUID string, page, limit int) ([]domain.StockDocument, error) {
+	var documents []domain.StockDocument
+	offset := (page - 1) * limit
+	query := r.db.WithContext(ctx).
+		Preload("Warehouse").Preload("Supplier").
+		Where("document_type = ?", documentType)
+	if warehouseUUID != "" {
+		query = query.Where("warehouse_uuid = ?", warehouseUUID)
+	}
+	if err := query.
+		Order("document_date DESC, created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *StockDocumentRepository) Count(ctx context.Context, documentType domain.StockDocumentType, warehouseUUID string) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).
+		Model(&domain.StockDocument{}).
+		Where("document_type = ?", documentType)
+	if warehouseUUID != "" {
+		query = query.Where("warehouse_uuid = ?", warehouseUUID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetByID returns the document with its lines in line order
+func (r *StockDocumentRepository) GetByID(ctx context.Context, documentType domain.StockDocumentType, uuid string) (*domain.StockDocument, error) {
+	var document domain.StockDocument
+	if err := r.db.WithContext(ctx).
+		Preload("Warehouse").Preload("Supplier").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_number ASC")
+		}).
+		Preload("Lines.Product").
+		Where("document_type = ? AND uuid = ?", documentType, uuid).
+		First(&document).Error; err != nil {
+		return nil, wrapError(strings.ToLower(string(documentType)), err)
+	}
+	return &document, nil
+}
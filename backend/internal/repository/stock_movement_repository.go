@@ -2,103 +2,135 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/shirloin/stockhub/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type StockMovementRepository struct {
-	db          *gorm.DB
-	subscribers []chan []domain.StockMovement
+	db *gorm.DB
 }
 
 func NewStockMovementRepository(db *gorm.DB) *StockMovementRepository {
-	repo := &StockMovementRepository{db: db, subscribers: make([]chan []domain.StockMovement, 0)}
-	go repo.pollForChanges()
-	return repo
+	return &StockMovementRepository{db: db}
 }
 
 func (r *StockMovementRepository) Create(ctx context.Context, movement *domain.StockMovement) error {
-	// Handle empty ToWarehouseUUID - PostgreSQL doesn't accept empty string for UUID
-	// Use Omit to exclude it from insert if empty
-	if movement.ToWarehouseUUID == "" {
-		return r.db.WithContext(ctx).Omit("to_warehouse_uuid").Create(movement).Error
+	if omits := movementOmits(movement); len(omits) > 0 {
+		return r.db.WithContext(ctx).Omit(omits...).Create(movement).Error
 	}
 	return r.db.WithContext(ctx).Create(movement).Error
 }
 
-func (r *StockMovementRepository) GetAll(ctx context.Context, limit int) ([]domain.StockMovement, error) {
-	var movements []domain.StockMovement
-	query := r.db.WithContext(ctx).
-		Preload("Product").Preload("Warehouse").
-		Order("movement_date DESC, created_at DESC")
+// Post applies the movement to the warehouse's stock and records it, in one transaction.
+// There must be enough stock on hand for movements that take stock away.
+func (r *StockMovementRepository) Post(ctx context.Context, movement *domain.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyMovement(tx, movement, false)
+	})
+}
 
-	if limit > 0 {
-		query = query.Limit(limit)
+// movementOmits lists the optional UUID columns that are empty on the movement.
+// PostgreSQL doesn't accept empty string for UUID, so they are excluded from the insert.
+func movementOmits(movement *domain.StockMovement) []string {
+	var omits []string
+	if movement.ToWarehouseUUID == "" {
+		omits = append(omits, "to_warehouse_uuid")
 	}
-
-	if err := query.Find(&movements).Error; err != nil {
-		return nil, err
+	if movement.DocumentUUID == "" {
+		omits = append(omits, "document_uuid")
+	}
+	return omits
+}
+
+// lockWarehouses locks the given warehouses for the rest of the transaction, in UUID order so
+// that transactions locking the same warehouses cannot deadlock, and returns them by UUID
+func lockWarehouses(tx *gorm.DB, uuids ...string) (map[string]*domain.Warehouse, error) {
+	uuids = slices.Clone(uuids)
+	slices.Sort(uuids)
+	uuids = slices.Compact(uuids)
+
+	warehouses := make(map[string]*domain.Warehouse, len(uuids))
+	for _, uuid := range uuids {
+		var warehouse domain.Warehouse
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uuid = ?", uuid).
+			First(&warehouse).Error; err != nil {
+			return nil, wrapError("warehouse", err)
+		}
+		warehouses[uuid] = &warehouse
+	}
+	return warehouses, nil
+}
+
+// applyMovement applies movement.Quantity to the stock of its product in its warehouse, filling
+// in PreviousQty and NewQty, and inserts the movement. The warehouse row is locked for the rest of
+// the transaction so that capacity and stock levels are checked against a consistent view, and
+// its updated_at is bumped so Watch streams notice. Removing more than is on hand fails with
+// ErrInsufficientStock, or leaves none when clampAtZero is set.
+func applyMovement(tx *gorm.DB, movement *domain.StockMovement, clampAtZero bool) error {
+	var warehouse domain.Warehouse
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ?", movement.WarehouseUUID).
+		First(&warehouse).Error; err != nil {
+		return wrapError("warehouse", err)
 	}
-	return movements, nil
-}
 
-func (r *StockMovementRepository) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.StockMovement, error) {
-	var movements []domain.StockMovement
-	offset := (page - 1) * limit
-	if err := r.db.WithContext(ctx).
-		Preload("Product").Preload("Warehouse").
-		Order("movement_date DESC, created_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&movements).Error; err != nil {
-		return nil, err
+	if movement.Quantity > 0 && warehouse.Capacity > 0 {
+		var total int
+		if err := tx.Model(&domain.WarehouseStock{}).
+			Where("warehouse_uuid = ?", movement.WarehouseUUID).
+			Select("COALESCE(SUM(quantity), 0)").
+			Scan(&total).Error; err != nil {
+			return err
+		}
+		if total+movement.Quantity > warehouse.Capacity {
+			return domain.ErrWarehouseCapacityExceeded
+		}
 	}
-	return movements, nil
-}
 
-func (r *StockMovementRepository) Count(ctx context.Context) (int64, error) {
-	var count int64
-	if err := r.db.WithContext(ctx).
-		Model(&domain.StockMovement{}).
-		Count(&count).Error; err != nil {
-		return 0, err
+	var stock domain.WarehouseStock
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_uuid = ? AND warehouse_uuid = ?", movement.ProductUUID, movement.WarehouseUUID).
+		First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		stock = domain.WarehouseStock{ProductUUID: movement.ProductUUID, WarehouseUUID: movement.WarehouseUUID}
+	} else if err != nil {
+		return err
 	}
-	return count, nil
-}
 
-func (r *StockMovementRepository) GetByTypePaginated(ctx context.Context, movementType domain.StockMovementType, page, limit int) ([]domain.StockMovement, error) {
-	var movements []domain.StockMovement
-	offset := (page - 1) * limit
-	if err := r.db.WithContext(ctx).
-		Preload("Product").Preload("Warehouse").
-		Where("movement_type = ?", movementType).
-		Order("movement_date DESC, created_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&movements).Error; err != nil {
-		return nil, err
+	movement.PreviousQty = stock.Quantity
+	movement.NewQty = stock.Quantity + movement.Quantity
+	if movement.NewQty < 0 {
+		if !clampAtZero {
+			return domain.ErrInsufficientStock
+		}
+		movement.NewQty = 0
 	}
-	return movements, nil
-}
+	stock.Quantity = movement.NewQty
 
-func (r *StockMovementRepository) CountByType(ctx context.Context, movementType domain.StockMovementType) (int64, error) {
-	var count int64
-	if err := r.db.WithContext(ctx).
-		Model(&domain.StockMovement{}).
-		Where("movement_type = ?", movementType).
-		Count(&count).Error; err != nil {
-		return 0, err
+	if stock.UUID == "" {
+		if err := tx.Create(&stock).Error; err != nil {
+			return err
+		}
+	} else if err := tx.Save(&stock).Error; err != nil {
+		return err
 	}
-	return count, nil
+	if err := tx.Omit(movementOmits(movement)...).Create(movement).Error; err != nil {
+		return err
+	}
+	return tx.Model(&domain.Warehouse{}).Where("uuid = ?", movement.WarehouseUUID).UpdateColumn("updated_at", time.Now()).Error
 }
 
-func (r *StockMovementRepository) GetByWarehouse(ctx context.Context, warehouseUUID string, limit int) ([]domain.StockMovement, error) {
+func (r *StockMovementRepository) GetAll(ctx context.Context, limit int) ([]domain.StockMovement, error) {
 	var movements []domain.StockMovement
 	query := r.db.WithContext(ctx).
 		Preload("Product").Preload("Warehouse").
-		Where("warehouse_uuid = ?", warehouseUUID).
 		Order("movement_date DESC, created_at DESC")
 
 	if limit > 0 {
@@ -111,14 +143,17 @@ func (r *StockMovementRepository) GetByWarehouse(ctx context.Context, warehouseU
 	return movements, nil
 }
 
-func (r *StockMovementRepository) GetByProduct(ctx context.Context, productUUID string, limit int) ([]domain.StockMovement, error) {
+// GetFiltered returns movements matching the filter in the requested order, newest first by default.
+// A limit of 0 returns every match.
+func (r *StockMovementRepository) GetFiltered(ctx context.Context, filter domain.StockMovementFilter, sorts []domain.StockMovementSort, page, limit int) ([]domain.StockMovement, error) {
 	var movements []domain.StockMovement
-	query := r.db.WithContext(ctx).
-		Preload("Product").Preload("Warehouse").
-		Where("product_uuid = ?", productUUID).
-		Order("movement_date DESC, created_at DESC")
+	query := filterMovements(r.db.WithContext(ctx).Preload("Product").Preload("Warehouse"), "stock_movements", filter)
+	query = orderMovements(query, sorts)
 
 	if limit > 0 {
+		if page > 1 {
+			query = query.Offset((page - 1) * limit)
+		}
 		query = query.Limit(limit)
 	}
 
@@ -128,58 +163,36 @@ func (r *StockMovementRepository) GetByProduct(ctx context.Context, productUUID
 	return movements, nil
 }
 
-func (r *StockMovementRepository) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]domain.StockMovement, error) {
-	var movements []domain.StockMovement
-	if err := r.db.WithContext(ctx).
-		Preload("Product").Preload("Warehouse").
-		Where("movement_date >= ? AND movement_date <= ?", startDate, endDate).
-		Order("movement_date DESC, created_at DESC").
-		Find(&movements).Error; err != nil {
-		return nil, err
+// CountFiltered counts the movements matching the same conditions GetFiltered applies
+func (r *StockMovementRepository) CountFiltered(ctx context.Context, filter domain.StockMovementFilter) (int64, error) {
+	var count int64
+	if err := filterMovements(r.db.WithContext(ctx).Model(&domain.StockMovement{}), "stock_movements", filter).
+		Count(&count).Error; err != nil {
+		return 0, err
 	}
-	return movements, nil
+	return count, nil
 }
 
-func (r *StockMovementRepository) SubscribeToChanges() <-chan []domain.StockMovement {
-	ch := make(chan []domain.StockMovement, 10)
-	r.subscribers = append(r.subscribers, ch)
-	return ch
+var movementSortColumns = map[domain.StockMovementSortField]string{
+	domain.MovementSortMovementDate: "stock_movements.movement_date",
+	domain.MovementSortCreatedAt:    "stock_movements.created_at",
+	domain.MovementSortQuantity:     "stock_movements.quantity",
 }
 
-func (r *StockMovementRepository) pollForChanges() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	var lastUpdate time.Time
-	for range ticker.C {
-		movements, err := r.getUpdatedMovements(lastUpdate)
-		if err != nil {
-			continue
-		}
-
-		if len(movements) > 0 {
-			allMovements, err := r.GetAll(context.Background(), 0)
-			if err != nil {
-				continue
-			}
-			r.notifySubscribers(allMovements)
-			lastUpdate = time.Now()
-		}
+// orderMovements applies the requested sort, falling back to the ledger order, with uuid as
+// a final tie-breaker so pages never overlap
+func orderMovements(query *gorm.DB, sorts []domain.StockMovementSort) *gorm.DB {
+	if len(sorts) == 0 {
+		return query.Order("stock_movements.movement_date DESC, stock_movements.created_at DESC, stock_movements.uuid DESC")
 	}
-}
-
-func (r *StockMovementRepository) getUpdatedMovements(since time.Time) ([]domain.StockMovement, error) {
-	ctx := context.Background()
-	return r.FindUpdatedSince(ctx, since)
-}
-
-func (r *StockMovementRepository) notifySubscribers(movements []domain.StockMovement) {
-	for _, ch := range r.subscribers {
-		select {
-		case ch <- movements:
-		default:
+	for _, sort := range sorts {
+		column, ok := movementSortColumns[sort.Field]
+		if !ok {
+			continue
 		}
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: column, Raw: true}, Desc: sort.Desc})
 	}
+	return query.Order("stock_movements.uuid DESC")
 }
 
 func (r *StockMovementRepository) FindUpdatedSince(ctx context.Context, since time.Time) ([]domain.StockMovement, error) {
@@ -194,21 +207,115 @@ func (r *StockMovementRepository) FindUpdatedSince(ctx context.Context, since ti
 	return movements, nil
 }
 
-func (r *StockMovementRepository) GetByType(ctx context.Context, movementType domain.StockMovementType, limit int) ([]domain.StockMovement, error) {
-	var movements []domain.StockMovement
+// StreamFiltered calls fn for every movement matching the filter, newest first
+func (r *StockMovementRepository) StreamFiltered(ctx context.Context, filter domain.StockMovementFilter, fn func(*domain.StockMovementExport) error) error {
 	query := r.db.WithContext(ctx).
-		Preload("Product").Preload("Warehouse").
-		Where("movement_type = ?", movementType).
-		Order("movement_date DESC, created_at DESC")
+		Table("stock_movements AS sm").
+		Select("sm.*, p.sku AS product_sku, p.title AS product_title, w.name AS warehouse_name, tw.name AS to_warehouse_name").
+		Joins("LEFT JOIN products p ON p.uuid = sm.product_uuid").
+		Joins("LEFT JOIN warehouses w ON w.uuid = sm.warehouse_uuid").
+		Joins("LEFT JOIN warehouses tw ON tw.uuid = sm.to_warehouse_uuid").
+		Order("sm.movement_date DESC, sm.created_at DESC")
 
-	if limit > 0 {
-		query = query.Limit(limit)
+	return streamRows(filterMovements(query, "sm", filter), fn)
+}
+
+// filterMovements applies the filter to columns of the given stock_movements table or alias
+func filterMovements(query *gorm.DB, table string, filter domain.StockMovementFilter) *gorm.DB {
+	if filter.WarehouseUUID != "" {
+		query = query.Where(table+".warehouse_uuid = ?", filter.WarehouseUUID)
+	}
+	if filter.ProductUUID != "" {
+		query = query.Where(table+".product_uuid = ?", filter.ProductUUID)
+	}
+	if len(filter.MovementTypes) > 0 {
+		query = query.Where(table+".movement_type IN ?", filter.MovementTypes)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where(table+".movement_date >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where(table+".movement_date < ?", filter.EndDate)
+	}
+	if filter.ReferenceNumber != "" {
+		query = query.Where(table+".reference_number = ?", filter.ReferenceNumber)
+	}
+	if filter.AdjustmentReason != "" {
+		query = query.Where(table+".adjustment_reason = ?", filter.AdjustmentReason)
+	}
+	if filter.CreatedBy != "" {
+		query = query.Where(table+".created_by = ?", filter.CreatedBy)
+	}
+	if filter.APIKeyUUID != "" {
+		query = query.Where(table+".api_key_uuid = ?", filter.APIKeyUUID)
 	}
+	switch filter.QuantitySign {
+	case domain.QuantitySignPositive:
+		query = query.Where(table + ".quantity > 0")
+	case domain.QuantitySignNegative:
+		query = query.Where(table + ".quantity < 0")
+	}
+	return query
+}
 
-	if err := query.Find(&movements).Error; err != nil {
-		return nil, err
+// GetPageByCursor returns up to limit movements past the cursor, newest first, using the
+// idx_stock_movements_ledger index instead of OFFSET. A nil cursor starts at the newest movement.
+// The boolean reports whether more movements exist beyond the page in the cursor's direction.
+func (r *StockMovementRepository) GetPageByCursor(ctx context.Context, filter domain.StockMovementFilter, cursor *domain.StockMovementCursor, limit int) ([]domain.StockMovement, bool, error) {
+	var movements []domain.StockMovement
+	query := filterMovements(r.db.WithContext(ctx).Preload("Product").Preload("Warehouse"), "stock_movements", filter)
+
+	backward := cursor != nil && cursor.Backward
+	if cursor != nil {
+		operator := "<"
+		if backward {
+			operator = ">"
+		}
+		query = query.Where("(stock_movements.movement_date, stock_movements.created_at, stock_movements.uuid) "+operator+" (?, ?, ?)",
+			cursor.MovementDate, cursor.CreatedAt, cursor.UUID)
 	}
-	return movements, nil
+	if backward {
+		query = query.Order("stock_movements.movement_date ASC, stock_movements.created_at ASC, stock_movements.uuid ASC")
+	} else {
+		query = query.Order("stock_movements.movement_date DESC, stock_movements.created_at DESC, stock_movements.uuid DESC")
+	}
+
+	// Fetch one extra row to learn whether another page follows
+	if err := query.Limit(limit + 1).Find(&movements).Error; err != nil {
+		return nil, false, err
+	}
+	hasMore := len(movements) > limit
+	if hasMore {
+		movements = movements[:limit]
+	}
+	if backward {
+		slices.Reverse(movements)
+	}
+	return movements, hasMore, nil
+}
+
+// EstimateCount returns the query planner's row estimate for the filter, which stays cheap
+// however large the ledger grows but is only approximate
+func (r *StockMovementRepository) EstimateCount(ctx context.Context, filter domain.StockMovementFilter) (int64, error) {
+	stmt := filterMovements(r.db.WithContext(ctx).Session(&gorm.Session{DryRun: true}).Model(&domain.StockMovement{}), "stock_movements", filter).
+		Find(&[]domain.StockMovement{}).Statement
+
+	var plan string
+	if err := r.db.WithContext(ctx).Raw("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Row().Scan(&plan); err != nil {
+		return 0, err
+	}
+	var explain []struct {
+		Plan struct {
+			PlanRows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &explain); err != nil {
+		return 0, err
+	}
+	if len(explain) == 0 {
+		return 0, nil
+	}
+	return int64(explain[0].Plan.PlanRows), nil
 }
 
 type StockInRepository struct {
@@ -223,6 +330,17 @@ func (r *StockInRepository) Create(ctx context.Context, stockIn *domain.StockIn)
 	return r.db.WithContext(ctx).Create(stockIn).Error
 }
 
+// Post writes the stock in record, adds its quantity to the warehouse's stock and records the
+// movement, in one transaction
+func (r *StockInRepository) Post(ctx context.Context, stockIn *domain.StockIn, movement *domain.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := applyMovement(tx, movement, false); err != nil {
+			return err
+		}
+		return tx.Omit(stockDocumentOmits(stockIn.SupplierUUID)...).Create(stockIn).Error
+	})
+}
+
 func (r *StockInRepository) GetAll(ctx context.Context) ([]domain.StockIn, error) {
 	var stockIns []domain.StockIn
 	if err := r.db.WithContext(ctx).
@@ -258,6 +376,29 @@ func (r *StockInRepository) GetByDateRange(ctx context.Context, startDate, endDa
 	return stockIns, nil
 }
 
+// StreamFiltered calls fn for every stock in record matching the filter, newest first
+func (r *StockInRepository) StreamFiltered(ctx context.Context, filter domain.StockRecordFilter, fn func(*domain.StockInExport) error) error {
+	query := r.db.WithContext(ctx).
+		Table("stock_ins AS si").
+		Select("si.*, p.sku AS product_sku, p.title AS product_title, w.name AS warehouse_name, s.name AS supplier_name").
+		Joins("LEFT JOIN products p ON p.uuid = si.product_uuid").
+		Joins("LEFT JOIN warehouses w ON w.uuid = si.warehouse_uuid").
+		Joins("LEFT JOIN suppliers s ON s.uuid = si.supplier_uuid").
+		Order("si.received_date DESC")
+
+	if filter.WarehouseUUID != "" {
+		query = query.Where("si.warehouse_uuid = ?", filter.WarehouseUUID)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where("si.received_date >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("si.received_date < ?", filter.EndDate)
+	}
+
+	return streamRows(query, fn)
+}
+
 type StockOutRepository struct {
 	db *gorm.DB
 }
@@ -270,6 +411,17 @@ func (r *StockOutRepository) Create(ctx context.Context, stockOut *domain.StockO
 	return r.db.WithContext(ctx).Create(stockOut).Error
 }
 
+// Post writes the stock out record, takes its quantity from the warehouse's stock and records
+// the movement, in one transaction. There must be enough stock on hand.
+func (r *StockOutRepository) Post(ctx context.Context, stockOut *domain.StockOut, movement *domain.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := applyMovement(tx, movement, false); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(stockOut).Error
+	})
+}
+
 func (r *StockOutRepository) GetAll(ctx context.Context) ([]domain.StockOut, error) {
 	var stockOuts []domain.StockOut
 	if err := r.db.WithContext(ctx).
@@ -305,6 +457,28 @@ func (r *StockOutRepository) GetByDateRange(ctx context.Context, startDate, endD
 	return stockOuts, nil
 }
 
+// StreamFiltered calls fn for every stock out record matching the filter, newest first
+func (r *StockOutRepository) StreamFiltered(ctx context.Context, filter domain.StockRecordFilter, fn func(*domain.StockOutExport) error) error {
+	query := r.db.WithContext(ctx).
+		Table("stock_outs AS so").
+		Select("so.*, p.sku AS product_sku, p.title AS product_title, w.name AS warehouse_name").
+		Joins("LEFT JOIN products p ON p.uuid = so.product_uuid").
+		Joins("LEFT JOIN warehouses w ON w.uuid = so.warehouse_uuid").
+		Order("so.shipped_date DESC")
+
+	if filter.WarehouseUUID != "" {
+		query = query.Where("so.warehouse_uuid = ?", filter.WarehouseUUID)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where("so.shipped_date >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("so.shipped_date < ?", filter.EndDate)
+	}
+
+	return streamRows(query, fn)
+}
+
 type StockAdjustmentRepository struct {
 	db *gorm.DB
 }
@@ -317,6 +491,19 @@ func (r *StockAdjustmentRepository) Create(ctx context.Context, adjustment *doma
 	return r.db.WithContext(ctx).Create(adjustment).Error
 }
 
+// Post applies the adjustment to the warehouse's stock, never below zero, records the movement
+// and writes the adjustment with the quantities before and after, in one transaction
+func (r *StockAdjustmentRepository) Post(ctx context.Context, adjustment *domain.StockAdjustment, movement *domain.StockMovement) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := applyMovement(tx, movement, true); err != nil {
+			return err
+		}
+		adjustment.PreviousQty = movement.PreviousQty
+		adjustment.NewQty = movement.NewQty
+		return tx.Omit(clause.Associations).Create(adjustment).Error
+	})
+}
+
 func (r *StockAdjustmentRepository) GetAll(ctx context.Context) ([]domain.StockAdjustment, error) {
 	var adjustments []domain.StockAdjustment
 	if err := r.db.WithContext(ctx).
@@ -351,3 +538,28 @@ func (r *StockAdjustmentRepository) GetByReason(ctx context.Context, reason doma
 	}
 	return adjustments, nil
 }
+
+// StreamFiltered calls fn for every adjustment matching the filter, newest first
+func (r *StockAdjustmentRepository) StreamFiltered(ctx context.Context, filter domain.StockRecordFilter, fn func(*domain.StockAdjustmentExport) error) error {
+	query := r.db.WithContext(ctx).
+		Table("stock_adjustments AS sa").
+		Select("sa.*, p.sku AS product_sku, p.title AS product_title, w.name AS warehouse_name").
+		Joins("LEFT JOIN products p ON p.uuid = sa.product_uuid").
+		Joins("LEFT JOIN warehouses w ON w.uuid = sa.warehouse_uuid").
+		Order("sa.adjustment_date DESC")
+
+	if filter.WarehouseUUID != "" {
+		query = query.Where("sa.warehouse_uuid = ?", filter.WarehouseUUID)
+	}
+	if filter.Reason != "" {
+		query = query.Where("sa.reason = ?", filter.Reason)
+	}
+	if !filter.StartDate.IsZero() {
+		query = query.Where("sa.adjustment_date >= ?", filter.StartDate)
+	}
+	if !filter.EndDate.IsZero() {
+		query = query.Where("sa.adjustment_date < ?", filter.EndDate)
+	}
+
+	return streamRows(query, fn)
+}
@@ -16,7 +16,7 @@ func NewSupplierRepository(db *gorm.DB) *SupplierRepository {
 }
 
 func (r *SupplierRepository) Create(ctx context.Context, supplier *domain.Supplier) error {
-	return r.db.WithContext(ctx).Create(supplier).Error
+	return wrapError("supplier", r.db.WithContext(ctx).Create(supplier).Error)
 }
 
 func (r *SupplierRepository) GetAll(ctx context.Context) ([]domain.Supplier, error) {
@@ -53,15 +53,31 @@ func (r *SupplierRepository) Count(ctx context.Context) (int64, error) {
 func (r *SupplierRepository) GetByID(ctx context.Context, uuid string) (*domain.Supplier, error) {
 	var supplier domain.Supplier
 	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&supplier).Error; err != nil {
-		return nil, err
+		return nil, wrapError("supplier", err)
 	}
 	return &supplier, nil
 }
 
 func (r *SupplierRepository) Update(ctx context.Context, uuid string, supplier *domain.Supplier) error {
-	return r.db.WithContext(ctx).Model(&domain.Supplier{}).Where("uuid = ?", uuid).Updates(supplier).Error
+	return wrapError("supplier", r.db.WithContext(ctx).Model(&domain.Supplier{}).Where("uuid = ?", uuid).Updates(supplier).Error)
 }
 
 func (r *SupplierRepository) Delete(ctx context.Context, uuid string) error {
-	return r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.Supplier{}).Error
+	return wrapError("supplier", r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.Supplier{}).Error)
+}
+
+// UpsertByName creates suppliers whose name does not exist yet and updates the given columns
+// of those that do, all within one transaction. Names are matched case-insensitively.
+func (r *SupplierRepository) UpsertByName(ctx context.Context, suppliers []domain.Supplier, columns [][]string) (int, int, error) {
+	var created, updated int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		created, updated, err = upsertByName(tx, suppliers, func(supplier *domain.Supplier) (string, *string) {
+			return supplier.Name, &supplier.UUID
+		}, columns, nil)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
 }
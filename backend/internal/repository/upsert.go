@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// upsertByName creates the records whose name does not exist yet and updates the given columns,
+// and updated_at, of those that do, filling in their UUIDs. columns[i] lists the columns written
+// for records[i]. Names are matched case-insensitively.
+// identity returns a record's name and a pointer to its UUID; saved, when set, is called with
+// every record's UUID and whether it was created.
+func upsertByName[T any](tx *gorm.DB, records []T, identity func(*T) (string, *string), columns [][]string, saved func(uuid string, created bool)) (int, int, error) {
+	names := make([]string, 0, len(records))
+	for i := range records {
+		name, _ := identity(&records[i])
+		names = append(names, strings.ToLower(name))
+	}
+
+	var existing []struct {
+		UUID string
+		Name string
+	}
+	if err := tx.Model(new(T)).Select("uuid", "name").Where("LOWER(name) IN ?", names).Find(&existing).Error; err != nil {
+		return 0, 0, err
+	}
+	uuidByName := make(map[string]string, len(existing))
+	for _, record := range existing {
+		uuidByName[strings.ToLower(record.Name)] = record.UUID
+	}
+
+	var created, updated int
+	for i := range records {
+		record := &records[i]
+		name, uuid := identity(record)
+		existingUUID, exists := uuidByName[strings.ToLower(name)]
+		if exists {
+			selected := append(columns[i][:len(columns[i]):len(columns[i])], "updated_at")
+			if err := tx.Model(new(T)).Where("uuid = ?", existingUUID).Select(selected).Updates(record).Error; err != nil {
+				return 0, 0, err
+			}
+			*uuid = existingUUID
+			updated++
+		} else {
+			if err := tx.Create(record).Error; err != nil {
+				return 0, 0, err
+			}
+			created++
+		}
+		if saved != nil {
+			saved(*uuid, !exists)
+		}
+	}
+	return created, updated, nil
+}
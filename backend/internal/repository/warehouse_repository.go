@@ -2,35 +2,31 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/shirloin/stockhub/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WarehouseRepository struct {
-	db          *gorm.DB
-	subscribers []chan []domain.Warehouse
+	db *gorm.DB
 }
 
 func NewWarehouseRepository(db *gorm.DB) *WarehouseRepository {
-	repo := &WarehouseRepository{db: db, subscribers: make([]chan []domain.Warehouse, 0)}
-	go repo.pollForChanges()
-	return repo
+	return &WarehouseRepository{db: db}
 }
 
+// Create stores the warehouse and its warehouse.created event in one transaction
 func (r *WarehouseRepository) Create(ctx context.Context, warehouse *domain.Warehouse) error {
-	if err := r.db.WithContext(ctx).Create(warehouse).Error; err != nil {
-		return err
-	}
-	// Trigger immediate notification for new warehouse
-	go func() {
-		allWarehouses, err := r.GetAll(context.Background())
-		if err == nil {
-			r.notifySubscribers(allWarehouses)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(warehouse).Error; err != nil {
+			return err
 		}
-	}()
-	return nil
+		return domain.EnqueueEvents(tx, domain.NewEvent(domain.EventWarehouseCreated, warehouse))
+	})
+	return wrapError("warehouse", err)
 }
 
 func (r *WarehouseRepository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
@@ -41,6 +37,19 @@ func (r *WarehouseRepository) GetAll(ctx context.Context) ([]domain.Warehouse, e
 	return warehouses, nil
 }
 
+// GetStocking returns the active warehouses holding a stock record of the product
+func (r *WarehouseRepository) GetStocking(ctx context.Context, productUUID string) ([]domain.Warehouse, error) {
+	var warehouses []domain.Warehouse
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Where("uuid IN (?)", r.db.WithContext(ctx).Model(&domain.WarehouseStock{}).Select("warehouse_uuid").Where("product_uuid = ?", productUUID)).
+		Order("name ASC").
+		Find(&warehouses).Error; err != nil {
+		return nil, err
+	}
+	return warehouses, nil
+}
+
 // GetAllPaginated returns paginated warehouses
 func (r *WarehouseRepository) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.Warehouse, error) {
 	var warehouses []domain.Warehouse
@@ -66,30 +75,12 @@ func (r *WarehouseRepository) GetAllWithMetrics(ctx context.Context, limit int)
 
 	var results []Result
 
-	// Build SQL query with utilization calculation and sorting in database
-	query := `
-		SELECT
-			w.*,
-			COALESCE(SUM(ws.quantity), 0) as total_stock,
-			CASE
-				WHEN w.capacity > 0 THEN
-					LEAST((COALESCE(SUM(ws.quantity), 0)::float / w.capacity::float) * 100, 100)
-				ELSE 0
-			END as utilization
-		FROM warehouses w
-		LEFT JOIN warehouse_stocks ws ON w.uuid = ws.warehouse_uuid
-		WHERE w.is_active = ?
-		GROUP BY w.uuid
-		ORDER BY utilization DESC
-	`
-
-	args := []interface{}{true}
+	// Utilization is calculated and sorted on in the database
+	query := r.withMetrics(ctx)
 	if limit > 0 {
-		query += " LIMIT ?"
-		args = append(args, limit)
+		query = query.Limit(limit)
 	}
-
-	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error; err != nil {
+	if err := query.Scan(&results).Error; err != nil {
 		return nil, err
 	}
 
@@ -105,6 +96,24 @@ func (r *WarehouseRepository) GetAllWithMetrics(ctx context.Context, limit int)
 	return warehousesWithMetrics, nil
 }
 
+// withMetrics selects the active warehouses with their total stock and utilization, most
+// utilized first
+func (r *WarehouseRepository) withMetrics(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("warehouses AS w").
+		Select(`w.*,
+			COALESCE(SUM(ws.quantity), 0) as total_stock,
+			CASE
+				WHEN w.capacity > 0 THEN
+					LEAST((COALESCE(SUM(ws.quantity), 0)::float / w.capacity::float) * 100, 100)
+				ELSE 0
+			END as utilization`).
+		Joins("LEFT JOIN warehouse_stocks ws ON w.uuid = ws.warehouse_uuid").
+		Where("w.is_active = ?", true).
+		Group("w.uuid").
+		Order("utilization DESC")
+}
+
 // GetAllWithMetricsPaginated returns paginated warehouses with utilization metrics
 func (r *WarehouseRepository) GetAllWithMetricsPaginated(ctx context.Context, page, limit int) ([]domain.WarehouseWithMetrics, int64, error) {
 	type Result struct {
@@ -117,36 +126,13 @@ func (r *WarehouseRepository) GetAllWithMetricsPaginated(ctx context.Context, pa
 	var total int64
 
 	// Count total warehouses
-	countQuery := `
-		SELECT COUNT(DISTINCT w.uuid)
-		FROM warehouses w
-		WHERE w.is_active = ?
-	`
-	if err := r.db.WithContext(ctx).Raw(countQuery, true).Scan(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&domain.Warehouse{}).Where("is_active = ?", true).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Build SQL query with utilization calculation and sorting in database
+	// Utilization is calculated and sorted on in the database
 	offset := (page - 1) * limit
-	query := `
-		SELECT
-			w.*,
-			COALESCE(SUM(ws.quantity), 0) as total_stock,
-			CASE
-				WHEN w.capacity > 0 THEN
-					LEAST((COALESCE(SUM(ws.quantity), 0)::float / w.capacity::float) * 100, 100)
-				ELSE 0
-			END as utilization
-		FROM warehouses w
-		LEFT JOIN warehouse_stocks ws ON w.uuid = ws.warehouse_uuid
-		WHERE w.is_active = ?
-		GROUP BY w.uuid
-		ORDER BY utilization DESC
-		LIMIT ? OFFSET ?
-	`
-
-	args := []interface{}{true, limit, offset}
-	if err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error; err != nil {
+	if err := r.withMetrics(ctx).Limit(limit).Offset(offset).Scan(&results).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -165,59 +151,77 @@ func (r *WarehouseRepository) GetAllWithMetricsPaginated(ctx context.Context, pa
 func (r *WarehouseRepository) GetByID(ctx context.Context, uuid string) (*domain.Warehouse, error) {
 	var warehouse domain.Warehouse
 	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&warehouse).Error; err != nil {
-		return nil, err
+		return nil, wrapError("warehouse", err)
 	}
 	return &warehouse, nil
 }
 
+// Update stores the changes and a warehouse.updated event describing the stored warehouse in one transaction
 func (r *WarehouseRepository) Update(ctx context.Context, uuid string, warehouse *domain.Warehouse) error {
-	return r.db.WithContext(ctx).Model(&domain.Warehouse{}).Where("uuid = ?", uuid).Updates(warehouse).Error
+	return wrapError("warehouse", r.change(ctx, uuid, domain.EventWarehouseUpdated, func(tx *gorm.DB) error {
+		return tx.Model(&domain.Warehouse{}).Where("uuid = ?", uuid).Updates(warehouse).Error
+	}))
 }
 
+// Delete deactivates the warehouse and queues warehouse.deleted in one transaction
 func (r *WarehouseRepository) Delete(ctx context.Context, uuid string) error {
-	return r.db.WithContext(ctx).Model(&domain.Warehouse{}).Where("uuid = ?", uuid).Update("is_active", false).Error
+	return wrapError("warehouse", r.change(ctx, uuid, domain.EventWarehouseDeleted, func(tx *gorm.DB) error {
+		return tx.Model(&domain.Warehouse{}).Where("uuid = ?", uuid).Update("is_active", false).Error
+	}))
 }
 
-func (r *WarehouseRepository) SubscribeToChanges() <-chan []domain.Warehouse {
-	ch := make(chan []domain.Warehouse, 10)
-	r.subscribers = append(r.subscribers, ch)
-	return ch
+// change applies update to the warehouse within a transaction that also queues an event of
+// eventType with the stored warehouse
+func (r *WarehouseRepository) change(ctx context.Context, uuid string, eventType domain.EventType, update func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := update(tx); err != nil {
+			return err
+		}
+		var warehouse domain.Warehouse
+		if err := tx.Where("uuid = ?", uuid).First(&warehouse).Error; err != nil {
+			return err
+		}
+		return domain.EnqueueEvents(tx, domain.NewEvent(eventType, &warehouse))
+	})
 }
 
-func (r *WarehouseRepository) pollForChanges() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	var lastUpdate time.Time
-	for range ticker.C {
-		warehouses, err := r.getUpdatedWarehouses(lastUpdate)
+// UpsertByName creates warehouses whose name does not exist yet and updates the given columns
+// of those that do, all within one transaction together with their events. Names are matched
+// case-insensitively.
+func (r *WarehouseRepository) UpsertByName(ctx context.Context, warehouses []domain.Warehouse, columns [][]string) (int, int, error) {
+	var created, updated int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		eventTypes := make(map[string]domain.EventType, len(warehouses))
+		created, updated, err = upsertByName(tx, warehouses, func(warehouse *domain.Warehouse) (string, *string) {
+			return warehouse.Name, &warehouse.UUID
+		}, columns, func(uuid string, created bool) {
+			eventTypes[uuid] = domain.EventWarehouseUpdated
+			if created {
+				eventTypes[uuid] = domain.EventWarehouseCreated
+			}
+		})
 		if err != nil {
-			continue
+			return err
 		}
 
-		if len(warehouses) > 0 {
-			allWarehouses, err := r.GetAll(context.Background())
-			if err != nil {
-				continue
-			}
-			r.notifySubscribers(allWarehouses)
-			lastUpdate = time.Now()
+		uuids := make([]string, 0, len(eventTypes))
+		for uuid := range eventTypes {
+			uuids = append(uuids, uuid)
 		}
-	}
-}
-
-func (r *WarehouseRepository) getUpdatedWarehouses(since time.Time) ([]domain.Warehouse, error) {
-	ctx := context.Background()
-	return r.FindUpdatedSince(ctx, since)
-}
-
-func (r *WarehouseRepository) notifySubscribers(warehouses []domain.Warehouse) {
-	for _, ch := range r.subscribers {
-		select {
-		case ch <- warehouses:
-		default:
+		var stored []domain.Warehouse
+		if err := tx.Where("uuid IN ?", uuids).Order("created_at").Find(&stored).Error; err != nil {
+			return err
+		}
+		events := make([]domain.Event, 0, len(stored))
+		for i := range stored {
+			events = append(events, domain.NewEvent(eventTypes[stored[i].UUID], &stored[i]))
 		}
+		return domain.EnqueueEvents(tx, events...)
+	})
+	if err != nil {
+		return 0, 0, err
 	}
+	return created, updated, nil
 }
 
 func (r *WarehouseRepository) FindUpdatedSince(ctx context.Context, since time.Time) ([]domain.Warehouse, error) {
@@ -256,7 +260,7 @@ func (r *WarehouseStockRepository) CreateOrUpdate(ctx context.Context, stock *do
 		Where("product_uuid = ? AND warehouse_uuid = ?", stock.ProductUUID, stock.WarehouseUUID).
 		First(&existing).Error
 
-	if err == gorm.ErrRecordNotFound {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return r.db.WithContext(ctx).Create(stock).Error
 	} else if err != nil {
 		return err
@@ -272,7 +276,7 @@ func (r *WarehouseStockRepository) GetByProductAndWarehouse(ctx context.Context,
 		Preload("Product").Preload("Warehouse").
 		Where("product_uuid = ? AND warehouse_uuid = ?", productUUID, warehouseUUID).
 		First(&stock).Error
-	if err == gorm.ErrRecordNotFound {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	if err != nil {
@@ -292,6 +296,19 @@ func (r *WarehouseStockRepository) GetByWarehouse(ctx context.Context, warehouse
 	return stocks, nil
 }
 
+// StreamByWarehouse calls fn for every product stocked in the warehouse, ordered by SKU
+func (r *WarehouseStockRepository) StreamByWarehouse(ctx context.Context, warehouseUUID string, fn func(*domain.WarehouseStockExport) error) error {
+	query := r.db.WithContext(ctx).
+		Table("warehouse_stocks AS ws").
+		Select("ws.*, p.sku AS product_sku, p.title AS product_title, w.name AS warehouse_name").
+		Joins("LEFT JOIN products p ON p.uuid = ws.product_uuid").
+		Joins("LEFT JOIN warehouses w ON w.uuid = ws.warehouse_uuid").
+		Where("ws.warehouse_uuid = ?", warehouseUUID).
+		Order("p.sku ASC")
+
+	return streamRows(query, fn)
+}
+
 func (r *WarehouseStockRepository) GetByProduct(ctx context.Context, productUUID string) ([]domain.WarehouseStock, error) {
 	var stocks []domain.WarehouseStock
 	if err := r.db.WithContext(ctx).
@@ -327,52 +344,147 @@ func (r *WarehouseStockRepository) GetTotalStockByWarehouse(ctx context.Context,
 	return total, nil
 }
 
-func (r *WarehouseStockRepository) Transfer(ctx context.Context, transfer *domain.StockTransfer) error {
+// Transfer moves stock between the transfer's warehouses, creating the transfer record and the
+// movements out of the source and into the destination, and queues transfer.completed after
+// their movement.created events, in one transaction. Both warehouses are locked, so the
+// quantities recorded on the movements are those of the rows changed, and the destination's
+// capacity is checked.
+func (r *WarehouseStockRepository) Transfer(ctx context.Context, transfer *domain.StockTransfer, from *domain.StockMovement, to *domain.StockMovement) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Create transfer record
-		if err := tx.Create(transfer).Error; err != nil {
+		if _, err := lockWarehouses(tx, transfer.FromWarehouseUUID, transfer.ToWarehouseUUID); err != nil {
 			return err
 		}
 
-		// Decrease stock from source warehouse
-		var fromStock domain.WarehouseStock
-		if err := tx.Where("product_uuid = ? AND warehouse_uuid = ?", transfer.ProductUUID, transfer.FromWarehouseUUID).
-			First(&fromStock).Error; err != nil {
-			return err
+		if err := tx.Create(transfer).Error; err != nil {
+			return wrapError("stock transfer", err)
 		}
 
-		if fromStock.Quantity < transfer.Quantity {
-			return domain.ErrInsufficientStock
+		// The source leg fails on insufficient stock, the destination leg on capacity
+		for _, movement := range []*domain.StockMovement{from, to} {
+			// The transfer's UUID is only known once its record exists
+			movement.ReferenceNumber = transfer.UUID
+			if err := applyMovement(tx, movement, false); err != nil {
+				return err
+			}
 		}
+		return domain.EnqueueEvents(tx, domain.NewEvent(domain.EventTransferCompleted, transfer))
+	})
+}
 
-		fromStock.Quantity -= transfer.Quantity
-		if err := tx.Save(&fromStock).Error; err != nil {
-			return err
-		}
+// SetOpeningBalances sets the on-hand quantity of each product in each warehouse and records
+// the difference as an OPENING_BALANCE adjustment movement, all within one transaction.
+// Capacity of every affected warehouse is checked against the resulting totals.
+func (r *WarehouseStockRepository) SetOpeningBalances(ctx context.Context, balances []domain.OpeningBalance, createdBy string) (int, int, error) {
+	var created, updated int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		created, updated, err = setOpeningBalances(tx, balances, createdBy, nil)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return created, updated, nil
+}
+
+// PreviewOpeningBalances fails as SetOpeningBalances would, capacity checks included, without
+// changing anything. pending holds the stock that earlier batches of the same dry run would have
+// added to each warehouse, counted against its capacity; if the batch would succeed, the stock it
+// adds is added to pending, so each batch is previewed once.
+func (r *WarehouseStockRepository) PreviewOpeningBalances(ctx context.Context, balances []domain.OpeningBalance, pending map[string]int) error {
+	return dryRun(r.db.WithContext(ctx), func(tx *gorm.DB) error {
+		_, _, err := setOpeningBalances(tx, balances, "", pending)
+		return err
+	})
+}
 
-		// Increase stock in destination warehouse
-		var toStock domain.WarehouseStock
-		err := tx.Where("product_uuid = ? AND warehouse_uuid = ?", transfer.ProductUUID, transfer.ToWarehouseUUID).
-			First(&toStock).Error
+// setOpeningBalances writes the balances and checks the capacity of their warehouses. pending,
+// when set, holds stock not yet written that is counted against capacity; the stock the balances
+// add is added to it once every check has passed.
+func setOpeningBalances(tx *gorm.DB, balances []domain.OpeningBalance, createdBy string, pending map[string]int) (int, int, error) {
+	var created, updated int
+	now := time.Now()
+	added := make(map[string]int)
+
+	warehouseUUIDs := make([]string, 0, len(balances))
+	for _, balance := range balances {
+		warehouseUUIDs = append(warehouseUUIDs, balance.WarehouseUUID)
+	}
+	warehouses, err := lockWarehouses(tx, warehouseUUIDs...)
+	if err != nil {
+		return 0, 0, err
+	}
 
-		if err == gorm.ErrRecordNotFound {
-			toStock = domain.WarehouseStock{
-				ProductUUID:   transfer.ProductUUID,
-				WarehouseUUID: transfer.ToWarehouseUUID,
-				Quantity:      transfer.Quantity,
+	for _, balance := range balances {
+		var stock domain.WarehouseStock
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_uuid = ? AND warehouse_uuid = ?", balance.ProductUUID, balance.WarehouseUUID).
+			First(&stock).Error
+
+		previousQty := 0
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			stock = domain.WarehouseStock{
+				ProductUUID:   balance.ProductUUID,
+				WarehouseUUID: balance.WarehouseUUID,
+				Quantity:      balance.Quantity,
 			}
-			if err := tx.Create(&toStock).Error; err != nil {
-				return err
+			if err := tx.Create(&stock).Error; err != nil {
+				return 0, 0, err
 			}
+			created++
 		} else if err != nil {
-			return err
+			return 0, 0, err
 		} else {
-			toStock.Quantity += transfer.Quantity
-			if err := tx.Save(&toStock).Error; err != nil {
-				return err
+			previousQty = stock.Quantity
+			stock.Quantity = balance.Quantity
+			if err := tx.Save(&stock).Error; err != nil {
+				return 0, 0, err
 			}
+			updated++
 		}
 
-		return nil
-	})
+		if balance.Quantity == previousQty {
+			continue
+		}
+		added[balance.WarehouseUUID] += balance.Quantity - previousQty
+		movement := &domain.StockMovement{
+			ProductUUID:      balance.ProductUUID,
+			WarehouseUUID:    balance.WarehouseUUID,
+			MovementType:     domain.MovementTypeAdjustment,
+			Quantity:         balance.Quantity - previousQty,
+			PreviousQty:      previousQty,
+			NewQty:           balance.Quantity,
+			AdjustmentReason: domain.AdjustmentReasonOpeningBalance,
+			Notes:            "Opening balance import",
+			CreatedBy:        createdBy,
+			MovementDate:     now,
+		}
+		if err := tx.Omit(movementOmits(movement)...).Create(movement).Error; err != nil {
+			return 0, 0, err
+		}
+	}
+
+	for warehouseUUID, warehouse := range warehouses {
+		if warehouse.Capacity > 0 {
+			var total int
+			if err := tx.Model(&domain.WarehouseStock{}).
+				Where("warehouse_uuid = ?", warehouseUUID).
+				Select("COALESCE(SUM(quantity), 0)").
+				Scan(&total).Error; err != nil {
+				return 0, 0, err
+			}
+			if total+pending[warehouseUUID] > warehouse.Capacity {
+				return 0, 0, domain.ErrWarehouseCapacityExceeded
+			}
+		}
+		// Update warehouse's updated_at timestamp to trigger real-time updates
+		if err := tx.Model(&domain.Warehouse{}).Where("uuid = ?", warehouseUUID).Update("updated_at", now).Error; err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if pending != nil {
+		for warehouseUUID, quantity := range added {
+			pending[warehouseUUID] += quantity
+		}
+	}
+	return created, updated, nil
 }
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	return wrapError("webhook", r.db.WithContext(ctx).Create(webhook).Error)
+}
+
+func (r *WebhookRepository) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, uuid string) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := r.db.WithContext(ctx).Where("uuid = ?", uuid).First(&webhook).Error; err != nil {
+		return nil, wrapError("webhook", err)
+	}
+	return &webhook, nil
+}
+
+// GetEnabled returns the webhooks events are delivered to
+func (r *WebhookRepository) GetEnabled(ctx context.Context) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	if err := r.db.WithContext(ctx).Where("enabled = ?", true).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Update saves every field of the webhook, so zero values such as enabled=false are persisted
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	return wrapError("webhook", r.db.WithContext(ctx).Select("*").Omit("created_at").Save(webhook).Error)
+}
+
+// Delete removes the webhook; its deliveries go with it
+func (r *WebhookRepository) Delete(ctx context.Context, uuid string) error {
+	result := r.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&domain.Webhook{})
+	if result.Error != nil {
+		return wrapError("webhook", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	// An event dispatched again finds its first deliveries already queued; redeliveries never conflict
+	return wrapError("webhook delivery", r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "webhook_uuid"}, {Name: "event_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "redelivery_of IS NULL"}}},
+		DoNothing:   true,
+	}).Create(&deliveries).Error)
+}
+
+// GetDeliveries returns a page of the webhook's deliveries, newest first, and how many it has
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, webhookUUID string, page, limit int) ([]domain.WebhookDelivery, int64, error) {
+	var deliveries []domain.WebhookDelivery
+	var total int64
+	query := r.db.WithContext(ctx).Model(&domain.WebhookDelivery{}).Where("webhook_uuid = ?", webhookUUID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, webhookUUID string, uuid string) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := r.db.WithContext(ctx).
+		Where("uuid = ? AND webhook_uuid = ?", uuid, webhookUUID).
+		First(&delivery).Error; err != nil {
+		return nil, wrapError("webhook delivery", err)
+	}
+	return &delivery, nil
+}
+
+// ClaimDueDeliveries returns up to limit pending deliveries of enabled webhooks whose next attempt
+// is due, pushing that attempt back by lease so other replicas skip them while they are sent.
+// A delivery whose sender dies is picked up again once the lease runs out.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+			Where("webhook_uuid IN (?)", tx.Model(&domain.Webhook{}).Select("uuid").Where("enabled = ?", true)).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+		uuids := make([]string, len(deliveries))
+		for i := range deliveries {
+			uuids[i] = deliveries[i].UUID
+		}
+		return tx.Model(&domain.WebhookDelivery{}).
+			Where("uuid IN ?", uuids).
+			UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// SaveDelivery records the outcome of an attempt
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Model(delivery).
+		Select("status", "attempts", "response_status", "response_body", "error", "next_attempt_at", "delivered_at").
+		Updates(delivery).Error
+}
+
+// RecordSuccess clears the webhook's run of failures
+func (r *WebhookRepository) RecordSuccess(ctx context.Context, uuid string) error {
+	return r.db.WithContext(ctx).Model(&domain.Webhook{}).
+		Where("uuid = ? AND consecutive_failures > 0", uuid).
+		UpdateColumn("consecutive_failures", 0).Error
+}
+
+// RecordFailure counts a failed attempt against the webhook and disables it once disableAfter
+// attempts in a row have failed; 0 never disables it. It reports whether this failure did.
+func (r *WebhookRepository) RecordFailure(ctx context.Context, uuid string, disableAfter int, now time.Time) (bool, error) {
+	var disabled bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Webhook{}).
+			Where("uuid = ?", uuid).
+			UpdateColumn("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error; err != nil {
+			return err
+		}
+		if disableAfter <= 0 {
+			return nil
+		}
+		result := tx.Model(&domain.Webhook{}).
+			Where("uuid = ? AND enabled = ? AND consecutive_failures >= ?", uuid, true, disableAfter).
+			Updates(map[string]any{"enabled": false, "disabled_at": now})
+		disabled = result.RowsAffected > 0
+		return result.Error
+	})
+	return disabled, err
+}
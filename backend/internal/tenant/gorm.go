@@ -0,0 +1,145 @@
+// Package tenant keeps the records of each organisation apart. Its GORM plugin limits every
+// statement on an owned table to the organisation in the statement's context, so repositories
+// never filter by organisation themselves.
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// column holds the owning organisation in every owned table
+const column = "organization_uuid"
+
+// scopedKey marks a statement already limited to its organisation, as GORM reuses statements
+// between chained calls such as Count and Find
+const scopedKey = "tenant:scoped"
+
+// ErrNoOrganization fails statements on owned tables made with a context that names neither an
+// organisation nor all of them; it means a caller lost the request's context
+var ErrNoOrganization = errors.New("tenant: statement on an owned table without an organisation in its context")
+
+// GormPlugin scopes statements on the tables of domain.OwnedModels. Creates fill in the
+// organisation; queries, updates and deletes only match its rows, including those made through
+// aliased tables and subqueries. Raw SQL is left alone, so repositories don't use it on owned tables.
+type GormPlugin struct {
+	tables map[string]bool
+}
+
+func (*GormPlugin) Name() string {
+	return "tenant"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	p.tables = make(map[string]bool, len(domain.OwnedModels))
+	cache := &sync.Map{}
+	for _, model := range domain.OwnedModels {
+		s, err := schema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			return err
+		}
+		if s.LookUpField(column) == nil {
+			return fmt.Errorf("tenant: %s has no %s column", s.Table, column)
+		}
+		p.tables[s.Table] = true
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tenant:create", p.assign),
+		callbacks.Query().Before("gorm:query").Register("tenant:query", p.scope),
+		callbacks.Update().Before("gorm:update").Register("tenant:update", p.scope),
+		callbacks.Delete().Before("gorm:delete").Register("tenant:delete", p.scope),
+		callbacks.Row().Before("gorm:row").Register("tenant:row", p.scope),
+	)
+}
+
+// owned reports whether the statement is on an owned table, also when it is aliased as in
+// Table("stock_movements AS sm")
+func (p *GormPlugin) owned(stmt *gorm.Statement) bool {
+	table := stmt.Table
+	if stmt.TableExpr != nil {
+		if fields := strings.Fields(stmt.TableExpr.SQL); len(fields) > 0 {
+			table = strings.Trim(fields[0], `"`)
+		}
+	}
+	return p.tables[table]
+}
+
+// assign sets the organisation of created records to the context's. With every organisation
+// in scope, as in the admin API, records must name theirs.
+func (p *GormPlugin) assign(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.Schema == nil || !p.owned(stmt) {
+		return
+	}
+	field := stmt.Schema.LookUpField(column)
+	if field == nil {
+		return
+	}
+	organization, ok := domain.OrganizationFromContext(stmt.Context)
+	if !ok && !domain.AllOrganizations(stmt.Context) {
+		tx.AddError(ErrNoOrganization)
+		return
+	}
+
+	set := func(record reflect.Value) {
+		if ok {
+			tx.AddError(field.Set(stmt.Context, record, organization))
+		} else if _, zero := field.ValueOf(stmt.Context, record); zero {
+			tx.AddError(fmt.Errorf("tenant: %s record created without an organisation", stmt.Table))
+		}
+	}
+	switch value := stmt.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			set(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		set(value)
+	}
+}
+
+// scope limits the statement to the rows of the context's organisation
+func (p *GormPlugin) scope(tx *gorm.DB) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.SQL.Len() > 0 || !p.owned(stmt) {
+		return
+	}
+	if _, ok := stmt.Clauses[scopedKey]; ok {
+		return
+	}
+	organization, ok := domain.OrganizationFromContext(stmt.Context)
+	if !ok {
+		if !domain.AllOrganizations(stmt.Context) {
+			tx.AddError(ErrNoOrganization)
+		}
+		return
+	}
+
+	// A lone Or condition would otherwise swallow the organisation: a OR b AND organisation
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 1 {
+			for _, expr := range where.Exprs {
+				if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					stmt.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: organization},
+	}})
+	stmt.Clauses[scopedKey] = clause.Clause{}
+}
@@ -0,0 +1,100 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const organization = "6f1c0a52-3a4e-4c1e-9f0b-2d8e5b7c9a10"
+
+// dryRun opens a database that builds statements without connecting anywhere
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(&GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestScopesQueries(t *testing.T) {
+	db := dryRun(t)
+	ctx := domain.WithOrganization(context.Background(), organization)
+
+	var products []domain.Product
+	stmt := db.WithContext(ctx).Where("sku = ?", "A-1").Or("barcode = ?", "123").Find(&products).Statement
+	sql := stmt.SQL.String()
+	if !strings.Contains(sql, `"products"."organization_uuid" = $3`) || stmt.Vars[2] != organization {
+		t.Fatalf("query not scoped to the organisation: %s %v", sql, stmt.Vars)
+	}
+	if !strings.Contains(sql, "(sku = $1 OR barcode = $2) AND") {
+		t.Fatalf("Or condition not grouped before the organisation: %s", sql)
+	}
+
+	stmt = db.WithContext(ctx).Table("stock_movements AS sm").Select("sm.uuid").Scan(&[]string{}).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, `"sm"."organization_uuid"`) {
+		t.Fatalf("aliased table not scoped: %s", sql)
+	}
+
+	var organizations []domain.Organization
+	stmt = db.WithContext(ctx).Find(&organizations).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "organization_uuid") {
+		t.Fatalf("organizations table scoped: %s", sql)
+	}
+}
+
+func TestAllOrganizationsIsUnscoped(t *testing.T) {
+	db := dryRun(t)
+
+	var products []domain.Product
+	stmt := db.WithContext(domain.WithAllOrganizations(context.Background())).Find(&products).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "organization_uuid") {
+		t.Fatalf("query with every organisation in scope was scoped: %s", sql)
+	}
+}
+
+func TestRequiresAnOrganization(t *testing.T) {
+	db := dryRun(t)
+
+	var products []domain.Product
+	if err := db.WithContext(context.Background()).Find(&products).Error; !errors.Is(err, ErrNoOrganization) {
+		t.Fatalf("query without an organisation: got %v, want ErrNoOrganization", err)
+	}
+	if err := db.WithContext(context.Background()).Create(&domain.Category{Name: "Tools"}).Error; !errors.Is(err, ErrNoOrganization) {
+		t.Fatalf("create without an organisation: got %v, want ErrNoOrganization", err)
+	}
+}
+
+func TestAssignsOrganizationOnCreate(t *testing.T) {
+	db := dryRun(t)
+
+	categories := []domain.Category{{Name: "Tools"}, {Name: "Paint", Owned: domain.Owned{OrganizationUUID: domain.DefaultOrganizationUUID}}}
+	if err := db.WithContext(domain.WithOrganization(context.Background(), organization)).Create(&categories).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, category := range categories {
+		if category.OrganizationUUID != organization {
+			t.Errorf("category %s created in %q, want %q", category.Name, category.OrganizationUUID, organization)
+		}
+	}
+
+	err := db.WithContext(domain.WithAllOrganizations(context.Background())).Create(&domain.Category{Name: "Tools"}).Error
+	if err == nil {
+		t.Fatal("create with every organisation in scope and none named succeeded")
+	}
+}
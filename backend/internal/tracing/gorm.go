@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	spanKey          = "tracing:span"
+	parentContextKey = "tracing:parent_context"
+)
+
+// GormPlugin records a client span for GORM statements whose context (db.WithContext) holds a
+// span, as its child, carrying the table, operation and SQL text
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startSpan("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endSpan("create")),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startSpan("select")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endSpan("select")),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startSpan("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endSpan("update")),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan("delete")),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startSpan("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endSpan("row")),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan("raw")),
+	)
+}
+
+func startSpan(operation string) func(tx *gorm.DB) {
+	return func(tx *gorm.DB) {
+		parent := tx.Statement.Context
+		// Only statements made on behalf of a traced request are recorded, so the change
+		// pollers do not start a new trace on every tick
+		if parent == nil || !trace.SpanContextFromContext(parent).IsValid() {
+			return
+		}
+		ctx, span := Tracer().Start(parent, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system.name", "postgresql"),
+				attribute.String("db.operation.name", operation),
+			),
+		)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(spanKey, span)
+		tx.InstanceSet(parentContextKey, parent)
+	}
+}
+
+func endSpan(operation string) func(tx *gorm.DB) {
+	return func(tx *gorm.DB) {
+		finishSpan(tx, operation)
+	}
+}
+
+func finishSpan(tx *gorm.DB, operation string) {
+	value, ok := tx.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+	// Later statements on the same session belong to the caller's span, not this one
+	if parent, ok := tx.InstanceGet(parentContextKey); ok {
+		tx.Statement.Context = parent.(context.Context)
+	}
+
+	if tx.Statement.Table != "" {
+		span.SetName("gorm." + operation + " " + tx.Statement.Table)
+		span.SetAttributes(attribute.String("db.collection.name", tx.Statement.Table))
+	}
+	span.SetAttributes(
+		attribute.String("db.query.text", tx.Statement.SQL.String()),
+		attribute.Int64("db.response.returned_rows", tx.RowsAffected),
+	)
+	// Not found and constraint violations are answers the use cases expect, not failures
+	if err := tx.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) &&
+		!errors.Is(err, gorm.ErrDuplicatedKey) && !errors.Is(err, gorm.ErrForeignKeyViolated) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
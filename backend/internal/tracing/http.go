@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPMiddleware starts a server span for every request, continuing the trace from an
+// incoming traceparent header. Spans are named after the method and path until
+// RouteMiddleware renames them after the matched route.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "HTTP",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}),
+	)
+}
+
+// RouteMiddleware names the request span after the route template, so /api/products/{uuid}
+// is one operation however many products there are
+func RouteMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				span := trace.SpanFromContext(r.Context())
+				span.SetName(r.Method + " " + template)
+				span.SetAttributes(attribute.String("http.route", template))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestHTTPMiddlewareNamesSpansAfterRoutes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewTracerProvider(exporter, resource.Empty())
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	router := mux.NewRouter()
+	router.Use(RouteMiddleware)
+	router.HandleFunc("/api/products/{uuid}", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Tracer().Start(r.Context(), "ProductUseCase.GetById")
+		span.End()
+	}).Methods("GET")
+
+	request := httptest.NewRequest("GET", "/api/products/42", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	HTTPMiddleware(router).ServeHTTP(httptest.NewRecorder(), request)
+	if err := provider.ForceFlush(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	child, server := spans[0], spans[1]
+	if server.Name != "GET /api/products/{uuid}" {
+		t.Errorf("server span is named %q", server.Name)
+	}
+	if got := server.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("server span did not continue the incoming trace: %s", got)
+	}
+	if child.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Errorf("use-case span is not a child of the request span")
+	}
+}
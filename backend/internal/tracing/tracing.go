@@ -0,0 +1,69 @@
+// Package tracing configures OpenTelemetry and records spans for HTTP routes and GORM statements
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/shirloin/stockhub"
+
+// Tracer starts the spans recorded by the application itself. It follows the global
+// provider, so it records nothing until Setup or otel.SetTracerProvider installs one.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// NewTracerProvider batches spans to exporter; tests pass a tracetest.InMemoryExporter.
+// The sampler follows OTEL_TRACES_SAMPLER and defaults to sampling every trace.
+func NewTracerProvider(exporter sdktrace.SpanExporter, res *resource.Resource) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+}
+
+// Setup installs the global tracer provider and W3C trace context propagation, and returns
+// a function flushing buffered spans on shutdown. Exporter "otlp" sends spans over gRPC to
+// OTEL_EXPORTER_OTLP_ENDPOINT (localhost:4317 by default); "none" only propagates context.
+func Setup(ctx context.Context, exporter string, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	switch strings.ToLower(exporter) {
+	case "none", "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+	default:
+		return nil, fmt.Errorf("invalid traces exporter %q: use otlp or none", exporter)
+	}
+
+	otlpExporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	res, err = resource.Merge(res, resource.Environment())
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewTracerProvider(otlpExporter, res)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,516 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/repository"
+)
+
+// AlertPolicy controls how Run evaluates alert rules
+type AlertPolicy struct {
+	EvaluateInterval time.Duration // How often every rule is evaluated in full and ended snoozes are woken
+}
+
+// maxAlertSnooze is the longest an alert may be snoozed for
+const maxAlertSnooze = 30 * 24 * time.Hour
+
+type AlertUseCase struct {
+	alertRepository     *repository.AlertRepository
+	productRepository   *repository.ProductRepository
+	warehouseRepository *repository.WarehouseRepository
+}
+
+func NewAlertUseCase(alertRepository *repository.AlertRepository, productRepository *repository.ProductRepository, warehouseRepository *repository.WarehouseRepository) *AlertUseCase {
+	return &AlertUseCase{
+		alertRepository:     alertRepository,
+		productRepository:   productRepository,
+		warehouseRepository: warehouseRepository,
+	}
+}
+
+// CreateRule stores the rule and, when it is enabled, opens its alerts straight away
+func (a *AlertUseCase) CreateRule(ctx context.Context, rule *domain.AlertRule) error {
+	ctx, span := startSpan(ctx, "AlertUseCase.CreateRule")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := a.validateRule(ctx, rule); err != nil {
+		return err
+	}
+	if err := a.alertRepository.CreateRule(ctx, rule); err != nil {
+		return err
+	}
+	if rule.Enabled {
+		a.evaluateNow(ctx, rule)
+	}
+	return nil
+}
+
+func (a *AlertUseCase) GetRules(ctx context.Context) ([]domain.AlertRule, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.GetRules")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return a.alertRepository.GetRules(ctx)
+}
+
+func (a *AlertUseCase) GetRuleByID(ctx context.Context, uuid string) (*domain.AlertRule, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.GetRuleByID")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return a.alertRepository.GetRuleByID(ctx, uuid)
+}
+
+// UpdateRule replaces the rule and brings its alerts in line with it. Disabling a rule resolves
+// its alerts, as does changing its type.
+func (a *AlertUseCase) UpdateRule(ctx context.Context, uuid string, rule *domain.AlertRule) error {
+	ctx, span := startSpan(ctx, "AlertUseCase.UpdateRule")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	existing, err := a.alertRepository.GetRuleByID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := a.validateRule(ctx, rule); err != nil {
+		return err
+	}
+	rule.UUID = existing.UUID
+	rule.OrganizationUUID = existing.OrganizationUUID
+	rule.CreatedAt = existing.CreatedAt
+	if err := a.alertRepository.UpdateRule(ctx, rule); err != nil {
+		return err
+	}
+
+	switch {
+	case !rule.Enabled:
+		a.resolveAll(ctx, rule, "rule disabled")
+	case rule.Type != existing.Type:
+		a.resolveAll(ctx, rule, "rule type changed")
+		a.evaluateNow(ctx, rule)
+	default:
+		a.evaluateNow(ctx, rule)
+	}
+	return nil
+}
+
+// DeleteRule deletes the rule with its alerts and their history; disable it to keep them
+func (a *AlertUseCase) DeleteRule(ctx context.Context, uuid string) error {
+	ctx, span := startSpan(ctx, "AlertUseCase.DeleteRule")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return a.alertRepository.DeleteRule(ctx, uuid)
+}
+
+// validateRule checks the rule, including that its product and warehouse exist
+func (a *AlertUseCase) validateRule(ctx context.Context, rule *domain.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if rule.ProductUUID != nil {
+		if _, err := a.productRepository.GetById(ctx, *rule.ProductUUID); err != nil {
+			if domain.IsNotFound(err) {
+				return domain.ErrAlertRuleProductInvalid
+			}
+			return err
+		}
+	}
+	if rule.WarehouseUUID != nil {
+		if _, err := a.warehouseRepository.GetByID(ctx, *rule.WarehouseUUID); err != nil {
+			if domain.IsNotFound(err) {
+				return domain.ErrAlertRuleWarehouseInvalid
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAll returns a page of the organisation's alerts, newest first
+func (a *AlertUseCase) GetAll(ctx context.Context, filter domain.AlertFilter, page, limit int) ([]domain.Alert, int64, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.GetAll")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+	return a.alertRepository.GetAll(ctx, filter, page, limit)
+}
+
+// GetByID returns the alert with its history
+func (a *AlertUseCase) GetByID(ctx context.Context, uuid string) (*domain.Alert, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.GetByID")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return a.alertRepository.GetByID(ctx, uuid)
+}
+
+// Acknowledge records that someone is dealing with the alert. It stays acknowledged, snoozes
+// included, until it is resolved.
+func (a *AlertUseCase) Acknowledge(ctx context.Context, uuid string, action domain.AlertAction) (*domain.Alert, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.Acknowledge")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	actor := alertActor(ctx, action)
+	if _, err := a.transition(ctx, uuid, actor, action.Note, func(alert *domain.Alert, now time.Time) (bool, error) {
+		switch alert.Status {
+		case domain.AlertResolved:
+			return false, domain.ErrAlertResolved
+		case domain.AlertAcknowledged:
+			return false, domain.ErrAlertAlreadyAcknowledged
+		}
+		alert.Status = domain.AlertAcknowledged
+		alert.AcknowledgedAt = &now
+		alert.AcknowledgedBy = actor
+		alert.SnoozedUntil = nil
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return a.alertRepository.GetByID(ctx, uuid)
+}
+
+// Snooze silences the alert until action.Until. If its condition still holds then, it opens
+// again; if the condition clears first, it is resolved. Snoozing a snoozed alert moves the end.
+func (a *AlertUseCase) Snooze(ctx context.Context, uuid string, action domain.AlertAction) (*domain.Alert, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.Snooze")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	if now := time.Now(); action.Until == nil || !action.Until.After(now) || action.Until.After(now.Add(maxAlertSnooze)) {
+		return nil, domain.ErrAlertSnoozeInvalid
+	}
+	until := action.Until.UTC()
+	if _, err := a.transition(ctx, uuid, alertActor(ctx, action), action.Note, func(alert *domain.Alert, now time.Time) (bool, error) {
+		if alert.Status == domain.AlertResolved {
+			return false, domain.ErrAlertResolved
+		}
+		alert.Status = domain.AlertSnoozed
+		alert.SnoozedUntil = &until
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return a.alertRepository.GetByID(ctx, uuid)
+}
+
+// Resolve closes the alert. If its condition still holds, the next evaluation opens a new one.
+func (a *AlertUseCase) Resolve(ctx context.Context, uuid string, action domain.AlertAction) (*domain.Alert, error) {
+	ctx, span := startSpan(ctx, "AlertUseCase.Resolve")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := a.transition(ctx, uuid, alertActor(ctx, action), action.Note, func(alert *domain.Alert, now time.Time) (bool, error) {
+		if alert.Status == domain.AlertResolved {
+			return false, domain.ErrAlertResolved
+		}
+		resolve(alert, now)
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return a.alertRepository.GetByID(ctx, uuid)
+}
+
+// alertActor names who acts on an alert: whoever the action names, else the request's API key
+func alertActor(ctx context.Context, action domain.AlertAction) string {
+	if by := strings.TrimSpace(action.By); by != "" {
+		return by
+	}
+	if key := domain.APIKeyFromContext(ctx); key != nil {
+		return key.Name
+	}
+	return "anonymous"
+}
+
+func resolve(alert *domain.Alert, now time.Time) {
+	alert.Status = domain.AlertResolved
+	alert.ResolvedAt = &now
+	alert.SnoozedUntil = nil
+}
+
+// transition moves the alert on behalf of actor. apply checks the move is allowed and makes it,
+// reporting false to leave the alert as it is.
+func (a *AlertUseCase) transition(ctx context.Context, uuid, actor, note string, apply func(alert *domain.Alert, now time.Time) (bool, error)) (*domain.Alert, error) {
+	changed := false
+	alert, err := a.alertRepository.Transition(ctx, uuid, func(alert *domain.Alert) (*domain.AlertTransition, error) {
+		from := alert.Status
+		ok, err := apply(alert, time.Now().UTC())
+		if err != nil || !ok {
+			return nil, err
+		}
+		changed = true
+		return &domain.AlertTransition{From: from, Actor: actor, Note: note}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		metrics.AlertTransitions.WithLabelValues(string(alert.Type), string(alert.Status)).Inc()
+	}
+	return alert, nil
+}
+
+// evaluate brings the rule's alerts about the scope in line with its condition: it opens alerts
+// for new violations, updates the values of open ones and resolves those whose condition
+// cleared. Large adjustment alerts are only resolved by people.
+func (a *AlertUseCase) evaluate(ctx context.Context, rule *domain.AlertRule, scope domain.AlertScope, now time.Time) error {
+	scope = alertScopeFor(rule.Type, scope)
+	violations, err := a.alertRepository.Violations(ctx, rule, scope, now)
+	if err != nil {
+		return err
+	}
+	active, err := a.alertRepository.GetActive(ctx, rule.UUID, scope)
+	if err != nil {
+		return err
+	}
+	bySubject := make(map[string]*domain.Alert, len(active))
+	for i := range active {
+		bySubject[active[i].SubjectKey] = &active[i]
+	}
+
+	for _, violation := range violations {
+		if alert, ok := bySubject[violation.SubjectKey]; ok {
+			delete(bySubject, violation.SubjectKey)
+			if alert.Value == violation.Value && alert.Threshold == rule.Threshold && alert.Message == violation.Message {
+				continue
+			}
+			alert.Value = violation.Value
+			alert.Threshold = rule.Threshold
+			alert.Message = violation.Message
+			if err := a.alertRepository.Remeasure(ctx, alert); err != nil {
+				return err
+			}
+			continue
+		}
+		opened, err := a.alertRepository.Open(ctx, &domain.Alert{
+			RuleUUID:      rule.UUID,
+			Type:          rule.Type,
+			SubjectKey:    violation.SubjectKey,
+			ProductUUID:   violation.ProductUUID,
+			WarehouseUUID: violation.WarehouseUUID,
+			MovementUUID:  violation.MovementUUID,
+			Message:       violation.Message,
+			Value:         violation.Value,
+			Threshold:     rule.Threshold,
+		})
+		if err != nil {
+			return err
+		}
+		if opened {
+			metrics.AlertTransitions.WithLabelValues(string(rule.Type), string(domain.AlertOpen)).Inc()
+		}
+	}
+
+	if rule.Type == domain.AlertLargeAdjustment {
+		return nil
+	}
+	for uuid := range bySubject {
+		if _, err := a.transition(ctx, uuid, domain.AlertActorSystem, "condition cleared", func(alert *domain.Alert, now time.Time) (bool, error) {
+			if !alert.Active() {
+				return false, nil
+			}
+			resolve(alert, now)
+			return true, nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alertScopeFor keeps the parts of scope alerts of the rule type are about: a utilization
+// alert is about a warehouse whatever product moved in it
+func alertScopeFor(ruleType domain.AlertRuleType, scope domain.AlertScope) domain.AlertScope {
+	switch ruleType {
+	case domain.AlertStockBelow:
+		return domain.AlertScope{ProductUUID: scope.ProductUUID, WarehouseUUID: scope.WarehouseUUID}
+	case domain.AlertWarehouseUtilization:
+		return domain.AlertScope{WarehouseUUID: scope.WarehouseUUID}
+	case domain.AlertNoMovement, domain.AlertStockDrift:
+		return domain.AlertScope{ProductUUID: scope.ProductUUID}
+	}
+	return scope
+}
+
+// evaluateNow evaluates the rule in full after it changed. The change is stored either way, so
+// failures are only logged; the next full evaluation catches up.
+func (a *AlertUseCase) evaluateNow(ctx context.Context, rule *domain.AlertRule) {
+	if rule.Type == domain.AlertLargeAdjustment {
+		return
+	}
+	if err := a.evaluate(ctx, rule, domain.AlertScope{}, time.Now()); err != nil {
+		slog.WarnContext(ctx, "Evaluating alert rule", "rule", rule.UUID, "error", err)
+	}
+}
+
+// resolveAll resolves the rule's unresolved alerts, logging failures like evaluateNow
+func (a *AlertUseCase) resolveAll(ctx context.Context, rule *domain.AlertRule, note string) {
+	alerts, err := a.alertRepository.GetActive(ctx, rule.UUID, domain.AlertScope{})
+	if err != nil {
+		slog.WarnContext(ctx, "Resolving alerts of rule", "rule", rule.UUID, "error", err)
+		return
+	}
+	for _, alert := range alerts {
+		if _, err := a.transition(ctx, alert.UUID, domain.AlertActorSystem, note, func(alert *domain.Alert, now time.Time) (bool, error) {
+			if !alert.Active() {
+				return false, nil
+			}
+			resolve(alert, now)
+			return true, nil
+		}); err != nil {
+			slog.WarnContext(ctx, "Resolving alert", "alert", alert.UUID, "error", err)
+		}
+	}
+}
+
+// Sink returns the outbox sink evaluating the enabled rules an event may have changed the
+// outcome of, narrowed to the product and warehouse it is about
+func (a *AlertUseCase) Sink() domain.EventSink {
+	return &alertSink{alerts: a}
+}
+
+type alertSink struct {
+	alerts *AlertUseCase
+}
+
+func (s *alertSink) Name() string {
+	return "alerts"
+}
+
+func (s *alertSink) Deliver(ctx context.Context, event domain.Event) error {
+	var scope domain.AlertScope
+	var types []domain.AlertRuleType
+	switch event.Type {
+	case domain.EventMovementCreated:
+		var movement domain.StockMovement
+		if err := decodeEventData(event, &movement); err != nil {
+			return err
+		}
+		scope = domain.AlertScope{ProductUUID: movement.ProductUUID, WarehouseUUID: movement.WarehouseUUID, MovementUUID: movement.UUID}
+		types = domain.AlertRuleTypes
+	case domain.EventProductCreated, domain.EventProductUpdated, domain.EventProductDeleted:
+		var product domain.Product
+		if err := decodeEventData(event, &product); err != nil {
+			return err
+		}
+		scope = domain.AlertScope{ProductUUID: product.UUID}
+		types = []domain.AlertRuleType{domain.AlertStockBelow, domain.AlertNoMovement, domain.AlertStockDrift}
+	case domain.EventWarehouseUpdated, domain.EventWarehouseDeleted:
+		var warehouse domain.Warehouse
+		if err := decodeEventData(event, &warehouse); err != nil {
+			return err
+		}
+		scope = domain.AlertScope{WarehouseUUID: warehouse.UUID}
+		types = []domain.AlertRuleType{domain.AlertStockBelow, domain.AlertWarehouseUtilization}
+	default:
+		return nil
+	}
+
+	ctx, span := startSpan(ctx, "AlertUseCase.Deliver")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ctx = domain.WithOrganization(ctx, event.OrganizationUUID)
+
+	rules, err := s.alerts.alertRepository.GetEnabledRules(ctx, types...)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range rules {
+		if err := s.alerts.evaluate(ctx, &rules[i], scope, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run evaluates every enabled rule of every organisation in full, and opens snoozed alerts
+// whose snooze ended, every policy.EvaluateInterval until ctx is cancelled. This catches what
+// events don't announce, such as products going without movements. Every replica may run it.
+func (a *AlertUseCase) Run(ctx context.Context, policy AlertPolicy) {
+	ctx = domain.WithAllOrganizations(ctx)
+	ticker := time.NewTicker(policy.EvaluateInterval)
+	defer ticker.Stop()
+	for {
+		a.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep wakes the snoozes that ended, then evaluates every rule. Large adjustments are only
+// evaluated as their movements come in.
+func (a *AlertUseCase) sweep(ctx context.Context) {
+	ctx, span := startSpan(ctx, "AlertUseCase.sweep")
+	defer span.End()
+
+	now := time.Now()
+	snoozes, err := a.alertRepository.GetDueSnoozes(ctx, now)
+	if err != nil && ctx.Err() == nil {
+		slog.ErrorContext(ctx, "Finding ended alert snoozes", "error", err)
+	}
+	for _, snoozed := range snoozes {
+		organizationCtx := domain.WithOrganization(ctx, snoozed.OrganizationUUID)
+		if _, err := a.transition(organizationCtx, snoozed.UUID, domain.AlertActorSystem, "snooze ended", func(alert *domain.Alert, now time.Time) (bool, error) {
+			if alert.Status != domain.AlertSnoozed || alert.SnoozedUntil == nil || alert.SnoozedUntil.After(now) {
+				return false, nil
+			}
+			alert.Status = domain.AlertOpen
+			alert.SnoozedUntil = nil
+			return true, nil
+		}); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Waking snoozed alert", "alert", snoozed.UUID, "error", err)
+		}
+	}
+
+	rules, err := a.alertRepository.GetEnabledRules(ctx,
+		domain.AlertStockBelow, domain.AlertWarehouseUtilization, domain.AlertNoMovement, domain.AlertStockDrift)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Finding alert rules", "error", err)
+		}
+		return
+	}
+	for i := range rules {
+		ruleCtx, cancel := context.WithTimeout(domain.WithOrganization(ctx, rules[i].OrganizationUUID), 30*time.Second)
+		if err := a.evaluate(ruleCtx, &rules[i], domain.AlertScope{}, now); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Evaluating alert rule", "rule", rules[i].UUID, "error", err)
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/repository"
+)
+
+const (
+	// apiKeyPrefix starts every key so it is recognisable in headers and secret scanners
+	apiKeyPrefix = "shk_"
+	// apiKeyIDLength is the length of the public part of a key, apiKeyPrefix included
+	apiKeyIDLength = len(apiKeyPrefix) + 12
+	// lastUsedInterval limits how often a busy key's last-used timestamp is written
+	lastUsedInterval = time.Minute
+)
+
+type APIKeyUseCase struct {
+	apiKeyRepository       *repository.APIKeyRepository
+	warehouseRepository    *repository.WarehouseRepository
+	organizationRepository *repository.OrganizationRepository
+}
+
+func NewAPIKeyUseCase(apiKeyRepository *repository.APIKeyRepository, warehouseRepository *repository.WarehouseRepository, organizationRepository *repository.OrganizationRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{apiKeyRepository: apiKeyRepository, warehouseRepository: warehouseRepository, organizationRepository: organizationRepository}
+}
+
+// IsAPIKey reports whether a credential looks like a key issued by Create, as opposed to
+// some other bearer token
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, apiKeyPrefix)
+}
+
+func (a *APIKeyUseCase) Create(ctx context.Context, key *domain.APIKey) (*domain.IssuedAPIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyUseCase.Create")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	key.Name = strings.TrimSpace(key.Name)
+	if key.WarehouseUUIDs == nil {
+		key.WarehouseUUIDs = []string{}
+	}
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	if err := checkOrganization(ctx, a.organizationRepository, &key.OrganizationUUID); err != nil {
+		return nil, err
+	}
+	// The key may only be restricted to warehouses of its own organisation
+	organizationCtx := domain.WithOrganization(ctx, key.OrganizationUUID)
+	for _, warehouseUUID := range key.WarehouseUUIDs {
+		if _, err := a.warehouseRepository.GetByID(organizationCtx, warehouseUUID); err != nil {
+			if domain.IsNotFound(err) {
+				return nil, domain.NewValidationError("warehouseUuids", "warehouse "+warehouseUUID+" not found")
+			}
+			return nil, err
+		}
+	}
+
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return nil, err
+	}
+	key.Prefix = secret[:apiKeyIDLength]
+	key.KeyHash = hashAPIKey(secret)
+	key.LastUsedAt = nil
+	key.RevokedAt = nil
+	if err := a.apiKeyRepository.Create(ctx, key); err != nil {
+		return nil, err
+	}
+	return &domain.IssuedAPIKey{APIKey: *key, Key: secret}, nil
+}
+
+func (a *APIKeyUseCase) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyUseCase.GetAll")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return a.apiKeyRepository.GetAll(ctx)
+}
+
+func (a *APIKeyUseCase) GetByID(ctx context.Context, uuid string) (*domain.APIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyUseCase.GetByID")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return a.apiKeyRepository.GetByID(ctx, uuid)
+}
+
+// Rotate issues a new secret for the key, keeping its name, scopes and warehouses.
+// The previous secret stops working immediately.
+func (a *APIKeyUseCase) Rotate(ctx context.Context, uuid string) (*domain.IssuedAPIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyUseCase.Rotate")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	key, err := a.apiKeyRepository.GetByID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, domain.ErrAPIKeyRevoked
+	}
+
+	secret, err := newAPIKeySecret()
+	if err != nil {
+		return nil, err
+	}
+	key.Prefix = secret[:apiKeyIDLength]
+	key.KeyHash = hashAPIKey(secret)
+	key.LastUsedAt = nil
+	if err := a.apiKeyRepository.UpdateSecret(ctx, uuid, key.Prefix, key.KeyHash); err != nil {
+		return nil, err
+	}
+	return &domain.IssuedAPIKey{APIKey: *key, Key: secret}, nil
+}
+
+// Revoke disables the key for good; revoking a revoked key does nothing
+func (a *APIKeyUseCase) Revoke(ctx context.Context, uuid string) error {
+	ctx, span := startSpan(ctx, "APIKeyUseCase.Revoke")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := a.apiKeyRepository.GetByID(ctx, uuid); err != nil {
+		return err
+	}
+	return a.apiKeyRepository.Revoke(ctx, uuid, time.Now())
+}
+
+// Authenticate returns the active key matching secret, or ErrAPIKeyInvalid
+func (a *APIKeyUseCase) Authenticate(ctx context.Context, secret string) (*domain.APIKey, error) {
+	ctx, span := startSpan(ctx, "APIKeyUseCase.Authenticate")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	// The key tells which organisation the request is for, so it is looked up among all of them
+	ctx = domain.WithAllOrganizations(ctx)
+
+	if !IsAPIKey(secret) || len(secret) <= apiKeyIDLength {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+	key, err := a.apiKeyRepository.GetByPrefix(ctx, secret[:apiKeyIDLength])
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(secret)), []byte(key.KeyHash)) != 1 || !key.Active(now) {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedInterval {
+		// A failed write only loses a timestamp; the request goes ahead
+		if err := a.apiKeyRepository.TouchLastUsed(ctx, key.UUID, now); err != nil {
+			slog.WarnContext(ctx, "Recording API key use", "api_key_uuid", key.UUID, "error", err)
+		} else {
+			key.LastUsedAt = &now
+		}
+	}
+	return key, nil
+}
+
+// newAPIKeySecret returns a key of the form shk_<12 hex characters>_<43 random characters>;
+// everything up to the second underscore is the public prefix
+func newAPIKeySecret() (string, error) {
+	id := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(id) + "_" + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashAPIKey hashes a key for storage. Keys carry 256 random bits, so a fast hash is enough;
+// there is nothing to gain from a password hash.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
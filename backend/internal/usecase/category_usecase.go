@@ -17,6 +17,8 @@ func NewCategoryUseCase(categoryRepository *repository.CategoryRepository) *Cate
 }
 
 func (c *CategoryUseCase) Create(ctx context.Context, category *domain.Category) error {
+	ctx, span := startSpan(ctx, "CategoryUseCase.Create")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -27,14 +29,22 @@ func (c *CategoryUseCase) Create(ctx context.Context, category *domain.Category)
 	return c.categoryRepository.Create(ctx, category)
 }
 
-func (c *CategoryUseCase) GetAll(ctx context.Context) ([]domain.Category, error) {
+// GetAll returns the categories, the first limit of them when limit is positive
+func (c *CategoryUseCase) GetAll(ctx context.Context, limit int) ([]domain.Category, error) {
+	ctx, span := startSpan(ctx, "CategoryUseCase.GetAll")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if limit > 0 {
+		return c.categoryRepository.GetAllPaginated(ctx, 1, limit)
+	}
 	return c.categoryRepository.GetAll(ctx)
 }
 
 func (c *CategoryUseCase) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.Category, int64, error) {
+	ctx, span := startSpan(ctx, "CategoryUseCase.GetAllPaginated")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -50,6 +60,8 @@ func (c *CategoryUseCase) GetAllPaginated(ctx context.Context, page, limit int)
 }
 
 func (c *CategoryUseCase) GetByID(ctx context.Context, uuid string) (*domain.Category, error) {
+	ctx, span := startSpan(ctx, "CategoryUseCase.GetByID")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -57,6 +69,8 @@ func (c *CategoryUseCase) GetByID(ctx context.Context, uuid string) (*domain.Cat
 }
 
 func (c *CategoryUseCase) Update(ctx context.Context, uuid string, category *domain.Category) error {
+	ctx, span := startSpan(ctx, "CategoryUseCase.Update")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -76,6 +90,8 @@ func (c *CategoryUseCase) Update(ctx context.Context, uuid string, category *dom
 }
 
 func (c *CategoryUseCase) Delete(ctx context.Context, uuid string) error {
+	ctx, span := startSpan(ctx, "CategoryUseCase.Delete")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/pkg/spreadsheet"
+)
+
+type ExportUseCase struct {
+	stockMovementRepository   *repository.StockMovementRepository
+	stockInRepository         *repository.StockInRepository
+	stockOutRepository        *repository.StockOutRepository
+	stockAdjustmentRepository *repository.StockAdjustmentRepository
+	warehouseRepository       *repository.WarehouseRepository
+	warehouseStockRepository  *repository.WarehouseStockRepository
+}
+
+func NewExportUseCase(
+	stockMovementRepository *repository.StockMovementRepository,
+	stockInRepository *repository.StockInRepository,
+	stockOutRepository *repository.StockOutRepository,
+	stockAdjustmentRepository *repository.StockAdjustmentRepository,
+	warehouseRepository *repository.WarehouseRepository,
+	warehouseStockRepository *repository.WarehouseStockRepository,
+) *ExportUseCase {
+	return &ExportUseCase{
+		stockMovementRepository:   stockMovementRepository,
+		stockInRepository:         stockInRepository,
+		stockOutRepository:        stockOutRepository,
+		stockAdjustmentRepository: stockAdjustmentRepository,
+		warehouseRepository:       warehouseRepository,
+		warehouseStockRepository:  warehouseStockRepository,
+	}
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (u *ExportUseCase) ExportStockMovements(ctx context.Context, filter domain.StockMovementFilter, out spreadsheet.Writer) error {
+	ctx, span := startSpan(ctx, "ExportUseCase.ExportStockMovements")
+	defer span.End()
+	if err := out.Write([]any{"Movement Date", "Type", "SKU", "Product", "Warehouse", "Quantity", "Previous Qty", "New Qty", "Reference Number", "To Warehouse", "Adjustment Reason", "Notes", "Created By", "Created At", "UUID"}); err != nil {
+		return err
+	}
+	return u.stockMovementRepository.StreamFiltered(ctx, filter, func(m *domain.StockMovementExport) error {
+		return out.Write([]any{
+			formatExportTime(m.MovementDate), string(m.MovementType), m.ProductSKU, m.ProductTitle, m.WarehouseName,
+			m.Quantity, m.PreviousQty, m.NewQty, m.ReferenceNumber, m.ToWarehouseName, string(m.AdjustmentReason),
+			m.Notes, m.CreatedBy, formatExportTime(m.CreatedAt), m.UUID,
+		})
+	})
+}
+
+func (u *ExportUseCase) ExportStockIns(ctx context.Context, filter domain.StockRecordFilter, out spreadsheet.Writer) error {
+	ctx, span := startSpan(ctx, "ExportUseCase.ExportStockIns")
+	defer span.End()
+	if err := out.Write([]any{"Received Date", "SKU", "Product", "Warehouse", "Quantity", "Purchase Order No", "Supplier", "Received By", "Notes", "Created At", "UUID"}); err != nil {
+		return err
+	}
+	return u.stockInRepository.StreamFiltered(ctx, filter, func(s *domain.StockInExport) error {
+		return out.Write([]any{
+			formatExportTime(s.ReceivedDate), s.ProductSKU, s.ProductTitle, s.WarehouseName, s.Quantity,
+			s.PurchaseOrderNo, s.SupplierName, s.ReceivedBy, s.Notes, formatExportTime(s.CreatedAt), s.UUID,
+		})
+	})
+}
+
+func (u *ExportUseCase) ExportStockOuts(ctx context.Context, filter domain.StockRecordFilter, out spreadsheet.Writer) error {
+	ctx, span := startSpan(ctx, "ExportUseCase.ExportStockOuts")
+	defer span.End()
+	if err := out.Write([]any{"Shipped Date", "SKU", "Product", "Warehouse", "Quantity", "Sales Order No", "Customer", "Shipped By", "Notes", "Created At", "UUID"}); err != nil {
+		return err
+	}
+	return u.stockOutRepository.StreamFiltered(ctx, filter, func(s *domain.StockOutExport) error {
+		return out.Write([]any{
+			formatExportTime(s.ShippedDate), s.ProductSKU, s.ProductTitle, s.WarehouseName, s.Quantity,
+			s.SalesOrderNo, s.CustomerName, s.ShippedBy, s.Notes, formatExportTime(s.CreatedAt), s.UUID,
+		})
+	})
+}
+
+func (u *ExportUseCase) ExportStockAdjustments(ctx context.Context, filter domain.StockRecordFilter, out spreadsheet.Writer) error {
+	ctx, span := startSpan(ctx, "ExportUseCase.ExportStockAdjustments")
+	defer span.End()
+	if err := out.Write([]any{"Adjustment Date", "SKU", "Product", "Warehouse", "Quantity", "Previous Qty", "New Qty", "Reason", "Adjusted By", "Notes", "Created At", "UUID"}); err != nil {
+		return err
+	}
+	return u.stockAdjustmentRepository.StreamFiltered(ctx, filter, func(a *domain.StockAdjustmentExport) error {
+		return out.Write([]any{
+			formatExportTime(a.AdjustmentDate), a.ProductSKU, a.ProductTitle, a.WarehouseName, a.Quantity,
+			a.PreviousQty, a.NewQty, string(a.Reason), a.AdjustedBy, a.Notes, formatExportTime(a.CreatedAt), a.UUID,
+		})
+	})
+}
+
+// ExportWarehouseStock writes the current on-hand quantity of every product in the warehouse
+func (u *ExportUseCase) ExportWarehouseStock(ctx context.Context, warehouseUUID string, out spreadsheet.Writer) error {
+	ctx, span := startSpan(ctx, "ExportUseCase.ExportWarehouseStock")
+	defer span.End()
+	if _, err := u.warehouseRepository.GetByID(ctx, warehouseUUID); err != nil {
+		return err
+	}
+	if err := out.Write([]any{"SKU", "Product", "Warehouse", "Quantity", "Updated At"}); err != nil {
+		return err
+	}
+	return u.warehouseStockRepository.StreamByWarehouse(ctx, warehouseUUID, func(s *domain.WarehouseStockExport) error {
+		return out.Write([]any{s.ProductSKU, s.ProductTitle, s.WarehouseName, s.Quantity, formatExportTime(s.UpdatedAt)})
+	})
+}
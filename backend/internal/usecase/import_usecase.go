@@ -0,0 +1,458 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/pkg/spreadsheet"
+)
+
+type ImportUseCase struct {
+	productRepository        *repository.ProductRepository
+	categoryRepository       *repository.CategoryRepository
+	supplierRepository       *repository.SupplierRepository
+	warehouseRepository      *repository.WarehouseRepository
+	warehouseStockRepository *repository.WarehouseStockRepository
+}
+
+func NewImportUseCase(
+	productRepository *repository.ProductRepository,
+	categoryRepository *repository.CategoryRepository,
+	supplierRepository *repository.SupplierRepository,
+	warehouseRepository *repository.WarehouseRepository,
+	warehouseStockRepository *repository.WarehouseStockRepository,
+) *ImportUseCase {
+	return &ImportUseCase{
+		productRepository:        productRepository,
+		categoryRepository:       categoryRepository,
+		supplierRepository:       supplierRepository,
+		warehouseRepository:      warehouseRepository,
+		warehouseStockRepository: warehouseStockRepository,
+	}
+}
+
+// Import reads every row of the file, validates it and, unless this is a dry run,
+// upserts the valid rows in batches. Rejected rows are reported in the result.
+func (u *ImportUseCase) Import(ctx context.Context, entity domain.ImportEntity, reader *spreadsheet.Reader, opts domain.ImportOptions) (*domain.ImportResult, error) {
+	ctx, span := startSpan(ctx, "ImportUseCase.Import")
+	defer span.End()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = domain.DefaultImportBatchSize
+	}
+
+	switch entity {
+	case domain.ImportEntityProducts:
+		spec, err := u.productSpec(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return runImport(ctx, reader, spec, opts)
+	case domain.ImportEntityCategories:
+		return runImport(ctx, reader, u.categorySpec(), opts)
+	case domain.ImportEntitySuppliers:
+		return runImport(ctx, reader, u.supplierSpec(), opts)
+	case domain.ImportEntityWarehouses:
+		return runImport(ctx, reader, u.warehouseSpec(), opts)
+	case domain.ImportEntityStock:
+		spec, err := u.openingBalanceSpec(ctx, opts.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		return runImport(ctx, reader, spec, opts)
+	}
+	return nil, domain.ErrImportEntityUnknown
+}
+
+// importColumn maps one column of the file onto a field of the imported entity
+type importColumn[T any] struct {
+	name     string // Header name as documented, reported in row errors
+	dbColumn string // Column written on update when the cell is filled; empty if the field is not persisted as-is
+	required bool
+	set      func(entity *T, value string) error
+}
+
+// importSpec describes how rows of one entity are parsed, checked and committed
+type importSpec[T any] struct {
+	entity  domain.ImportEntity
+	columns []importColumn[T]
+	// validate resolves references and runs domain validation on a parsed row
+	validate func(entity *T) error
+	// key identifies the row for duplicate detection within the same file
+	key func(entity *T) string
+	// commit persists one batch in a single transaction and reports created/updated counts.
+	// dbColumns holds, for each row, the columns of its filled cells; blank cells are left out so
+	// that they do not overwrite stored values.
+	commit func(ctx context.Context, batch []T, dbColumns [][]string) (int, int, error)
+	// preview, when set, fails a batch of a dry run the way commit would, without persisting it
+	preview func(ctx context.Context, batch []T, dbColumns [][]string) error
+}
+
+// rowError is a row-level failure attributed to a specific column
+type rowError struct {
+	column string
+	err    error
+}
+
+func (e *rowError) Error() string {
+	return e.err.Error()
+}
+
+func runImport[T any](ctx context.Context, reader *spreadsheet.Reader, spec importSpec[T], opts domain.ImportOptions) (*domain.ImportResult, error) {
+	var bound []boundColumn[T]
+	present := make(map[string]bool)
+	for i, header := range reader.Header() {
+		normalized := spreadsheet.NormalizeHeader(header)
+		for j := range spec.columns {
+			if spreadsheet.NormalizeHeader(spec.columns[j].name) == normalized && !present[spec.columns[j].name] {
+				bound = append(bound, boundColumn[T]{index: i, column: &spec.columns[j]})
+				present[spec.columns[j].name] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, column := range spec.columns {
+		if column.required && !present[column.name] {
+			missing = append(missing, column.name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrImportMissingColumns, strings.Join(missing, ", "))
+	}
+
+	result := &domain.ImportResult{
+		Entity: spec.entity,
+		DryRun: opts.DryRun,
+		Errors: make([]domain.ImportRowError, 0),
+	}
+	reject := func(row int, err error) {
+		rowErr := domain.ImportRowError{Row: row, Message: err.Error()}
+		var colErr *rowError
+		if errors.As(err, &colErr) {
+			rowErr.Column = colErr.column
+		}
+		result.Errors = append(result.Errors, rowErr)
+		result.Failed++
+	}
+
+	batch := make([]T, 0, opts.BatchSize)
+	batchRows := make([]int, 0, opts.BatchSize)
+	batchColumns := make([][]string, 0, opts.BatchSize)
+	flush := func() error {
+		defer func() {
+			batch = batch[:0]
+			batchRows = batchRows[:0]
+			batchColumns = batchColumns[:0]
+		}()
+		if len(batch) == 0 || (opts.DryRun && spec.preview == nil) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if opts.DryRun {
+			if err := spec.preview(ctx, batch, batchColumns); err != nil {
+				for _, row := range batchRows {
+					reject(row, fmt.Errorf("batch would not be committed: %w", err))
+				}
+			}
+			return nil
+		}
+		created, updated, err := spec.commit(ctx, batch, batchColumns)
+		if err != nil {
+			// The whole batch was rolled back, so every row in it is reported as failed
+			for _, row := range batchRows {
+				reject(row, fmt.Errorf("batch not committed: %w", err))
+			}
+			return nil
+		}
+		result.Created += created
+		result.Updated += updated
+		return nil
+	}
+
+	seen := make(map[string]int)
+	row := 1 // Header row
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		row++
+		if err != nil {
+			result.TotalRows++
+			reject(row, err)
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		result.TotalRows++
+
+		var entity T
+		dbColumns, err := parseRecord(record, bound, &entity)
+		if err != nil {
+			reject(row, err)
+			continue
+		}
+		if err := spec.validate(&entity); err != nil {
+			reject(row, err)
+			continue
+		}
+		key := spec.key(&entity)
+		if first, ok := seen[key]; ok {
+			reject(row, fmt.Errorf("duplicate of row %d", first))
+			continue
+		}
+		seen[key] = row
+
+		result.ValidRows++
+		batch = append(batch, entity)
+		batchRows = append(batchRows, row)
+		batchColumns = append(batchColumns, dbColumns)
+		if len(batch) >= opts.BatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// boundColumn ties a spec column to its position in the file header
+type boundColumn[T any] struct {
+	index  int
+	column *importColumn[T]
+}
+
+// parseRecord sets the entity's fields from the filled cells of the record and returns the
+// database columns they map to. Blank optional cells are skipped.
+func parseRecord[T any](record []string, bound []boundColumn[T], entity *T) ([]string, error) {
+	var dbColumns []string
+	for _, b := range bound {
+		column := b.column
+		value := ""
+		// Spreadsheet rows may omit trailing empty cells
+		if b.index < len(record) {
+			value = strings.TrimSpace(record[b.index])
+		}
+		if value == "" {
+			if column.required {
+				return nil, &rowError{column: column.name, err: fmt.Errorf("%s is required", column.name)}
+			}
+			continue
+		}
+		if err := column.set(entity, value); err != nil {
+			return nil, &rowError{column: column.name, err: fmt.Errorf("invalid %s %q: %w", column.name, value, err)}
+		}
+		if column.dbColumn != "" {
+			dbColumns = append(dbColumns, column.dbColumn)
+		}
+	}
+	return dbColumns, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseInt(value string, target *int) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return errors.New("must be a whole number")
+	}
+	*target = n
+	return nil
+}
+
+func (u *ImportUseCase) productSpec(ctx context.Context) (importSpec[domain.Product], error) {
+	ctx, span := startSpan(ctx, "ImportUseCase.productSpec")
+	defer span.End()
+	categories, err := u.categoryRepository.GetAll(ctx)
+	if err != nil {
+		return importSpec[domain.Product]{}, err
+	}
+	categoryUUIDs := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryUUIDs[strings.ToLower(category.Name)] = category.UUID
+	}
+
+	suppliers, err := u.supplierRepository.GetAll(ctx)
+	if err != nil {
+		return importSpec[domain.Product]{}, err
+	}
+	supplierUUIDs := make(map[string]string, len(suppliers))
+	for _, supplier := range suppliers {
+		supplierUUIDs[strings.ToLower(supplier.Name)] = supplier.UUID
+	}
+
+	return importSpec[domain.Product]{
+		entity: domain.ImportEntityProducts,
+		columns: []importColumn[domain.Product]{
+			{name: "sku", dbColumn: "sku", required: true, set: func(p *domain.Product, v string) error { p.SKU = v; return nil }},
+			{name: "title", dbColumn: "title", required: true, set: func(p *domain.Product, v string) error { p.Title = v; return nil }},
+			{name: "description", dbColumn: "description", set: func(p *domain.Product, v string) error { p.Description = v; return nil }},
+			{name: "price", dbColumn: "price", set: func(p *domain.Product, v string) error { return parseInt(v, &p.Price) }},
+			{name: "stock", dbColumn: "stock", set: func(p *domain.Product, v string) error { return parseInt(v, &p.Stock) }},
+			{name: "lowStockThreshold", dbColumn: "low_stock_threshold", set: func(p *domain.Product, v string) error { return parseInt(v, &p.LowStockThreshold) }},
+			{name: "barcode", dbColumn: "barcode", set: func(p *domain.Product, v string) error { p.Barcode = v; return nil }},
+			{name: "imageUrl", dbColumn: "image_url", set: func(p *domain.Product, v string) error { p.ImageURL = v; return nil }},
+			{name: "category", dbColumn: "category_uuid", set: func(p *domain.Product, v string) error {
+				uuid, ok := categoryUUIDs[strings.ToLower(v)]
+				if !ok {
+					return errors.New("category not found")
+				}
+				p.CategoryUUID = uuid
+				return nil
+			}},
+			{name: "supplier", dbColumn: "supplier_uuid", set: func(p *domain.Product, v string) error {
+				uuid, ok := supplierUUIDs[strings.ToLower(v)]
+				if !ok {
+					return errors.New("supplier not found")
+				}
+				p.SupplierUUID = uuid
+				return nil
+			}},
+		},
+		validate: func(p *domain.Product) error { return p.Validate() },
+		key:      func(p *domain.Product) string { return p.SKU },
+		commit:   u.productRepository.UpsertBySKU,
+		preview:  u.productRepository.PreviewUpsertBySKU,
+	}, nil
+}
+
+func (u *ImportUseCase) categorySpec() importSpec[domain.Category] {
+	return importSpec[domain.Category]{
+		entity: domain.ImportEntityCategories,
+		columns: []importColumn[domain.Category]{
+			{name: "name", dbColumn: "name", required: true, set: func(c *domain.Category, v string) error { c.Name = v; return nil }},
+			{name: "description", dbColumn: "description", set: func(c *domain.Category, v string) error { c.Description = v; return nil }},
+		},
+		validate: func(c *domain.Category) error { return c.Validate() },
+		key:      func(c *domain.Category) string { return strings.ToLower(c.Name) },
+		commit:   u.categoryRepository.UpsertByName,
+	}
+}
+
+func (u *ImportUseCase) supplierSpec() importSpec[domain.Supplier] {
+	return importSpec[domain.Supplier]{
+		entity: domain.ImportEntitySuppliers,
+		columns: []importColumn[domain.Supplier]{
+			{name: "name", dbColumn: "name", required: true, set: func(s *domain.Supplier, v string) error { s.Name = v; return nil }},
+			{name: "email", dbColumn: "email", set: func(s *domain.Supplier, v string) error { s.Email = v; return nil }},
+			{name: "phone", dbColumn: "phone", set: func(s *domain.Supplier, v string) error { s.Phone = v; return nil }},
+			{name: "address", dbColumn: "address", set: func(s *domain.Supplier, v string) error { s.Address = v; return nil }},
+			{name: "contactName", dbColumn: "contact_name", set: func(s *domain.Supplier, v string) error { s.ContactName = v; return nil }},
+		},
+		validate: func(s *domain.Supplier) error { return s.Validate() },
+		key:      func(s *domain.Supplier) string { return strings.ToLower(s.Name) },
+		commit:   u.supplierRepository.UpsertByName,
+	}
+}
+
+func (u *ImportUseCase) warehouseSpec() importSpec[domain.Warehouse] {
+	return importSpec[domain.Warehouse]{
+		entity: domain.ImportEntityWarehouses,
+		columns: []importColumn[domain.Warehouse]{
+			{name: "name", dbColumn: "name", required: true, set: func(w *domain.Warehouse, v string) error { w.Name = v; return nil }},
+			{name: "address", dbColumn: "address", set: func(w *domain.Warehouse, v string) error { w.Address = v; return nil }},
+			{name: "city", dbColumn: "city", set: func(w *domain.Warehouse, v string) error { w.City = v; return nil }},
+			{name: "state", dbColumn: "state", set: func(w *domain.Warehouse, v string) error { w.State = v; return nil }},
+			{name: "country", dbColumn: "country", set: func(w *domain.Warehouse, v string) error { w.Country = v; return nil }},
+			{name: "postalCode", dbColumn: "postal_code", set: func(w *domain.Warehouse, v string) error { w.PostalCode = v; return nil }},
+			{name: "managerName", dbColumn: "manager_name", set: func(w *domain.Warehouse, v string) error { w.ManagerName = v; return nil }},
+			{name: "managerEmail", dbColumn: "manager_email", set: func(w *domain.Warehouse, v string) error { w.ManagerEmail = v; return nil }},
+			{name: "managerPhone", dbColumn: "manager_phone", set: func(w *domain.Warehouse, v string) error { w.ManagerPhone = v; return nil }},
+			{name: "capacity", dbColumn: "capacity", set: func(w *domain.Warehouse, v string) error { return parseInt(v, &w.Capacity) }},
+		},
+		validate: func(w *domain.Warehouse) error { return w.Validate() },
+		key:      func(w *domain.Warehouse) string { return strings.ToLower(w.Name) },
+		commit:   u.warehouseRepository.UpsertByName,
+	}
+}
+
+// openingBalanceRow carries the names from the file until they are resolved to UUIDs
+type openingBalanceRow struct {
+	domain.OpeningBalance
+	sku       string
+	warehouse string
+}
+
+func (u *ImportUseCase) openingBalanceSpec(ctx context.Context, createdBy string) (importSpec[openingBalanceRow], error) {
+	ctx, span := startSpan(ctx, "ImportUseCase.openingBalanceSpec")
+	defer span.End()
+	productUUIDs, err := u.productRepository.GetUUIDsBySKU(ctx)
+	if err != nil {
+		return importSpec[openingBalanceRow]{}, err
+	}
+
+	warehouses, err := u.warehouseRepository.GetAll(ctx)
+	if err != nil {
+		return importSpec[openingBalanceRow]{}, err
+	}
+	warehouseUUIDs := make(map[string]string, len(warehouses))
+	for _, warehouse := range warehouses {
+		warehouseUUIDs[strings.ToLower(warehouse.Name)] = warehouse.UUID
+	}
+
+	balancesOf := func(batch []openingBalanceRow) []domain.OpeningBalance {
+		balances := make([]domain.OpeningBalance, len(batch))
+		for i, row := range batch {
+			balances[i] = row.OpeningBalance
+		}
+		return balances
+	}
+	// Stock that earlier batches of a dry run would have added, as a real import would have
+	// committed them by then
+	pending := make(map[string]int)
+
+	return importSpec[openingBalanceRow]{
+		entity: domain.ImportEntityStock,
+		columns: []importColumn[openingBalanceRow]{
+			{name: "sku", required: true, set: func(b *openingBalanceRow, v string) error { b.sku = v; return nil }},
+			{name: "warehouse", required: true, set: func(b *openingBalanceRow, v string) error { b.warehouse = v; return nil }},
+			{name: "quantity", required: true, set: func(b *openingBalanceRow, v string) error { return parseInt(v, &b.Quantity) }},
+		},
+		validate: func(b *openingBalanceRow) error {
+			productUUID, ok := productUUIDs[b.sku]
+			if !ok {
+				return &rowError{column: "sku", err: errors.New("product not found")}
+			}
+			warehouseUUID, ok := warehouseUUIDs[strings.ToLower(b.warehouse)]
+			if !ok {
+				return &rowError{column: "warehouse", err: errors.New("warehouse not found")}
+			}
+			if err := domain.CheckWarehouseAccess(ctx, warehouseUUID); err != nil {
+				return &rowError{column: "warehouse", err: err}
+			}
+			if b.Quantity < 0 {
+				return &rowError{column: "quantity", err: domain.ErrImportQuantityInvalid}
+			}
+			b.ProductUUID = productUUID
+			b.WarehouseUUID = warehouseUUID
+			return nil
+		},
+		key: func(b *openingBalanceRow) string { return b.ProductUUID + "/" + b.WarehouseUUID },
+		commit: func(ctx context.Context, batch []openingBalanceRow, _ [][]string) (int, int, error) {
+			return u.warehouseStockRepository.SetOpeningBalances(ctx, balancesOf(batch), createdBy)
+		},
+		preview: func(ctx context.Context, batch []openingBalanceRow, _ [][]string) error {
+			return u.warehouseStockRepository.PreviewOpeningBalances(ctx, balancesOf(batch), pending)
+		},
+	}, nil
+}
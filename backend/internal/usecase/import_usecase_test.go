@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/pkg/spreadsheet"
+)
+
+func TestRunImportLeavesBlankCellsOutOfTheUpdate(t *testing.T) {
+	reader, err := spreadsheet.NewReader(strings.NewReader(
+		"name,managerEmail,capacity\n"+
+			"North,north@example.com,500\n"+
+			"South,,\n"+
+			"East,  ,250\n",
+	), spreadsheet.FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var committed [][]string
+	spec := (&ImportUseCase{}).warehouseSpec()
+	spec.commit = func(ctx context.Context, batch []domain.Warehouse, dbColumns [][]string) (int, int, error) {
+		committed = append(committed, dbColumns...)
+		return 0, len(batch), nil
+	}
+
+	result, err := runImport(context.Background(), reader, spec, domain.ImportOptions{BatchSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Failed != 0 {
+		t.Fatalf("failed rows: %+v", result.Errors)
+	}
+
+	want := [][]string{
+		{"name", "manager_email", "capacity"},
+		{"name"},
+		{"name", "capacity"},
+	}
+	if !reflect.DeepEqual(committed, want) {
+		t.Errorf("columns written = %v, want %v", committed, want)
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// observeStockOperation records the outcome of a stock operation: the movements it created when
+// it succeeded, or a rejection against the warehouse when it ran out of stock or capacity, which
+// is also logged with the request ID from ctx and recorded on its span
+func observeStockOperation(ctx context.Context, warehouseUUID string, movementType domain.StockMovementType, movements int, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("warehouse.uuid", warehouseUUID), attribute.Int("stock.movements", movements))
+	if err == nil {
+		if movements > 0 {
+			metrics.MovementsCreated.WithLabelValues(string(movementType)).Add(float64(movements))
+		}
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	switch code := domain.ErrorCodeOf(err); code {
+	case domain.ErrorCodeInsufficientStock, domain.ErrorCodeCapacityExceeded:
+		metrics.StockRejections.WithLabelValues(string(code), warehouseUUID).Inc()
+		slog.InfoContext(ctx, "Stock operation rejected", "reason", string(code), "warehouse_uuid", warehouseUUID, "error", err)
+	}
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/repository"
+)
+
+// NotificationMailer sends one email to a warehouse manager
+type NotificationMailer interface {
+	Send(ctx context.Context, email *domain.NotificationEmail) error
+}
+
+// NotificationPolicy controls which events become notifications and how Run sends them
+type NotificationPolicy struct {
+	DedupWindow     time.Duration // A manager hears about the same product or warehouse once per window
+	CapacityWarning int           // Percent of its capacity a warehouse fills to before its manager is warned
+	DailyHour       int           // Hour of the day, UTC, daily digests are sent
+	PollInterval    time.Duration // How often due notifications are looked for
+	BatchSize       int           // Notifications claimed at once
+	Timeout         time.Duration // How long sending an email may take
+	MaxAttempts     int           // Attempts before a notification is marked failed
+	Backoff         time.Duration // Wait before the first retry, doubling with every attempt
+	MaxBackoff      time.Duration // Longest wait between attempts
+}
+
+type NotificationUseCase struct {
+	notificationRepository   *repository.NotificationRepository
+	warehouseRepository      *repository.WarehouseRepository
+	warehouseStockRepository *repository.WarehouseStockRepository
+}
+
+func NewNotificationUseCase(notificationRepository *repository.NotificationRepository, warehouseRepository *repository.WarehouseRepository, warehouseStockRepository *repository.WarehouseStockRepository) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepository:   notificationRepository,
+		warehouseRepository:      warehouseRepository,
+		warehouseStockRepository: warehouseStockRepository,
+	}
+}
+
+// GetAll returns a page of the organisation's notification log, newest first
+func (n *NotificationUseCase) GetAll(ctx context.Context, filter domain.NotificationFilter, page, limit int) ([]domain.Notification, int64, error) {
+	ctx, span := startSpan(ctx, "NotificationUseCase.GetAll")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	filter.Recipient = normalizeEmail(filter.Recipient)
+	switch filter.Status {
+	case "", domain.NotificationPending, domain.NotificationSent, domain.NotificationFailed:
+	default:
+		return nil, 0, domain.ErrNotificationStatusInvalid
+	}
+	return n.notificationRepository.GetAll(ctx, filter, page, limit)
+}
+
+// GetPreferences returns the preferences managers have set; the others get the defaults
+func (n *NotificationUseCase) GetPreferences(ctx context.Context) ([]domain.NotificationPreference, error) {
+	ctx, span := startSpan(ctx, "NotificationUseCase.GetPreferences")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return n.notificationRepository.GetPreferences(ctx)
+}
+
+// GetPreference returns the manager's preference, or the defaults when none is set
+func (n *NotificationUseCase) GetPreference(ctx context.Context, email string) (*domain.NotificationPreference, error) {
+	ctx, span := startSpan(ctx, "NotificationUseCase.GetPreference")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return n.preference(ctx, normalizeEmail(email))
+}
+
+// SavePreference sets how the manager with the email address is notified
+func (n *NotificationUseCase) SavePreference(ctx context.Context, email string, preference *domain.NotificationPreference) error {
+	ctx, span := startSpan(ctx, "NotificationUseCase.SavePreference")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	preference.Email = normalizeEmail(email)
+	if preference.Frequency == "" {
+		preference.Frequency = domain.NotificationImmediate
+	}
+	if preference.Kinds == nil {
+		preference.Kinds = []domain.NotificationKind{}
+	}
+	if err := preference.Validate(); err != nil {
+		return err
+	}
+	return n.notificationRepository.SavePreference(ctx, preference)
+}
+
+// DeletePreference returns the manager to the defaults
+func (n *NotificationUseCase) DeletePreference(ctx context.Context, email string) error {
+	ctx, span := startSpan(ctx, "NotificationUseCase.DeletePreference")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return n.notificationRepository.DeletePreference(ctx, normalizeEmail(email))
+}
+
+func (n *NotificationUseCase) preference(ctx context.Context, email string) (*domain.NotificationPreference, error) {
+	preference, err := n.notificationRepository.GetPreference(ctx, email)
+	if domain.IsNotFound(err) {
+		return domain.DefaultNotificationPreference(email), nil
+	}
+	return preference, err
+}
+
+// Sink returns the outbox sink turning events into notifications for warehouse managers:
+// product.low_stock for the managers of the warehouses stocking the product, and movements
+// filling a warehouse past policy.CapacityWarning percent for its manager
+func (n *NotificationUseCase) Sink(policy NotificationPolicy) domain.EventSink {
+	return &notificationSink{notifications: n, policy: policy}
+}
+
+type notificationSink struct {
+	notifications *NotificationUseCase
+	policy        NotificationPolicy
+}
+
+func (s *notificationSink) Name() string {
+	return "notifications"
+}
+
+func (s *notificationSink) Deliver(ctx context.Context, event domain.Event) error {
+	switch event.Type {
+	case domain.EventProductLowStock, domain.EventMovementCreated:
+	default:
+		return nil
+	}
+	ctx, span := startSpan(ctx, "NotificationUseCase.Deliver")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ctx = domain.WithOrganization(ctx, event.OrganizationUUID)
+
+	if event.Type == domain.EventProductLowStock {
+		var product domain.Product
+		if err := decodeEventData(event, &product); err != nil {
+			return err
+		}
+		warehouses, err := s.notifications.warehouseRepository.GetStocking(ctx, product.UUID)
+		if err != nil {
+			return err
+		}
+		// A manager of several of them hears about the product once
+		managers := map[string]bool{}
+		for _, warehouse := range warehouses {
+			if managers[normalizeEmail(warehouse.ManagerEmail)] {
+				continue
+			}
+			managers[normalizeEmail(warehouse.ManagerEmail)] = true
+			if err := s.queue(ctx, warehouse.ManagerEmail, domain.Notification{
+				Kind:        domain.NotificationLowStock,
+				SubjectUUID: product.UUID,
+				SubjectName: product.Title,
+				SKU:         product.SKU,
+				Quantity:    product.Stock,
+				Threshold:   product.LowStockThreshold,
+				EventID:     event.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var movement domain.StockMovement
+	if err := decodeEventData(event, &movement); err != nil {
+		return err
+	}
+	if movement.Quantity <= 0 {
+		return nil
+	}
+	warehouse, err := s.notifications.warehouseRepository.GetByID(ctx, movement.WarehouseUUID)
+	if err != nil {
+		if domain.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if warehouse.Capacity <= 0 || !warehouse.IsActive {
+		return nil
+	}
+	total, err := s.notifications.warehouseStockRepository.GetTotalStockByWarehouse(ctx, warehouse.UUID)
+	if err != nil {
+		return err
+	}
+	if total*100 < warehouse.Capacity*s.policy.CapacityWarning {
+		return nil
+	}
+	return s.queue(ctx, warehouse.ManagerEmail, domain.Notification{
+		Kind:        domain.NotificationCapacityWarning,
+		SubjectUUID: warehouse.UUID,
+		SubjectName: warehouse.Name,
+		Quantity:    total,
+		Threshold:   warehouse.Capacity,
+		EventID:     event.ID,
+	})
+}
+
+// queue schedules the notification for the manager as their preference asks, unless they were
+// told about the same thing within the dedup window. Warehouses without a manager email are skipped.
+func (s *notificationSink) queue(ctx context.Context, email string, notification domain.Notification) error {
+	email = normalizeEmail(email)
+	if !strings.Contains(email, "@") {
+		return nil
+	}
+	preference, err := s.notifications.preference(ctx, email)
+	if err != nil {
+		return err
+	}
+	if !preference.Receives(notification.Kind) {
+		return nil
+	}
+
+	now := time.Now()
+	due := preference.Frequency.Due(now, s.policy.DailyHour)
+	notification.Recipient = email
+	notification.Frequency = preference.Frequency
+	notification.Status = domain.NotificationPending
+	notification.NextAttemptAt = &due
+	queued, err := s.notifications.notificationRepository.Queue(ctx, &notification, now.Add(-s.policy.DedupWindow))
+	if err != nil {
+		return err
+	}
+	result := "queued"
+	if !queued {
+		result = "duplicate"
+	}
+	metrics.NotificationsQueued.WithLabelValues(string(notification.Kind), result).Inc()
+	return nil
+}
+
+// Run emails due notifications of every organisation with mailer until ctx is cancelled, one
+// email per recipient for the notifications due together. Every replica may run it; each
+// notification is claimed by one of them at a time.
+func (n *NotificationUseCase) Run(ctx context.Context, mailer NotificationMailer, policy NotificationPolicy) {
+	ctx = domain.WithAllOrganizations(ctx)
+	ticker := time.NewTicker(policy.PollInterval)
+	defer ticker.Stop()
+	for {
+		// A full batch suggests more are waiting, so look again straight away
+		if n.sendDue(ctx, mailer, policy) < policy.BatchSize || ctx.Err() != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// sendDue sends one batch of due notifications, grouped into an email per recipient, and
+// returns how many notifications there were
+func (n *NotificationUseCase) sendDue(ctx context.Context, mailer NotificationMailer, policy NotificationPolicy) int {
+	// The lease outlasts an attempt, so a notification is only claimed again if its sender died
+	notifications, err := n.notificationRepository.ClaimDue(ctx, time.Now(), 2*policy.Timeout, policy.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Claiming notifications", "error", err)
+		}
+		return 0
+	}
+
+	var emails []*domain.NotificationEmail
+	byRecipient := map[string]*domain.NotificationEmail{}
+	for _, notification := range notifications {
+		key := notification.OrganizationUUID + "/" + notification.Recipient
+		email, ok := byRecipient[key]
+		if !ok {
+			email = &domain.NotificationEmail{To: notification.Recipient, Frequency: notification.Frequency}
+			byRecipient[key] = email
+			emails = append(emails, email)
+		}
+		email.Notifications = append(email.Notifications, notification)
+	}
+
+	var wg sync.WaitGroup
+	for _, email := range emails {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n.send(ctx, mailer, policy, email)
+		}()
+	}
+	wg.Wait()
+	return len(notifications)
+}
+
+// send makes one attempt at the email and records the outcome against its notifications
+func (n *NotificationUseCase) send(ctx context.Context, mailer NotificationMailer, policy NotificationPolicy, email *domain.NotificationEmail) {
+	ctx, span := startSpan(ctx, "NotificationUseCase.send")
+	defer span.End()
+
+	err := mailer.Send(ctx, email)
+	if ctx.Err() != nil {
+		// Shutting down; the lease runs out and the email is sent again
+		return
+	}
+
+	now := time.Now()
+	result := "sent"
+	for i := range email.Notifications {
+		notification := &email.Notifications[i]
+		notification.Attempts++
+		notification.Error = ""
+		if err == nil {
+			notification.Status = domain.NotificationSent
+			notification.SentAt = &now
+			notification.NextAttemptAt = nil
+			continue
+		}
+		result = "failed"
+		notification.Error = err.Error()
+		if notification.Attempts >= policy.MaxAttempts {
+			notification.Status = domain.NotificationFailed
+			notification.NextAttemptAt = nil
+		} else {
+			next := now.Add(retryBackoff(policy.Backoff, policy.MaxBackoff, notification.Attempts))
+			notification.NextAttemptAt = &next
+		}
+	}
+	metrics.NotificationEmails.WithLabelValues(result).Inc()
+	if err != nil {
+		slog.WarnContext(ctx, "Sending notification email failed", "recipient", email.To,
+			"notifications", len(email.Notifications), "error", err)
+	}
+
+	if err := n.notificationRepository.Save(ctx, email.Notifications); err != nil {
+		slog.ErrorContext(ctx, "Recording notifications", "recipient", email.To, "error", err)
+	}
+}
+
+// decodeEventData reads the event's data into v, whether it is still a record or the JSON the
+// outbox stored it as
+func decodeEventData(event domain.Event, v any) error {
+	data, ok := event.Data.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(event.Data); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
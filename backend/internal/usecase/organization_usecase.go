@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/repository"
+)
+
+type OrganizationUseCase struct {
+	organizationRepository *repository.OrganizationRepository
+}
+
+func NewOrganizationUseCase(organizationRepository *repository.OrganizationRepository) *OrganizationUseCase {
+	return &OrganizationUseCase{organizationRepository: organizationRepository}
+}
+
+func (o *OrganizationUseCase) Create(ctx context.Context, organization *domain.Organization) error {
+	ctx, span := startSpan(ctx, "OrganizationUseCase.Create")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	organization.Name = strings.TrimSpace(organization.Name)
+	if err := organization.Validate(); err != nil {
+		return err
+	}
+	return o.organizationRepository.Create(ctx, organization)
+}
+
+func (o *OrganizationUseCase) GetAll(ctx context.Context) ([]domain.Organization, error) {
+	ctx, span := startSpan(ctx, "OrganizationUseCase.GetAll")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return o.organizationRepository.GetAll(ctx)
+}
+
+func (o *OrganizationUseCase) GetByID(ctx context.Context, uuid string) (*domain.Organization, error) {
+	ctx, span := startSpan(ctx, "OrganizationUseCase.GetByID")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return o.organizationRepository.GetByID(ctx, uuid)
+}
+
+// Update renames the organisation
+func (o *OrganizationUseCase) Update(ctx context.Context, uuid string, organization *domain.Organization) error {
+	ctx, span := startSpan(ctx, "OrganizationUseCase.Update")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	existing, err := o.organizationRepository.GetByID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	organization.UUID = existing.UUID
+	organization.CreatedAt = existing.CreatedAt
+	organization.Name = strings.TrimSpace(organization.Name)
+	if err := organization.Validate(); err != nil {
+		return err
+	}
+	return o.organizationRepository.Update(ctx, uuid, organization)
+}
+
+// checkOrganization resolves an empty organisation to the default one and fails with
+// ErrOrganizationInvalid when the organisation does not exist
+func checkOrganization(ctx context.Context, organizationRepository *repository.OrganizationRepository, organizationUUID *string) error {
+	if *organizationUUID == "" {
+		*organizationUUID = domain.DefaultOrganizationUUID
+	}
+	if _, err := uuid.Parse(*organizationUUID); err != nil {
+		return domain.ErrOrganizationInvalid
+	}
+	if _, err := organizationRepository.GetByID(ctx, *organizationUUID); err != nil {
+		if domain.IsNotFound(err) {
+			return domain.ErrOrganizationInvalid
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/repository"
+)
+
+// OutboxPolicy controls how Run dispatches the outbox
+type OutboxPolicy struct {
+	PollInterval time.Duration // How often undelivered events are looked for
+	BatchSize    int           // Events dispatched per transaction
+	Backoff      time.Duration // Wait before retrying an event a sink failed, doubling with every attempt
+	MaxBackoff   time.Duration // Longest wait between attempts
+	Retention    time.Duration // How long delivered events are kept before they are deleted
+}
+
+// outboxCleanupInterval is how often delivered events past their retention are deleted
+const outboxCleanupInterval = time.Minute
+
+type OutboxUseCase struct {
+	outboxRepository *repository.OutboxRepository
+}
+
+func NewOutboxUseCase(outboxRepository *repository.OutboxRepository) *OutboxUseCase {
+	return &OutboxUseCase{outboxRepository: outboxRepository}
+}
+
+// Run delivers outbox events to every sink until ctx is cancelled. Every replica may run it;
+// one of them dispatches at a time. An event counts as delivered once every sink has taken it;
+// otherwise all of them get it again after a backoff, and the later events of its aggregate wait.
+func (o *OutboxUseCase) Run(ctx context.Context, sinks []domain.EventSink, policy OutboxPolicy) {
+	ticker := time.NewTicker(policy.PollInterval)
+	defer ticker.Stop()
+	var lastCleanup time.Time
+	for {
+		if time.Since(lastCleanup) >= outboxCleanupInterval {
+			o.deleteDelivered(ctx, policy.Retention)
+			lastCleanup = time.Now()
+		}
+		// A full batch suggests more are waiting, so look again straight away
+		if o.dispatch(ctx, sinks, policy) < policy.BatchSize || ctx.Err() != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// dispatch delivers one batch of events and returns how many there were
+func (o *OutboxUseCase) dispatch(ctx context.Context, sinks []domain.EventSink, policy OutboxPolicy) int {
+	ctx, span := startSpan(ctx, "OutboxUseCase.dispatch")
+	defer span.End()
+
+	count, err := o.outboxRepository.Dispatch(ctx, time.Now(), policy.BatchSize, func(messages []domain.OutboxMessage) []domain.OutboxMessage {
+		// Aggregates with an event that failed in this batch, whose later events must wait for it
+		failed := map[string]bool{}
+		outcomes := make([]domain.OutboxMessage, 0, len(messages))
+		for _, message := range messages {
+			aggregate := message.AggregateType + "/" + message.AggregateID
+			if failed[aggregate] {
+				continue
+			}
+			now := time.Now()
+			if err := deliverEvent(ctx, sinks, message.Event()); err != nil {
+				failed[aggregate] = true
+				message.Attempts++
+				message.LastError = err.Error()
+				message.NextAttemptAt = now.Add(retryBackoff(policy.Backoff, policy.MaxBackoff, message.Attempts))
+				slog.WarnContext(ctx, "Outbox delivery failed", "event_id", message.EventID, "event_type", string(message.EventType),
+					"attempts", message.Attempts, "error", err)
+			} else {
+				message.DeliveredAt = &now
+			}
+			outcomes = append(outcomes, message)
+		}
+		return outcomes
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Dispatching the outbox", "error", err)
+		}
+		return 0
+	}
+	return count
+}
+
+// deliverEvent hands the event to every sink, returning the first failure once all have been tried
+func deliverEvent(ctx context.Context, sinks []domain.EventSink, event domain.Event) error {
+	var failure error
+	for _, sink := range sinks {
+		if err := sink.Deliver(ctx, event); err != nil {
+			metrics.OutboxDeliveries.WithLabelValues(sink.Name(), "failed").Inc()
+			if failure == nil {
+				failure = fmt.Errorf("%s: %w", sink.Name(), err)
+			}
+			continue
+		}
+		metrics.OutboxDeliveries.WithLabelValues(sink.Name(), "succeeded").Inc()
+	}
+	return failure
+}
+
+// deleteDelivered removes events delivered longer ago than retention
+func (o *OutboxUseCase) deleteDelivered(ctx context.Context, retention time.Duration) {
+	deleted, err := o.outboxRepository.DeleteDelivered(ctx, time.Now().Add(-retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Deleting delivered outbox events", "error", err)
+		}
+		return
+	}
+	metrics.OutboxDeleted.Add(float64(deleted))
+}
+
+// maxNotifyPayload is the largest payload Postgres accepts for a notification
+const maxNotifyPayload = 8000
+
+// NotifySink returns a sink publishing every event as JSON on a Postgres NOTIFY channel, so other
+// services can LISTEN for them without a separate message broker. Events too large for a
+// notification are sent without their data.
+func (o *OutboxUseCase) NotifySink(channel string) domain.EventSink {
+	return &notifySink{outboxRepository: o.outboxRepository, channel: channel}
+}
+
+type notifySink struct {
+	outboxRepository *repository.OutboxRepository
+	channel          string
+}
+
+func (n *notifySink) Name() string {
+	return "notify"
+}
+
+func (n *notifySink) Deliver(ctx context.Context, event domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		event.Data = nil
+		if payload, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+	return n.outboxRepository.Notify(ctx, n.channel, string(payload))
+}
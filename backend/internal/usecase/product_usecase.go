@@ -23,6 +23,8 @@ func NewProductUseCase(productRepository *repository.ProductRepository, warehous
 }
 
 func (p *ProductUseCase) Create(ctx context.Context, product *domain.Product) error {
+	ctx, span := startSpan(ctx, "ProductUseCase.Create")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -39,22 +41,36 @@ func (p *ProductUseCase) Create(ctx context.Context, product *domain.Product) er
 	return p.productRepository.Create(ctx, product)
 }
 
-func (p *ProductUseCase) GetAll(ctx context.Context) ([]domain.Product, error) {
+// GetAll returns the products matching filter, the first limit of them when limit is positive
+func (p *ProductUseCase) GetAll(ctx context.Context, filter domain.ProductFilter, limit int) ([]domain.Product, error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.GetAll")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	return p.productRepository.GetAll(ctx)
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if limit > 0 {
+		return p.productRepository.GetAllPaginated(ctx, filter, 1, limit)
+	}
+	return p.productRepository.GetAll(ctx, filter)
 }
 
-func (p *ProductUseCase) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.Product, int64, error) {
+func (p *ProductUseCase) GetAllPaginated(ctx context.Context, filter domain.ProductFilter, page, limit int) ([]domain.Product, int64, error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.GetAllPaginated")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	products, err := p.productRepository.GetAllPaginated(ctx, page, limit)
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+	products, err := p.productRepository.GetAllPaginated(ctx, filter, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
-	total, err := p.productRepository.Count(ctx)
+	total, err := p.productRepository.CountFiltered(ctx, filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -62,6 +78,8 @@ func (p *ProductUseCase) GetAllPaginated(ctx context.Context, page, limit int) (
 }
 
 func (p *ProductUseCase) GetById(ctx context.Context, uuid string) (*domain.Product, error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.GetById")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -69,6 +87,8 @@ func (p *ProductUseCase) GetById(ctx context.Context, uuid string) (*domain.Prod
 }
 
 func (p *ProductUseCase) Update(ctx context.Context, uuid string, product *domain.Product) error {
+	ctx, span := startSpan(ctx, "ProductUseCase.Update")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -97,18 +117,24 @@ func (p *ProductUseCase) Update(ctx context.Context, uuid string, product *domai
 }
 
 func (p *ProductUseCase) Delete(ctx context.Context, uuid string) error {
+	ctx, span := startSpan(ctx, "ProductUseCase.Delete")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	return p.productRepository.Delete(ctx, uuid)
 }
 
 func (p *ProductUseCase) GetTopByStock(ctx context.Context, limit int) ([]domain.Product, error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.GetTopByStock")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	return p.productRepository.GetTopByStock(ctx, limit)
 }
 
 func (p *ProductUseCase) GetTopByPrice(ctx context.Context, limit int) ([]domain.Product, error) {
+	ctx, span := startSpan(ctx, "ProductUseCase.GetTopByPrice")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	return p.productRepository.GetTopByPrice(ctx, limit)
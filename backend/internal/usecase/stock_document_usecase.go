@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/repository"
+)
+
+type StockDocumentUseCase struct {
+	stockDocumentRepository *repository.StockDocumentRepository
+	warehouseRepository     *repository.WarehouseRepository
+	productRepository       *repository.ProductRepository
+}
+
+func NewStockDocumentUseCase(
+	stockDocumentRepository *repository.StockDocumentRepository,
+	warehouseRepository *repository.WarehouseRepository,
+	productRepository *repository.ProductRepository,
+) *StockDocumentUseCase {
+	return &StockDocumentUseCase{
+		stockDocumentRepository: stockDocumentRepository,
+		warehouseRepository:     warehouseRepository,
+		productRepository:       productRepository,
+	}
+}
+
+func (s *StockDocumentUseCase) CreateReceipt(ctx context.Context, document *domain.StockDocument) error {
+	ctx, span := startSpan(ctx, "StockDocumentUseCase.CreateReceipt")
+	defer span.End()
+	document.DocumentType = domain.StockDocumentReceipt
+	document.CustomerName = ""
+	return s.post(ctx, document)
+}
+
+func (s *StockDocumentUseCase) CreateShipment(ctx context.Context, document *domain.StockDocument) error {
+	ctx, span := startSpan(ctx, "StockDocumentUseCase.CreateShipment")
+	defer span.End()
+	document.DocumentType = domain.StockDocumentShipment
+	document.SupplierUUID = ""
+	return s.post(ctx, document)
+}
+
+// post validates the whole document up front and then posts it atomically:
+// either every line is applied or none is
+func (s *StockDocumentUseCase) post(ctx context.Context, document *domain.StockDocument) (err error) {
+	ctx, span := startSpan(ctx, "StockDocumentUseCase.post")
+	defer span.End()
+	movementType := domain.MovementTypeStockIn
+	if document.DocumentType == domain.StockDocumentShipment {
+		movementType = domain.MovementTypeStockOut
+	}
+	defer func() { observeStockOperation(ctx, document.WarehouseUUID, movementType, len(document.Lines), err) }()
+
+	if err := domain.CheckWarehouseAccess(ctx, document.WarehouseUUID); err != nil {
+		return err
+	}
+
+	document.ReferenceNumber = strings.TrimSpace(document.ReferenceNumber)
+	if err := document.Validate(); err != nil {
+		return err
+	}
+
+	exists, err := s.stockDocumentRepository.ExistsByReference(ctx, document.DocumentType, document.ReferenceNumber)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrStockDocumentDuplicate
+	}
+
+	if _, err := s.warehouseRepository.GetByID(ctx, document.WarehouseUUID); err != nil {
+		return err
+	}
+
+	productUUIDs := make([]string, 0, len(document.Lines))
+	for _, line := range document.Lines {
+		productUUIDs = append(productUUIDs, line.ProductUUID)
+	}
+	products, err := s.productRepository.GetByUUIDs(ctx, productUUIDs)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool, len(products))
+	for _, product := range products {
+		found[product.UUID] = true
+	}
+
+	document.TotalQuantity = 0
+	for i, line := range document.Lines {
+		if !found[line.ProductUUID] {
+			return &domain.StockDocumentLineError{Line: i + 1, Err: domain.ErrProductNotFound}
+		}
+		document.TotalQuantity += line.Quantity
+	}
+
+	return s.stockDocumentRepository.Post(ctx, document)
+}
+
+func (s *StockDocumentUseCase) GetAllPaginated(ctx context.Context, documentType domain.StockDocumentType, warehouseUUID string, page, limit int) ([]domain.StockDocument, int64, error) {
+	ctx, span := startSpan(ctx, "StockDocumentUseCase.GetAllPaginated")
+	defer span.End()
+	documents, err := s.stockDocumentRepository.GetAllPaginated(ctx, documentType, warehouseUUID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.stockDocumentRepository.Count(ctx, documentType, warehouseUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return documents, total, nil
+}
+
+func (s *StockDocumentUseCase) GetByID(ctx context.Context, documentType domain.StockDocumentType, uuid string) (*domain.StockDocument, error) {
+	ctx, span := startSpan(ctx, "StockDocumentUseCase.GetByID")
+	defer span.End()
+	return s.stockDocumentRepository.GetByID(ctx, documentType, uuid)
+}
@@ -9,490 +9,269 @@ import (
 )
 
 type StockMovementUseCase struct {
-	stockMovementRepository  *repository.StockMovementRepository
-	warehouseStockRepository *repository.WarehouseStockRepository
-	warehouseRepository      *repository.WarehouseRepository
-	productRepository        *repository.ProductRepository
+	stockMovementRepository *repository.StockMovementRepository
 }
 
 func NewStockMovementUseCase(
 	stockMovementRepository *repository.StockMovementRepository,
-	warehouseStockRepository *repository.WarehouseStockRepository,
-	warehouseRepository *repository.WarehouseRepository,
-	productRepository *repository.ProductRepository,
 ) *StockMovementUseCase {
 	return &StockMovementUseCase{
-		stockMovementRepository:  stockMovementRepository,
-		warehouseStockRepository: warehouseStockRepository,
-		warehouseRepository:      warehouseRepository,
-		productRepository:        productRepository,
+		stockMovementRepository: stockMovementRepository,
 	}
 }
 
 // CreateMovement creates a stock movement and updates warehouse stock
-func (s *StockMovementUseCase) CreateMovement(ctx context.Context, movement *domain.StockMovement) error {
-	// If movement is positive (receiving stock), check warehouse capacity
-	if movement.Quantity > 0 {
-		// Get warehouse to check capacity
-		warehouse, err := s.warehouseRepository.GetByID(ctx, movement.WarehouseUUID)
-		if err != nil {
-			return err
-		}
-
-		// Get current total stock in warehouse (capacity usage)
-		currentTotalStock, err := s.warehouseStockRepository.GetTotalStockByWarehouse(ctx, movement.WarehouseUUID)
-		if err != nil {
-			return err
-		}
-
-		// Check warehouse capacity if it's set (> 0)
-		if warehouse.Capacity > 0 {
-			newTotalStock := currentTotalStock + movement.Quantity
-			if newTotalStock > warehouse.Capacity {
-				return domain.ErrWarehouseCapacityExceeded
-			}
-		}
-	}
+func (s *StockMovementUseCase) CreateMovement(ctx context.Context, movement *domain.StockMovement) (err error) {
+	ctx, span := startSpan(ctx, "StockMovementUseCase.CreateMovement")
+	defer span.End()
+	defer func() { observeStockOperation(ctx, movement.WarehouseUUID, movement.MovementType, 1, err) }()
 
-	// Get current warehouse stock
-	warehouseStock, err := s.warehouseStockRepository.GetByProductAndWarehouse(ctx, movement.ProductUUID, movement.WarehouseUUID)
-	if err != nil {
+	if err := domain.CheckWarehouseAccess(ctx, movement.WarehouseUUID); err != nil {
 		return err
 	}
-
-	var previousQty int
-	if warehouseStock != nil {
-		previousQty = warehouseStock.Quantity
-	} else {
-		previousQty = 0
-	}
-
-	// Calculate new quantity
-	newQty := previousQty + movement.Quantity
-	if newQty < 0 {
-		return domain.ErrInsufficientStock
-	}
-
-	movement.PreviousQty = previousQty
-	movement.NewQty = newQty
-
-	// Create movement record
-	if err := s.stockMovementRepository.Create(ctx, movement); err != nil {
-		return err
-	}
-
-	// Update warehouse stock
-	if warehouseStock == nil {
-		warehouseStock = &domain.WarehouseStock{
-			ProductUUID:   movement.ProductUUID,
-			WarehouseUUID: movement.WarehouseUUID,
-			Quantity:      newQty,
+	if movement.ToWarehouseUUID != "" {
+		if err := domain.CheckWarehouseAccess(ctx, movement.ToWarehouseUUID); err != nil {
+			return err
 		}
-	} else {
-		warehouseStock.Quantity = newQty
 	}
 
-	if err := s.warehouseStockRepository.CreateOrUpdate(ctx, warehouseStock); err != nil {
-		return err
-	}
-
-	// Update warehouse's updated_at timestamp to trigger real-time updates
-	// This ensures warehouse utilization changes are detected by the subscription
-	warehouse, err := s.warehouseRepository.GetByID(ctx, movement.WarehouseUUID)
-	if err != nil {
-		return err
-	}
-	warehouse.UpdatedAt = time.Now()
-	if err := s.warehouseRepository.Update(ctx, movement.WarehouseUUID, warehouse); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (s *StockMovementUseCase) GetAll(ctx context.Context, limit int) ([]domain.StockMovement, error) {
-	return s.stockMovementRepository.GetAll(ctx, limit)
-}
-
-func (s *StockMovementUseCase) GetByWarehouse(ctx context.Context, warehouseUUID string, limit int) ([]domain.StockMovement, error) {
-	return s.stockMovementRepository.GetByWarehouse(ctx, warehouseUUID, limit)
-}
-
-func (s *StockMovementUseCase) GetByProduct(ctx context.Context, productUUID string, limit int) ([]domain.StockMovement, error) {
-	return s.stockMovementRepository.GetByProduct(ctx, productUUID, limit)
-}
-
-func (s *StockMovementUseCase) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]domain.StockMovement, error) {
-	return s.stockMovementRepository.GetByDateRange(ctx, startDate, endDate)
+	// The warehouse stock and the movement are written in one transaction, which also checks
+	// capacity when stock is added and that there is enough when it is taken
+	return s.stockMovementRepository.Post(ctx, movement)
 }
 
-func (s *StockMovementUseCase) GetByType(ctx context.Context, movementType domain.StockMovementType, limit int) ([]domain.StockMovement, error) {
-	return s.stockMovementRepository.GetByType(ctx, movementType, limit)
+// List returns up to limit movements matching the filter; a limit of 0 returns every match
+func (s *StockMovementUseCase) List(ctx context.Context, filter domain.StockMovementFilter, sorts []domain.StockMovementSort, limit int) ([]domain.StockMovement, error) {
+	ctx, span := startSpan(ctx, "StockMovementUseCase.List")
+	defer span.End()
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.stockMovementRepository.GetFiltered(ctx, filter, sorts, 1, limit)
 }
 
-func (s *StockMovementUseCase) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.StockMovement, int64, error) {
-	movements, err := s.stockMovementRepository.GetAllPaginated(ctx, page, limit)
-	if err != nil {
+func (s *StockMovementUseCase) ListPaginated(ctx context.Context, filter domain.StockMovementFilter, sorts []domain.StockMovementSort, page, limit int) ([]domain.StockMovement, int64, error) {
+	ctx, span := startSpan(ctx, "StockMovementUseCase.ListPaginated")
+	defer span.End()
+	if err := filter.Validate(); err != nil {
 		return nil, 0, err
 	}
-	total, err := s.stockMovementRepository.Count(ctx)
-	if err != nil {
-		return nil, 0, err
-	}
-	return movements, total, nil
-}
-
-func (s *StockMovementUseCase) GetByTypePaginated(ctx context.Context, movementType domain.StockMovementType, page, limit int) ([]domain.StockMovement, int64, error) {
-	movements, err := s.stockMovementRepository.GetByTypePaginated(ctx, movementType, page, limit)
+	movements, err := s.stockMovementRepository.GetFiltered(ctx, filter, sorts, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
-	total, err := s.stockMovementRepository.CountByType(ctx, movementType)
+	total, err := s.stockMovementRepository.CountFiltered(ctx, filter)
 	if err != nil {
 		return nil, 0, err
 	}
 	return movements, total, nil
 }
 
-type StockInUseCase struct {
-	stockInRepository        *repository.StockInRepository
-	stockMovementRepository  *repository.StockMovementRepository
-	warehouseStockRepository *repository.WarehouseStockRepository
-	warehouseRepository      *repository.WarehouseRepository
-	productRepository        *repository.ProductRepository
-}
-
-func NewStockInUseCase(
-	stockInRepository *repository.StockInRepository,
-	stockMovementRepository *repository.StockMovementRepository,
-	warehouseStockRepository *repository.WarehouseStockRepository,
-	warehouseRepository *repository.WarehouseRepository,
-	productRepository *repository.ProductRepository,
-) *StockInUseCase {
-	return &StockInUseCase{
-		stockInRepository:        stockInRepository,
-		stockMovementRepository:  stockMovementRepository,
-		warehouseStockRepository: warehouseStockRepository,
-		warehouseRepository:      warehouseRepository,
-		productRepository:        productRepository,
+// GetPage returns one cursor-paginated page of the ledger. An empty cursor starts at the newest
+// movement; withTotal adds an approximate count of all matching movements.
+func (s *StockMovementUseCase) GetPage(ctx context.Context, filter domain.StockMovementFilter, cursorValue string, limit int, withTotal bool) (*domain.StockMovementPage, error) {
+	ctx, span := startSpan(ctx, "StockMovementUseCase.GetPage")
+	defer span.End()
+	if err := filter.Validate(); err != nil {
+		return nil, err
 	}
-}
 
-func (s *StockInUseCase) Create(ctx context.Context, stockIn *domain.StockIn) error {
-	// Get warehouse to check capacity
-	warehouse, err := s.warehouseRepository.GetByID(ctx, stockIn.WarehouseUUID)
-	if err != nil {
-		return err
+	var cursor *domain.StockMovementCursor
+	if cursorValue != "" {
+		decoded, err := domain.DecodeStockMovementCursor(cursorValue)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
 	}
 
-	// Get current total stock in warehouse (capacity usage)
-	currentTotalStock, err := s.warehouseStockRepository.GetTotalStockByWarehouse(ctx, stockIn.WarehouseUUID)
+	movements, hasMore, err := s.stockMovementRepository.GetPageByCursor(ctx, filter, cursor, limit)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Check warehouse capacity if it's set (> 0)
-	if warehouse.Capacity > 0 {
-		newTotalStock := currentTotalStock + stockIn.Quantity
-		if newTotalStock > warehouse.Capacity {
-			return domain.ErrWarehouseCapacityExceeded
+	page := &domain.StockMovementPage{Items: movements}
+	if len(movements) > 0 {
+		first, last := movements[0], movements[len(movements)-1]
+		backward := cursor != nil && cursor.Backward
+		// Going forward there is always something newer unless this is the first page;
+		// going backward there is always something older, since we came from there
+		if hasMore || backward {
+			page.NextCursor = domain.StockMovementCursor{MovementDate: last.MovementDate, CreatedAt: last.CreatedAt, UUID: last.UUID}.Encode()
+		}
+		if (hasMore && backward) || (cursor != nil && !backward) {
+			page.PrevCursor = domain.StockMovementCursor{MovementDate: first.MovementDate, CreatedAt: first.CreatedAt, UUID: first.UUID, Backward: true}.Encode()
 		}
 	}
 
-	// Create stock in record
-	if err := s.stockInRepository.Create(ctx, stockIn); err != nil {
-		return err
-	}
-
-	// Get current warehouse stock
-	warehouseStock, err := s.warehouseStockRepository.GetByProductAndWarehouse(ctx, stockIn.ProductUUID, stockIn.WarehouseUUID)
-	if err != nil {
-		return err
-	}
-
-	var previousQty int
-	if warehouseStock != nil {
-		previousQty = warehouseStock.Quantity
-	} else {
-		previousQty = 0
+	if withTotal {
+		total, err := s.stockMovementRepository.EstimateCount(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
 	}
+	return page, nil
+}
 
-	newQty := previousQty + stockIn.Quantity
+type StockInUseCase struct {
+	stockInRepository *repository.StockInRepository
+}
 
-	// Update warehouse stock
-	if warehouseStock == nil {
-		warehouseStock = &domain.WarehouseStock{
-			ProductUUID:   stockIn.ProductUUID,
-			WarehouseUUID: stockIn.WarehouseUUID,
-			Quantity:      newQty,
-		}
-	} else {
-		warehouseStock.Quantity = newQty
+func NewStockInUseCase(
+	stockInRepository *repository.StockInRepository,
+) *StockInUseCase {
+	return &StockInUseCase{
+		stockInRepository: stockInRepository,
 	}
+}
 
-	if err := s.warehouseStockRepository.CreateOrUpdate(ctx, warehouseStock); err != nil {
-		return err
-	}
+func (s *StockInUseCase) Create(ctx context.Context, stockIn *domain.StockIn) (err error) {
+	ctx, span := startSpan(ctx, "StockInUseCase.Create")
+	defer span.End()
+	defer func() { observeStockOperation(ctx, stockIn.WarehouseUUID, domain.MovementTypeStockIn, 1, err) }()
 
-	// Update warehouse's updated_at timestamp to trigger real-time updates
-	warehouse.UpdatedAt = time.Now()
-	if err := s.warehouseRepository.Update(ctx, stockIn.WarehouseUUID, warehouse); err != nil {
+	if err := domain.CheckWarehouseAccess(ctx, stockIn.WarehouseUUID); err != nil {
 		return err
 	}
 
-	// Create movement record
+	// The record, the warehouse stock and the movement are written in one transaction, which
+	// also checks capacity
 	movement := &domain.StockMovement{
 		ProductUUID:     stockIn.ProductUUID,
 		WarehouseUUID:   stockIn.WarehouseUUID,
 		MovementType:    domain.MovementTypeStockIn,
 		Quantity:        stockIn.Quantity,
-		PreviousQty:     previousQty,
-		NewQty:          newQty,
 		ReferenceNumber: stockIn.PurchaseOrderNo,
 		Notes:           stockIn.Notes,
 		CreatedBy:       stockIn.ReceivedBy,
 		MovementDate:    stockIn.ReceivedDate,
 	}
-	if err := s.stockMovementRepository.Create(ctx, movement); err != nil {
-		return err
-	}
-
-	return nil
+	return s.stockInRepository.Post(ctx, stockIn, movement)
 }
 
 func (s *StockInUseCase) GetAll(ctx context.Context) ([]domain.StockIn, error) {
+	ctx, span := startSpan(ctx, "StockInUseCase.GetAll")
+	defer span.End()
 	return s.stockInRepository.GetAll(ctx)
 }
 
 func (s *StockInUseCase) GetByWarehouse(ctx context.Context, warehouseUUID string) ([]domain.StockIn, error) {
+	ctx, span := startSpan(ctx, "StockInUseCase.GetByWarehouse")
+	defer span.End()
 	return s.stockInRepository.GetByWarehouse(ctx, warehouseUUID)
 }
 
 func (s *StockInUseCase) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]domain.StockIn, error) {
+	ctx, span := startSpan(ctx, "StockInUseCase.GetByDateRange")
+	defer span.End()
 	return s.stockInRepository.GetByDateRange(ctx, startDate, endDate)
 }
 
 type StockOutUseCase struct {
-	stockOutRepository       *repository.StockOutRepository
-	stockMovementRepository  *repository.StockMovementRepository
-	warehouseStockRepository *repository.WarehouseStockRepository
-	warehouseRepository      *repository.WarehouseRepository
-	productRepository        *repository.ProductRepository
+	stockOutRepository *repository.StockOutRepository
 }
 
 func NewStockOutUseCase(
 	stockOutRepository *repository.StockOutRepository,
-	stockMovementRepository *repository.StockMovementRepository,
-	warehouseStockRepository *repository.WarehouseStockRepository,
-	warehouseRepository *repository.WarehouseRepository,
-	productRepository *repository.ProductRepository,
 ) *StockOutUseCase {
 	return &StockOutUseCase{
-		stockOutRepository:       stockOutRepository,
-		stockMovementRepository:  stockMovementRepository,
-		warehouseStockRepository: warehouseStockRepository,
-		warehouseRepository:      warehouseRepository,
-		productRepository:        productRepository,
+		stockOutRepository: stockOutRepository,
 	}
 }
 
-func (s *StockOutUseCase) Create(ctx context.Context, stockOut *domain.StockOut) error {
-	// Verify warehouse stock exists and has enough quantity
-	warehouseStock, err := s.warehouseStockRepository.GetByProductAndWarehouse(ctx, stockOut.ProductUUID, stockOut.WarehouseUUID)
-	if err != nil {
-		return err
-	}
-	if warehouseStock == nil {
-		return domain.ErrInsufficientStock
-	}
-
-	if warehouseStock.Quantity < stockOut.Quantity {
-		return domain.ErrInsufficientStock
-	}
+func (s *StockOutUseCase) Create(ctx context.Context, stockOut *domain.StockOut) (err error) {
+	ctx, span := startSpan(ctx, "StockOutUseCase.Create")
+	defer span.End()
+	defer func() { observeStockOperation(ctx, stockOut.WarehouseUUID, domain.MovementTypeStockOut, 1, err) }()
 
-	previousQty := warehouseStock.Quantity
-	newQty := previousQty - stockOut.Quantity
-
-	// Create stock out record
-	if err := s.stockOutRepository.Create(ctx, stockOut); err != nil {
+	if err := domain.CheckWarehouseAccess(ctx, stockOut.WarehouseUUID); err != nil {
 		return err
 	}
 
-	// Update warehouse stock
-	warehouseStock.Quantity = newQty
-	if err := s.warehouseStockRepository.CreateOrUpdate(ctx, warehouseStock); err != nil {
-		return err
-	}
-
-	// Update warehouse's updated_at timestamp to trigger real-time updates
-	warehouse, err := s.warehouseRepository.GetByID(ctx, stockOut.WarehouseUUID)
-	if err != nil {
-		return err
-	}
-	warehouse.UpdatedAt = time.Now()
-	if err := s.warehouseRepository.Update(ctx, stockOut.WarehouseUUID, warehouse); err != nil {
-		return err
-	}
-
-	// Create movement record
+	// The record, the warehouse stock and the movement are written in one transaction, which
+	// also checks there is enough stock
 	movement := &domain.StockMovement{
 		ProductUUID:     stockOut.ProductUUID,
 		WarehouseUUID:   stockOut.WarehouseUUID,
 		MovementType:    domain.MovementTypeStockOut,
 		Quantity:        -stockOut.Quantity, // Negative for out
-		PreviousQty:     previousQty,
-		NewQty:          newQty,
 		ReferenceNumber: stockOut.SalesOrderNo,
 		Notes:           stockOut.Notes,
 		CreatedBy:       stockOut.ShippedBy,
 		MovementDate:    stockOut.ShippedDate,
 	}
-	if err := s.stockMovementRepository.Create(ctx, movement); err != nil {
-		return err
-	}
-
-	return nil
+	return s.stockOutRepository.Post(ctx, stockOut, movement)
 }
 
 func (s *StockOutUseCase) GetAll(ctx context.Context) ([]domain.StockOut, error) {
+	ctx, span := startSpan(ctx, "StockOutUseCase.GetAll")
+	defer span.End()
 	return s.stockOutRepository.GetAll(ctx)
 }
 
 func (s *StockOutUseCase) GetByWarehouse(ctx context.Context, warehouseUUID string) ([]domain.StockOut, error) {
+	ctx, span := startSpan(ctx, "StockOutUseCase.GetByWarehouse")
+	defer span.End()
 	return s.stockOutRepository.GetByWarehouse(ctx, warehouseUUID)
 }
 
 func (s *StockOutUseCase) GetByDateRange(ctx context.Context, startDate, endDate time.Time) ([]domain.StockOut, error) {
+	ctx, span := startSpan(ctx, "StockOutUseCase.GetByDateRange")
+	defer span.End()
 	return s.stockOutRepository.GetByDateRange(ctx, startDate, endDate)
 }
 
 type StockAdjustmentUseCase struct {
-	adjustmentRepository     *repository.StockAdjustmentRepository
-	stockMovementRepository  *repository.StockMovementRepository
-	warehouseStockRepository *repository.WarehouseStockRepository
-	warehouseRepository      *repository.WarehouseRepository
-	productRepository        *repository.ProductRepository
+	adjustmentRepository *repository.StockAdjustmentRepository
 }
 
 func NewStockAdjustmentUseCase(
 	adjustmentRepository *repository.StockAdjustmentRepository,
-	stockMovementRepository *repository.StockMovementRepository,
-	warehouseStockRepository *repository.WarehouseStockRepository,
-	warehouseRepository *repository.WarehouseRepository,
-	productRepository *repository.ProductRepository,
 ) *StockAdjustmentUseCase {
 	return &StockAdjustmentUseCase{
-		adjustmentRepository:     adjustmentRepository,
-		stockMovementRepository:  stockMovementRepository,
-		warehouseStockRepository: warehouseStockRepository,
-		warehouseRepository:      warehouseRepository,
-		productRepository:        productRepository,
+		adjustmentRepository: adjustmentRepository,
 	}
 }
 
-func (s *StockAdjustmentUseCase) Create(ctx context.Context, adjustment *domain.StockAdjustment) error {
-	// If adjustment is positive (adding stock), check warehouse capacity
-	if adjustment.Quantity > 0 {
-		// Get warehouse to check capacity
-		warehouse, err := s.warehouseRepository.GetByID(ctx, adjustment.WarehouseUUID)
-		if err != nil {
-			return err
-		}
-
-		// Get current total stock in warehouse (capacity usage)
-		currentTotalStock, err := s.warehouseStockRepository.GetTotalStockByWarehouse(ctx, adjustment.WarehouseUUID)
-		if err != nil {
-			return err
-		}
-
-		// Check warehouse capacity if it's set (> 0)
-		if warehouse.Capacity > 0 {
-			newTotalStock := currentTotalStock + adjustment.Quantity
-			if newTotalStock > warehouse.Capacity {
-				return domain.ErrWarehouseCapacityExceeded
-			}
-		}
-	}
-
-	// Get current warehouse stock
-	warehouseStock, err := s.warehouseStockRepository.GetByProductAndWarehouse(ctx, adjustment.ProductUUID, adjustment.WarehouseUUID)
-	if err != nil {
-		return err
-	}
-
-	var previousQty int
-	if warehouseStock != nil {
-		previousQty = warehouseStock.Quantity
-	} else {
-		previousQty = 0
-	}
-
-	newQty := previousQty + adjustment.Quantity
-	if newQty < 0 {
-		newQty = 0
-	}
-
-	adjustment.PreviousQty = previousQty
-	adjustment.NewQty = newQty
+func (s *StockAdjustmentUseCase) Create(ctx context.Context, adjustment *domain.StockAdjustment) (err error) {
+	ctx, span := startSpan(ctx, "StockAdjustmentUseCase.Create")
+	defer span.End()
+	defer func() { observeStockOperation(ctx, adjustment.WarehouseUUID, domain.MovementTypeAdjustment, 1, err) }()
 
-	// Create adjustment record
-	if err := s.adjustmentRepository.Create(ctx, adjustment); err != nil {
+	if err := domain.CheckWarehouseAccess(ctx, adjustment.WarehouseUUID); err != nil {
 		return err
 	}
 
-	// Update warehouse stock
-	if warehouseStock == nil {
-		warehouseStock = &domain.WarehouseStock{
-			ProductUUID:   adjustment.ProductUUID,
-			WarehouseUUID: adjustment.WarehouseUUID,
-			Quantity:      newQty,
-		}
-	} else {
-		warehouseStock.Quantity = newQty
-	}
-
-	if err := s.warehouseStockRepository.CreateOrUpdate(ctx, warehouseStock); err != nil {
-		return err
-	}
-
-	// Update warehouse's updated_at timestamp to trigger real-time updates
-	warehouse, err := s.warehouseRepository.GetByID(ctx, adjustment.WarehouseUUID)
-	if err != nil {
-		return err
-	}
-	warehouse.UpdatedAt = time.Now()
-	if err := s.warehouseRepository.Update(ctx, adjustment.WarehouseUUID, warehouse); err != nil {
-		return err
-	}
-
-	// Create movement record
+	// The adjustment, the warehouse stock and the movement are written in one transaction, which
+	// also checks capacity when stock is added; stock taken away never goes below zero
 	movement := &domain.StockMovement{
 		ProductUUID:      adjustment.ProductUUID,
 		WarehouseUUID:    adjustment.WarehouseUUID,
 		MovementType:     domain.MovementTypeAdjustment,
 		Quantity:         adjustment.Quantity,
-		PreviousQty:      previousQty,
-		NewQty:           newQty,
 		AdjustmentReason: adjustment.Reason,
 		Notes:            adjustment.Notes,
 		CreatedBy:        adjustment.AdjustedBy,
 		MovementDate:     adjustment.AdjustmentDate,
 	}
-	if err := s.stockMovementRepository.Create(ctx, movement); err != nil {
-		return err
-	}
-
-	return nil
+	return s.adjustmentRepository.Post(ctx, adjustment, movement)
 }
 
 func (s *StockAdjustmentUseCase) GetAll(ctx context.Context) ([]domain.StockAdjustment, error) {
+	ctx, span := startSpan(ctx, "StockAdjustmentUseCase.GetAll")
+	defer span.End()
 	return s.adjustmentRepository.GetAll(ctx)
 }
 
 func (s *StockAdjustmentUseCase) GetByWarehouse(ctx context.Context, warehouseUUID string) ([]domain.StockAdjustment, error) {
+	ctx, span := startSpan(ctx, "StockAdjustmentUseCase.GetByWarehouse")
+	defer span.End()
 	return s.adjustmentRepository.GetByWarehouse(ctx, warehouseUUID)
 }
 
 func (s *StockAdjustmentUseCase) GetByReason(ctx context.Context, reason domain.AdjustmentReason) ([]domain.StockAdjustment, error) {
+	ctx, span := startSpan(ctx, "StockAdjustmentUseCase.GetByReason")
+	defer span.End()
 	return s.adjustmentRepository.GetByReason(ctx, reason)
 }
@@ -17,6 +17,8 @@ func NewSupplierUseCase(supplierRepository *repository.SupplierRepository) *Supp
 }
 
 func (s *SupplierUseCase) Create(ctx context.Context, supplier *domain.Supplier) error {
+	ctx, span := startSpan(ctx, "SupplierUseCase.Create")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -27,14 +29,22 @@ func (s *SupplierUseCase) Create(ctx context.Context, supplier *domain.Supplier)
 	return s.supplierRepository.Create(ctx, supplier)
 }
 
-func (s *SupplierUseCase) GetAll(ctx context.Context) ([]domain.Supplier, error) {
+// GetAll returns the suppliers, the first limit of them when limit is positive
+func (s *SupplierUseCase) GetAll(ctx context.Context, limit int) ([]domain.Supplier, error) {
+	ctx, span := startSpan(ctx, "SupplierUseCase.GetAll")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if limit > 0 {
+		return s.supplierRepository.GetAllPaginated(ctx, 1, limit)
+	}
 	return s.supplierRepository.GetAll(ctx)
 }
 
 func (s *SupplierUseCase) GetAllPaginated(ctx context.Context, page, limit int) ([]domain.Supplier, int64, error) {
+	ctx, span := startSpan(ctx, "SupplierUseCase.GetAllPaginated")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -50,6 +60,8 @@ func (s *SupplierUseCase) GetAllPaginated(ctx context.Context, page, limit int)
 }
 
 func (s *SupplierUseCase) GetByID(ctx context.Context, uuid string) (*domain.Supplier, error) {
+	ctx, span := startSpan(ctx, "SupplierUseCase.GetByID")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -57,6 +69,8 @@ func (s *SupplierUseCase) GetByID(ctx context.Context, uuid string) (*domain.Sup
 }
 
 func (s *SupplierUseCase) Update(ctx context.Context, uuid string, supplier *domain.Supplier) error {
+	ctx, span := startSpan(ctx, "SupplierUseCase.Update")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -76,6 +90,8 @@ func (s *SupplierUseCase) Update(ctx context.Context, uuid string, supplier *dom
 }
 
 func (s *SupplierUseCase) Delete(ctx context.Context, uuid string) error {
+	ctx, span := startSpan(ctx, "SupplierUseCase.Delete")
+	defer span.End()
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shirloin/stockhub/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts the span of a use-case method, named Type.Method, as a child of the
+// HTTP or gRPC request span in ctx; repository queries made with the returned context
+// become its children
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name)
+}
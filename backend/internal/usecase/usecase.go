@@ -11,6 +11,15 @@ type Usecases struct {
 	StockInUseCase         *StockInUseCase
 	StockOutUseCase        *StockOutUseCase
 	StockAdjustmentUseCase *StockAdjustmentUseCase
+	ImportUseCase          *ImportUseCase
+	ExportUseCase          *ExportUseCase
+	StockDocumentUseCase   *StockDocumentUseCase
+	APIKeyUseCase          *APIKeyUseCase
+	WebhookUseCase         *WebhookUseCase
+	OutboxUseCase          *OutboxUseCase
+	OrganizationUseCase    *OrganizationUseCase
+	NotificationUseCase    *NotificationUseCase
+	AlertUseCase           *AlertUseCase
 }
 
 func InitUsecases(repositories *repository.Repositories) *Usecases {
@@ -18,10 +27,19 @@ func InitUsecases(repositories *repository.Repositories) *Usecases {
 	categoryUsecase := NewCategoryUseCase(repositories.CategoryRepository)
 	supplierUsecase := NewSupplierUseCase(repositories.SupplierRepository)
 	warehouseUsecase := NewWarehouseUseCase(repositories.WarehouseRepository, repositories.WarehouseStockRepository, repositories.ProductRepository, repositories.StockMovementRepository)
-	stockMovementUseCase := NewStockMovementUseCase(repositories.StockMovementRepository, repositories.WarehouseStockRepository, repositories.WarehouseRepository, repositories.ProductRepository)
-	stockInUseCase := NewStockInUseCase(repositories.StockInRepository, repositories.StockMovementRepository, repositories.WarehouseStockRepository, repositories.WarehouseRepository, repositories.ProductRepository)
-	stockOutUseCase := NewStockOutUseCase(repositories.StockOutRepository, repositories.StockMovementRepository, repositories.WarehouseStockRepository, repositories.WarehouseRepository, repositories.ProductRepository)
-	stockAdjustmentUseCase := NewStockAdjustmentUseCase(repositories.StockAdjustmentRepository, repositories.StockMovementRepository, repositories.WarehouseStockRepository, repositories.WarehouseRepository, repositories.ProductRepository)
+	stockMovementUseCase := NewStockMovementUseCase(repositories.StockMovementRepository)
+	stockInUseCase := NewStockInUseCase(repositories.StockInRepository)
+	stockOutUseCase := NewStockOutUseCase(repositories.StockOutRepository)
+	stockAdjustmentUseCase := NewStockAdjustmentUseCase(repositories.StockAdjustmentRepository)
+	importUseCase := NewImportUseCase(repositories.ProductRepository, repositories.CategoryRepository, repositories.SupplierRepository, repositories.WarehouseRepository, repositories.WarehouseStockRepository)
+	stockDocumentUseCase := NewStockDocumentUseCase(repositories.StockDocumentRepository, repositories.WarehouseRepository, repositories.ProductRepository)
+	apiKeyUseCase := NewAPIKeyUseCase(repositories.APIKeyRepository, repositories.WarehouseRepository, repositories.OrganizationRepository)
+	webhookUseCase := NewWebhookUseCase(repositories.WebhookRepository, repositories.OrganizationRepository)
+	outboxUseCase := NewOutboxUseCase(repositories.OutboxRepository)
+	organizationUseCase := NewOrganizationUseCase(repositories.OrganizationRepository)
+	notificationUseCase := NewNotificationUseCase(repositories.NotificationRepository, repositories.WarehouseRepository, repositories.WarehouseStockRepository)
+	alertUseCase := NewAlertUseCase(repositories.AlertRepository, repositories.ProductRepository, repositories.WarehouseRepository)
+	exportUseCase := NewExportUseCase(repositories.StockMovementRepository, repositories.StockInRepository, repositories.StockOutRepository, repositories.StockAdjustmentRepository, repositories.WarehouseRepository, repositories.WarehouseStockRepository)
 
 	return &Usecases{
 		ProductUsecase:         productUsecase,
@@ -32,5 +50,14 @@ func InitUsecases(repositories *repository.Repositories) *Usecases {
 		StockInUseCase:         stockInUseCase,
 		StockOutUseCase:        stockOutUseCase,
 		StockAdjustmentUseCase: stockAdjustmentUseCase,
+		ImportUseCase:          importUseCase,
+		ExportUseCase:          exportUseCase,
+		StockDocumentUseCase:   stockDocumentUseCase,
+		APIKeyUseCase:          apiKeyUseCase,
+		WebhookUseCase:         webhookUseCase,
+		OutboxUseCase:          outboxUseCase,
+		OrganizationUseCase:    organizationUseCase,
+		NotificationUseCase:    notificationUseCase,
+		AlertUseCase:           alertUseCase,
 	}
 }
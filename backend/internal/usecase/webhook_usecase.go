@@ -0,0 +1,371 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+	"github.com/shirloin/stockhub/internal/metrics"
+	"github.com/shirloin/stockhub/internal/repository"
+	"github.com/shirloin/stockhub/internal/webhook"
+)
+
+// WebhookSender makes one attempt at sending a delivery
+type WebhookSender interface {
+	Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) domain.WebhookAttempt
+}
+
+// WebhookPolicy controls how Run delivers events
+type WebhookPolicy struct {
+	PollInterval time.Duration // How often due deliveries are looked for
+	BatchSize    int           // Deliveries attempted at once
+	Timeout      time.Duration // How long an attempt may take
+	MaxAttempts  int           // Attempts before a delivery is marked failed
+	Backoff      time.Duration // Wait before the first retry, doubling with every attempt
+	MaxBackoff   time.Duration // Longest wait between attempts
+	DisableAfter int           // Failed attempts in a row that disable a webhook; 0 never does
+}
+
+type WebhookUseCase struct {
+	webhookRepository      *repository.WebhookRepository
+	organizationRepository *repository.OrganizationRepository
+	AllowPrivateURLs       bool // Accept endpoints on loopback, private and link-local addresses
+}
+
+func NewWebhookUseCase(webhookRepository *repository.WebhookRepository, organizationRepository *repository.OrganizationRepository) *WebhookUseCase {
+	return &WebhookUseCase{webhookRepository: webhookRepository, organizationRepository: organizationRepository}
+}
+
+// Create subscribes a webhook, generating its secret unless one is given. The secret is only
+// returned here.
+func (w *WebhookUseCase) Create(ctx context.Context, webhook *domain.Webhook) error {
+	ctx, span := startSpan(ctx, "WebhookUseCase.Create")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+	webhook.Enabled = true
+	webhook.ConsecutiveFailures = 0
+	webhook.DisabledAt = nil
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+	if err := w.checkURL(ctx, webhook.URL); err != nil {
+		return err
+	}
+	if err := checkOrganization(ctx, w.organizationRepository, &webhook.OrganizationUUID); err != nil {
+		return err
+	}
+	return w.webhookRepository.Create(ctx, webhook)
+}
+
+func (w *WebhookUseCase) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.GetAll")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	webhooks, err := w.webhookRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+func (w *WebhookUseCase) GetByID(ctx context.Context, uuid string) (*domain.Webhook, error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.GetByID")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	webhook, err := w.webhookRepository.GetByID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// Update replaces the webhook's URL, description, event types and enabled flag, and its secret
+// when a new one is given; its organisation stays. Enabling a disabled webhook clears its failures, and its pending
+// deliveries resume.
+func (w *WebhookUseCase) Update(ctx context.Context, uuid string, webhook *domain.Webhook) error {
+	ctx, span := startSpan(ctx, "WebhookUseCase.Update")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	existing, err := w.webhookRepository.GetByID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	webhook.UUID = existing.UUID
+	webhook.OrganizationUUID = existing.OrganizationUUID
+	webhook.CreatedAt = existing.CreatedAt
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	if webhook.Secret == "" {
+		webhook.Secret = existing.Secret
+	}
+	if webhook.Enabled && !existing.Enabled {
+		webhook.ConsecutiveFailures = 0
+		webhook.DisabledAt = nil
+	} else {
+		webhook.ConsecutiveFailures = existing.ConsecutiveFailures
+		webhook.DisabledAt = existing.DisabledAt
+	}
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+	if err := w.checkURL(ctx, webhook.URL); err != nil {
+		return err
+	}
+	if err := w.webhookRepository.Update(ctx, webhook); err != nil {
+		return err
+	}
+	webhook.Secret = ""
+	return nil
+}
+
+func (w *WebhookUseCase) Delete(ctx context.Context, uuid string) error {
+	ctx, span := startSpan(ctx, "WebhookUseCase.Delete")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return w.webhookRepository.Delete(ctx, uuid)
+}
+
+// GetDeliveries returns a page of the webhook's delivery log, newest first
+func (w *WebhookUseCase) GetDeliveries(ctx context.Context, webhookUUID string, page, limit int) ([]domain.WebhookDelivery, int64, error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.GetDeliveries")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := w.webhookRepository.GetByID(ctx, webhookUUID); err != nil {
+		return nil, 0, err
+	}
+	return w.webhookRepository.GetDeliveries(ctx, webhookUUID, page, limit)
+}
+
+// Redeliver queues the event of a past delivery again, as a new delivery with the same event ID
+func (w *WebhookUseCase) Redeliver(ctx context.Context, webhookUUID string, deliveryUUID string) (*domain.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.Redeliver")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	webhook, err := w.webhookRepository.GetByID(ctx, webhookUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.Enabled {
+		return nil, domain.ErrWebhookDisabled
+	}
+	original, err := w.webhookRepository.GetDelivery(ctx, webhookUUID, deliveryUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := []domain.WebhookDelivery{{
+		WebhookUUID:   webhookUUID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.UUID,
+	}}
+	if err := w.webhookRepository.CreateDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+func (w *WebhookUseCase) Name() string {
+	return "webhooks"
+}
+
+// Deliver queues a delivery of the event to every enabled webhook of its organisation subscribed
+// to it, as the outbox's webhook sink. An event delivered again is only queued once per webhook.
+func (w *WebhookUseCase) Deliver(ctx context.Context, event domain.Event) error {
+	ctx, span := startSpan(ctx, "WebhookUseCase.Deliver")
+	defer span.End()
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ctx = domain.WithOrganization(ctx, event.OrganizationUUID)
+
+	webhooks, err := w.webhookRepository.GetEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var payload json.RawMessage
+	var deliveries []domain.WebhookDelivery
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return err
+			}
+		}
+		deliveries = append(deliveries, domain.WebhookDelivery{
+			WebhookUUID:   webhook.UUID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		})
+	}
+	return w.webhookRepository.CreateDeliveries(ctx, deliveries)
+}
+
+// Run delivers due deliveries of every organisation with sender until ctx is cancelled. Every
+// replica may run it; each delivery is claimed by one of them at a time.
+func (w *WebhookUseCase) Run(ctx context.Context, sender WebhookSender, policy WebhookPolicy) {
+	ctx = domain.WithAllOrganizations(ctx)
+	ticker := time.NewTicker(policy.PollInterval)
+	defer ticker.Stop()
+	for {
+		// A full batch suggests more are waiting, so look again straight away
+		if w.deliverDue(ctx, sender, policy) < policy.BatchSize || ctx.Err() != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// deliverDue attempts one batch of due deliveries at once and returns how many there were
+func (w *WebhookUseCase) deliverDue(ctx context.Context, sender WebhookSender, policy WebhookPolicy) int {
+	// The lease outlasts an attempt, so a delivery is only claimed again if its sender died
+	deliveries, err := w.webhookRepository.ClaimDueDeliveries(ctx, time.Now(), 2*policy.Timeout, policy.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Claiming webhook deliveries", "error", err)
+		}
+		return 0
+	}
+
+	webhooks := map[string]*domain.Webhook{}
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		webhook, ok := webhooks[delivery.WebhookUUID]
+		if !ok {
+			if webhook, err = w.webhookRepository.GetByID(ctx, delivery.WebhookUUID); err != nil {
+				// Deleted since it was claimed, or unreachable; the lease brings it back if it still exists
+				continue
+			}
+			webhooks[delivery.WebhookUUID] = webhook
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.attempt(ctx, sender, policy, webhook, delivery)
+		}()
+	}
+	wg.Wait()
+	return len(deliveries)
+}
+
+// attempt sends a delivery once and records the outcome against it and its webhook
+func (w *WebhookUseCase) attempt(ctx context.Context, sender WebhookSender, policy WebhookPolicy, webhook *domain.Webhook, delivery *domain.WebhookDelivery) {
+	ctx, span := startSpan(ctx, "WebhookUseCase.attempt")
+	defer span.End()
+
+	attempt := sender.Send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		// Shutting down; the lease runs out and the attempt is made again
+		return
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.ResponseStatus = attempt.ResponseStatus
+	delivery.ResponseBody = attempt.ResponseBody
+	delivery.Error = ""
+	if attempt.Succeeded() {
+		delivery.Status = domain.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		metrics.WebhookAttempts.WithLabelValues(string(delivery.EventType), "succeeded").Inc()
+		if err := w.webhookRepository.RecordSuccess(ctx, webhook.UUID); err != nil {
+			slog.ErrorContext(ctx, "Recording webhook success", "webhook_uuid", webhook.UUID, "error", err)
+		}
+	} else {
+		delivery.Error = attempt.Err.Error()
+		if delivery.Attempts >= policy.MaxAttempts {
+			delivery.Status = domain.WebhookDeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := now.Add(retryBackoff(policy.Backoff, policy.MaxBackoff, delivery.Attempts))
+			delivery.NextAttemptAt = &next
+		}
+		metrics.WebhookAttempts.WithLabelValues(string(delivery.EventType), "failed").Inc()
+		slog.WarnContext(ctx, "Webhook delivery failed", "webhook_uuid", webhook.UUID, "delivery_uuid", delivery.UUID,
+			"attempts", delivery.Attempts, "status", attempt.ResponseStatus, "error", attempt.Err)
+
+		disabled, err := w.webhookRepository.RecordFailure(ctx, webhook.UUID, policy.DisableAfter, now)
+		if err != nil {
+			slog.ErrorContext(ctx, "Recording webhook failure", "webhook_uuid", webhook.UUID, "error", err)
+		} else if disabled {
+			metrics.WebhooksDisabled.Inc()
+			slog.WarnContext(ctx, "Webhook disabled after repeated failures", "webhook_uuid", webhook.UUID, "url", webhook.URL, "failures", policy.DisableAfter)
+		}
+	}
+
+	if err := w.webhookRepository.SaveDelivery(ctx, delivery); err != nil {
+		slog.ErrorContext(ctx, "Recording webhook delivery", "delivery_uuid", delivery.UUID, "error", err)
+	}
+}
+
+// retryBackoff is the wait after the given number of failed attempts: backoff, doubling every
+// attempt, capped at maxBackoff
+func retryBackoff(backoff, maxBackoff time.Duration, attempts int) time.Duration {
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// checkURL refuses endpoints on addresses that are not public, unless AllowPrivateURLs is set
+func (w *WebhookUseCase) checkURL(ctx context.Context, rawURL string) error {
+	if w.AllowPrivateURLs {
+		return nil
+	}
+	return webhook.CheckURL(ctx, rawURL)
+}
+
+// newWebhookSecret returns a random signing secret
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(secret), nil
+}
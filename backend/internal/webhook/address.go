@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, private in all but name
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// PublicAddr reports whether ip can be reached over the internet rather than only from inside
+// the network the server runs in, such as loopback, private, link-local (cloud metadata
+// endpoints among them) and unspecified addresses
+func PublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// CheckURL resolves the host of a webhook URL and fails with ErrWebhookURLPrivate unless every
+// address it resolves to is public. The sender checks the address it connects to again, as DNS
+// may answer differently by then.
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return domain.ErrWebhookURLInvalid
+	}
+	if ip, err := netip.ParseAddr(u.Hostname()); err == nil {
+		if !PublicAddr(ip) {
+			return domain.ErrWebhookURLPrivate
+		}
+		return nil
+	}
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(ips) == 0 {
+		return domain.ErrWebhookURLUnresolved
+	}
+	for _, ip := range ips {
+		if !PublicAddr(ip) {
+			return domain.ErrWebhookURLPrivate
+		}
+	}
+	return nil
+}
+
+// dialPublic refuses connections to addresses that are not public. It runs once the host has
+// been resolved, so a name that resolved to a public address at registration cannot be pointed
+// at an internal one later.
+func dialPublic(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !PublicAddr(ip) {
+		return fmt.Errorf("connecting to %s: %w", host, domain.ErrWebhookURLPrivate)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package webhook sends signed webhook deliveries over HTTP
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+// Headers sent with every delivery
+const (
+	EventHeader     = "X-StockHub-Event"     // The event type, such as movement.created
+	EventIDHeader   = "X-StockHub-Event-Id"  // Same for every delivery of an event, to drop duplicates
+	DeliveryHeader  = "X-StockHub-Delivery"  // This delivery, as listed in the delivery log
+	TimestampHeader = "X-StockHub-Timestamp" // Unix seconds when the attempt was made
+	SignatureHeader = "X-StockHub-Signature" // sha256=<hex HMAC-SHA256 of "<timestamp>.<body>">
+	userAgent       = "StockHub-Webhooks/1.0"
+)
+
+// maxResponseBody is how much of an endpoint's answer is kept in the delivery log
+const maxResponseBody = 1024
+
+// Sender posts deliveries to webhook endpoints
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender returns a sender giving each attempt timeout to complete. Redirects are not
+// followed, so an endpoint that moved fails until its webhook is updated. Unless allowPrivate
+// is set, connections are only made to public addresses, checked after DNS resolution, and
+// never through a proxy.
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second, Control: dialPublic}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+	return &Sender{client: &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+// Send makes one attempt at the delivery
+func (s *Sender) Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) domain.WebhookAttempt {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return domain.WebhookAttempt{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(EventIDHeader, delivery.EventID)
+	req.Header.Set(DeliveryHeader, delivery.UUID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return domain.WebhookAttempt{Err: err}
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBody))
+	// Drain what is left so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	attempt := domain.WebhookAttempt{ResponseStatus: res.StatusCode, ResponseBody: string(body)}
+	if !attempt.Succeeded() {
+		attempt.Err = fmt.Errorf("endpoint answered %s", res.Status)
+	}
+	return attempt
+}
+
+// Sign returns the signature header value for a body sent at timestamp. Receivers recompute
+// it with their copy of the secret, compare in constant time and reject stale timestamps.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the one Sign gives for the body and timestamp
+func Verify(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shirloin/stockhub/internal/domain"
+)
+
+func TestSenderSignsDeliveries(t *testing.T) {
+	webhook := &domain.Webhook{Secret: "whsec_0123456789abcdef"}
+	delivery := &domain.WebhookDelivery{
+		UUID:      "6f1c1a52-2b0e-4a8f-9d4e-5c1f3c1b2a10",
+		EventID:   "0b7e5d0c-7c8a-4f51-8a3e-2d6f9e4b1c22",
+		EventType: domain.EventMovementCreated,
+		Payload:   []byte(`{"id":"0b7e5d0c-7c8a-4f51-8a3e-2d6f9e4b1c22","type":"movement.created","data":{}}`),
+	}
+
+	var got *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	webhook.URL = receiver.URL
+
+	attempt := NewSender(time.Second, true).Send(context.Background(), webhook, delivery)
+	if !attempt.Succeeded() {
+		t.Fatalf("attempt failed: status %d, error %v", attempt.ResponseStatus, attempt.Err)
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body = %s, want the payload", body)
+	}
+	if got.Header.Get(EventHeader) != string(domain.EventMovementCreated) || got.Header.Get(EventIDHeader) != delivery.EventID || got.Header.Get(DeliveryHeader) != delivery.UUID {
+		t.Errorf("event headers = %v", got.Header)
+	}
+	if !Verify(webhook.Secret, got.Header.Get(TimestampHeader), body, got.Header.Get(SignatureHeader)) {
+		t.Error("signature does not verify with the webhook secret")
+	}
+	if Verify("another-secret-entirely", got.Header.Get(TimestampHeader), body, got.Header.Get(SignatureHeader)) {
+		t.Error("signature verifies with the wrong secret")
+	}
+}
+
+func TestSenderFailsOnErrorsAndRedirects(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"server error": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "database down", http.StatusInternalServerError)
+		},
+		"redirect": func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			receiver := httptest.NewServer(handler)
+			defer receiver.Close()
+
+			webhook := &domain.Webhook{URL: receiver.URL, Secret: "whsec_0123456789abcdef"}
+			attempt := NewSender(time.Second, true).Send(context.Background(), webhook, &domain.WebhookDelivery{Payload: []byte(`{}`)})
+			if attempt.Succeeded() || attempt.Err == nil || attempt.ResponseStatus < 300 {
+				t.Fatalf("attempt = %+v, want a failure with the endpoint's status", attempt)
+			}
+		})
+	}
+}
+
+func TestSenderRefusesPrivateAddresses(t *testing.T) {
+	reached := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhook := &domain.Webhook{URL: receiver.URL, Secret: "whsec_0123456789abcdef"}
+	attempt := NewSender(time.Second, false).Send(context.Background(), webhook, &domain.WebhookDelivery{Payload: []byte(`{}`)})
+	if attempt.Succeeded() || !errors.Is(attempt.Err, domain.ErrWebhookURLPrivate) {
+		t.Fatalf("attempt = %+v, want it refused for its loopback address", attempt)
+	}
+	if reached {
+		t.Error("the loopback endpoint was reached")
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	for rawURL, want := range map[string]error{
+		"https://93.184.215.14/hooks":          nil,
+		"https://[2606:4700::6810:84e5]/hooks": nil,
+		"http://127.0.0.1:8080/hooks":          domain.ErrWebhookURLPrivate,
+		"http://[::1]/hooks":                   domain.ErrWebhookURLPrivate,
+		"http://10.1.2.3/hooks":                domain.ErrWebhookURLPrivate,
+		"http://192.168.0.10/hooks":            domain.ErrWebhookURLPrivate,
+		"http://169.254.169.254/latest/meta":   domain.ErrWebhookURLPrivate,
+		"http://[fe80::1]/hooks":               domain.ErrWebhookURLPrivate,
+		"http://100.64.0.1/hooks":              domain.ErrWebhookURLPrivate,
+		"http://0.0.0.0/hooks":                 domain.ErrWebhookURLPrivate,
+		"http://[::ffff:127.0.0.1]/hooks":      domain.ErrWebhookURLPrivate,
+		"http://stockhub.invalid/hooks":        domain.ErrWebhookURLUnresolved,
+	} {
+		if err := CheckURL(context.Background(), rawURL); !errors.Is(err, want) {
+			t.Errorf("CheckURL(%q) = %v, want %v", rawURL, err, want)
+		}
+	}
+}
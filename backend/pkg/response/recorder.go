@@ -0,0 +1,24 @@
+package response
+
+import "net/http"
+
+// StatusRecorder remembers the status code written by the handler, for middlewares that log or
+// count responses
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+// NewStatusRecorder wraps w; the status is 200 until the handler writes another
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (r *StatusRecorder) WriteHeader(status int) {
+	r.Status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
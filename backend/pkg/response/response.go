@@ -3,11 +3,14 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 )
 
 type Response struct {
 	Status  bool        `json:"status"`
 	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`    // Machine-readable error code, errors only
+	Details interface{} `json:"details,omitempty"` // Per-field validation failures, errors only
 	Data    interface{} `json:"data"`
 }
 
@@ -20,11 +23,14 @@ type PaginatedResponse struct {
 }
 
 type PaginatedData struct {
-	Page       int         `json:"page"`
-	Limit      int         `json:"limit"`
-	Total      int64       `json:"total"`
-	TotalPages int         `json:"totalPages"`
-	Items      interface{} `json:"items"`
+	Page             int         `json:"page"`
+	Limit            int         `json:"limit"`
+	Total            int64       `json:"total"`
+	TotalPages       int         `json:"totalPages"`
+	Items            interface{} `json:"items"`
+	NextCursor       string      `json:"nextCursor,omitempty"`       // Cursor pagination only
+	PrevCursor       string      `json:"prevCursor,omitempty"`       // Cursor pagination only
+	TotalApproximate bool        `json:"totalApproximate,omitempty"` // Total is an estimate
 }
 
 func Success(w http.ResponseWriter, code int, message string, data interface{}) {
@@ -58,11 +64,48 @@ func PaginatedSuccess(w http.ResponseWriter, code int, message string, page, lim
 	})
 }
 
-func Error(w http.ResponseWriter, code int, message string) {
+// CursorPaginatedSuccess writes a cursor-paginated page. Page is always 0; total is omitted
+// (left at 0) when nil and otherwise reported as approximate.
+func CursorPaginatedSuccess(w http.ResponseWriter, code int, message string, limit int, total *int64, nextCursor, prevCursor string, data interface{}) {
+	paginated := PaginatedData{
+		Limit:      limit,
+		Items:      data,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	}
+	if total != nil {
+		paginated.Total = *total
+		paginated.TotalPages = int((*total + int64(limit) - 1) / int64(limit))
+		paginated.TotalApproximate = true
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(Response{
+		Status:  true,
+		Message: message,
+		Data:    paginated,
+	})
+}
+
+// Error writes an error whose code is derived from the HTTP status, e.g. BAD_REQUEST
+func Error(w http.ResponseWriter, code int, message string) {
+	ErrorWithCode(w, code, StatusCode(code), message, nil)
+}
+
+// ErrorWithCode writes an error with an explicit machine-readable code and optional details
+func ErrorWithCode(w http.ResponseWriter, status int, code, message string, details interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(Response{
 		Status:  false,
 		Message: message,
+		Code:    code,
+		Details: details,
 	})
 }
+
+// StatusCode turns an HTTP status into an error code, e.g. 404 into NOT_FOUND
+func StatusCode(status int) string {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
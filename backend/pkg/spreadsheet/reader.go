@@ -0,0 +1,130 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format, expected csv or xlsx")
+
+// DetectFormat resolves the file format from the uploaded file name, falling back to the content type
+func DetectFormat(filename, contentType string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return ParseFormat(contentType)
+}
+
+// ParseFormat resolves a format name or MIME type such as "csv" or "text/csv"
+func ParseFormat(value string) (Format, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.Index(value, ";"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	switch value {
+	case "csv", "text/csv", "application/csv":
+		return FormatCSV, nil
+	case "xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return FormatXLSX, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Reader iterates over the rows of a CSV file or the first sheet of an XLSX workbook.
+// The first row is treated as the header.
+type Reader struct {
+	header []string
+	next   func() ([]string, error)
+	close  func() error
+}
+
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	var reader *Reader
+	switch format {
+	case FormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		reader = &Reader{next: cr.Read, close: func() error { return nil }}
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open workbook: %w", err)
+		}
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			f.Close()
+			return nil, errors.New("workbook has no sheets")
+		}
+		rows, err := f.Rows(sheets[0])
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		reader = &Reader{
+			next: func() ([]string, error) {
+				if !rows.Next() {
+					if err := rows.Error(); err != nil {
+						return nil, err
+					}
+					return nil, io.EOF
+				}
+				return rows.Columns()
+			},
+			close: func() error {
+				rows.Close()
+				return f.Close()
+			},
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	header, err := reader.next()
+	if err == io.EOF {
+		reader.Close()
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+	reader.header = header
+	return reader, nil
+}
+
+func (r *Reader) Header() []string {
+	return r.header
+}
+
+// Next returns the next data row, or io.EOF when the file is exhausted
+func (r *Reader) Next() ([]string, error) {
+	return r.next()
+}
+
+func (r *Reader) Close() error {
+	return r.close()
+}
+
+// NormalizeHeader lowercases a column name and strips spaces, dashes and underscores
+// so that "Low Stock Threshold", "low_stock_threshold" and "lowStockThreshold" match.
+func NormalizeHeader(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name)
+}
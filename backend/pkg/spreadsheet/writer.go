@@ -0,0 +1,98 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const sheetName = "Sheet1"
+
+// Writer writes rows of values to a CSV or XLSX destination
+type Writer interface {
+	Write(values []any) error
+	// Close flushes buffered rows; XLSX workbooks are only written to the destination here
+	Close() error
+	// Abort releases the writer without writing anything more to the destination, for exports
+	// that failed midway
+	Abort()
+}
+
+func NewWriter(w io.Writer, format Format) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		f := excelize.NewFile()
+		sw, err := f.NewStreamWriter(sheetName)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &xlsxWriter{dst: w, file: f, stream: sw}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (c *csvWriter) Write(values []any) error {
+	c.record = c.record[:0]
+	for _, value := range values {
+		c.record = append(c.record, fmt.Sprint(value))
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Abort() {}
+
+// xlsxWriter uses excelize's stream writer, which spools rows to a temporary file
+// instead of keeping the whole sheet in memory
+type xlsxWriter struct {
+	dst    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func (x *xlsxWriter) Write(values []any) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.dst)
+	return err
+}
+
+// Abort removes the stream writer's temporary file
+func (x *xlsxWriter) Abort() {
+	x.file.Close()
+}
@@ -0,0 +1,491 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.1
+// source: proto/alert/alert.proto
+
+package alert
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Alert struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	RuleUuid       string                 `protobuf:"bytes,2,opt,name=rule_uuid,json=ruleUuid,proto3" json:"rule_uuid,omitempty"`
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                        // stock_below, warehouse_utilization, large_adjustment, no_movement or stock_drift
+	ProductUuid    string                 `protobuf:"bytes,4,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`       // Empty for warehouse_utilization alerts
+	WarehouseUuid  string                 `protobuf:"bytes,5,opt,name=warehouse_uuid,json=warehouseUuid,proto3" json:"warehouse_uuid,omitempty"` // Empty for no_movement and stock_drift alerts
+	MovementUuid   string                 `protobuf:"bytes,6,opt,name=movement_uuid,json=movementUuid,proto3" json:"movement_uuid,omitempty"`    // The adjustment of a large_adjustment alert
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                    // open, acknowledged, snoozed or resolved
+	Message        string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	Value          int32                  `protobuf:"varint,9,opt,name=value,proto3" json:"value,omitempty"`
+	Threshold      int32                  `protobuf:"varint,10,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	SnoozedUntil   string                 `protobuf:"bytes,11,opt,name=snoozed_until,json=snoozedUntil,proto3" json:"snoozed_until,omitempty"`
+	AcknowledgedAt string                 `protobuf:"bytes,12,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string                 `protobuf:"bytes,13,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	ResolvedAt     string                 `protobuf:"bytes,14,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_proto_alert_alert_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_alert_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_proto_alert_alert_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Alert) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Alert) GetRuleUuid() string {
+	if x != nil {
+		return x.RuleUuid
+	}
+	return ""
+}
+
+func (x *Alert) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Alert) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *Alert) GetWarehouseUuid() string {
+	if x != nil {
+		return x.WarehouseUuid
+	}
+	return ""
+}
+
+func (x *Alert) GetMovementUuid() string {
+	if x != nil {
+		return x.MovementUuid
+	}
+	return ""
+}
+
+func (x *Alert) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Alert) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Alert) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Alert) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Alert) GetSnoozedUntil() string {
+	if x != nil {
+		return x.SnoozedUntil
+	}
+	return ""
+}
+
+func (x *Alert) GetAcknowledgedAt() string {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return ""
+}
+
+func (x *Alert) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *Alert) GetResolvedAt() string {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return ""
+}
+
+func (x *Alert) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Alert) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type AlertTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Alert         *Alert                 `protobuf:"bytes,2,opt,name=alert,proto3" json:"alert,omitempty"` // The alert as it is now
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`   // Empty when the alert was opened
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertTransition) Reset() {
+	*x = AlertTransition{}
+	mi := &file_proto_alert_alert_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertTransition) ProtoMessage() {}
+
+func (x *AlertTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_alert_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertTransition.ProtoReflect.Descriptor instead.
+func (*AlertTransition) Descriptor() ([]byte, []int) {
+	return file_proto_alert_alert_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AlertTransition) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlertTransition) GetAlert() *Alert {
+	if x != nil {
+		return x.Alert
+	}
+	return nil
+}
+
+func (x *AlertTransition) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AlertTransition) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *AlertTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AlertTransition) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *AlertTransition) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type WatchAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterId       int64                  `protobuf:"varint,1,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"` // Resume with the transitions after this one instead of the unresolved alerts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchAlertsRequest) Reset() {
+	*x = WatchAlertsRequest{}
+	mi := &file_proto_alert_alert_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAlertsRequest) ProtoMessage() {}
+
+func (x *WatchAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_alert_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAlertsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_alert_alert_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchAlertsRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type AlertUpdate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Alerts           []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`                                                // The unresolved alerts, in the first update unless resuming
+	Transitions      []*AlertTransition     `protobuf:"bytes,2,rep,name=transitions,proto3" json:"transitions,omitempty"`                                      // What changed since the previous update, oldest first
+	LastTransitionId int64                  `protobuf:"varint,3,opt,name=last_transition_id,json=lastTransitionId,proto3" json:"last_transition_id,omitempty"` // Resume from here after reconnecting
+	Timestamp        string                 `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AlertUpdate) Reset() {
+	*x = AlertUpdate{}
+	mi := &file_proto_alert_alert_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertUpdate) ProtoMessage() {}
+
+func (x *AlertUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_alert_alert_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertUpdate.ProtoReflect.Descriptor instead.
+func (*AlertUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_alert_alert_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AlertUpdate) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *AlertUpdate) GetTransitions() []*AlertTransition {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+func (x *AlertUpdate) GetLastTransitionId() int64 {
+	if x != nil {
+		return x.LastTransitionId
+	}
+	return 0
+}
+
+func (x *AlertUpdate) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+var File_proto_alert_alert_proto protoreflect.FileDescriptor
+
+const file_proto_alert_alert_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/alert/alert.proto\x12\x05alert\"\xf7\x03\n" +
+	"\x05Alert\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1b\n" +
+	"\trule_uuid\x18\x02 \x01(\tR\bruleUuid\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12!\n" +
+	"\fproduct_uuid\x18\x04 \x01(\tR\vproductUuid\x12%\n" +
+	"\x0ewarehouse_uuid\x18\x05 \x01(\tR\rwarehouseUuid\x12#\n" +
+	"\rmovement_uuid\x18\x06 \x01(\tR\fmovementUuid\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12\x14\n" +
+	"\x05value\x18\t \x01(\x05R\x05value\x12\x1c\n" +
+	"\tthreshold\x18\n" +
+	" \x01(\x05R\tthreshold\x12#\n" +
+	"\rsnoozed_until\x18\v \x01(\tR\fsnoozedUntil\x12'\n" +
+	"\x0facknowledged_at\x18\f \x01(\tR\x0eacknowledgedAt\x12'\n" +
+	"\x0facknowledged_by\x18\r \x01(\tR\x0eacknowledgedBy\x12\x1f\n" +
+	"\vresolved_at\x18\x0e \x01(\tR\n" +
+	"resolvedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\tR\tupdatedAt\"\xb2\x01\n" +
+	"\x0fAlertTransition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\"\n" +
+	"\x05alert\x18\x02 \x01(\v2\f.alert.AlertR\x05alert\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"/\n" +
+	"\x12WatchAlertsRequest\x12\x19\n" +
+	"\bafter_id\x18\x01 \x01(\x03R\aafterId\"\xb9\x01\n" +
+	"\vAlertUpdate\x12$\n" +
+	"\x06alerts\x18\x01 \x03(\v2\f.alert.AlertR\x06alerts\x128\n" +
+	"\vtransitions\x18\x02 \x03(\v2\x16.alert.AlertTransitionR\vtransitions\x12,\n" +
+	"\x12last_transition_id\x18\x03 \x01(\x03R\x10lastTransitionId\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp2N\n" +
+	"\fAlertService\x12>\n" +
+	"\vWatchAlerts\x12\x19.alert.WatchAlertsRequest\x1a\x12.alert.AlertUpdate0\x01B\rZ\vproto/alertb\x06proto3"
+
+var (
+	file_proto_alert_alert_proto_rawDescOnce sync.Once
+	file_proto_alert_alert_proto_rawDescData []byte
+)
+
+func file_proto_alert_alert_proto_rawDescGZIP() []byte {
+	file_proto_alert_alert_proto_rawDescOnce.Do(func() {
+		file_proto_alert_alert_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_alert_alert_proto_rawDesc), len(file_proto_alert_alert_proto_rawDesc)))
+	})
+	return file_proto_alert_alert_proto_rawDescData
+}
+
+var file_proto_alert_alert_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_alert_alert_proto_goTypes = []any{
+	(*Alert)(nil),              // 0: alert.Alert
+	(*AlertTransition)(nil),    // 1: alert.AlertTransition
+	(*WatchAlertsRequest)(nil), // 2: alert.WatchAlertsRequest
+	(*AlertUpdate)(nil),        // 3: alert.AlertUpdate
+}
+var file_proto_alert_alert_proto_depIdxs = []int32{
+	0, // 0: alert.AlertTransition.alert:type_name -> alert.Alert
+	0, // 1: alert.AlertUpdate.alerts:type_name -> alert.Alert
+	1, // 2: alert.AlertUpdate.transitions:type_name -> alert.AlertTransition
+	2, // 3: alert.AlertService.WatchAlerts:input_type -> alert.WatchAlertsRequest
+	3, // 4: alert.AlertService.WatchAlerts:output_type -> alert.AlertUpdate
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_alert_alert_proto_init() }
+func file_proto_alert_alert_proto_init() {
+	if File_proto_alert_alert_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_alert_alert_proto_rawDesc), len(file_proto_alert_alert_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_alert_alert_proto_goTypes,
+		DependencyIndexes: file_proto_alert_alert_proto_depIdxs,
+		MessageInfos:      file_proto_alert_alert_proto_msgTypes,
+	}.Build()
+	File_proto_alert_alert_proto = out.File
+	file_proto_alert_alert_proto_goTypes = nil
+	file_proto_alert_alert_proto_depIdxs = nil
+}
@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.1
+// source: proto/alert/alert.proto
+
+package alert
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AlertService_WatchAlerts_FullMethodName = "/alert.AlertService/WatchAlerts"
+)
+
+// AlertServiceClient is the client API for AlertService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AlertServiceClient interface {
+	WatchAlerts(ctx context.Context, in *WatchAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlertUpdate], error)
+}
+
+type alertServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlertServiceClient(cc grpc.ClientConnInterface) AlertServiceClient {
+	return &alertServiceClient{cc}
+}
+
+func (c *alertServiceClient) WatchAlerts(ctx context.Context, in *WatchAlertsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlertUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AlertService_ServiceDesc.Streams[0], AlertService_WatchAlerts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAlertsRequest, AlertUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AlertService_WatchAlertsClient = grpc.ServerStreamingClient[AlertUpdate]
+
+// AlertServiceServer is the server API for AlertService service.
+// All implementations must embed UnimplementedAlertServiceServer
+// for forward compatibility.
+type AlertServiceServer interface {
+	WatchAlerts(*WatchAlertsRequest, grpc.ServerStreamingServer[AlertUpdate]) error
+	mustEmbedUnimplementedAlertServiceServer()
+}
+
+// UnimplementedAlertServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAlertServiceServer struct{}
+
+func (UnimplementedAlertServiceServer) WatchAlerts(*WatchAlertsRequest, grpc.ServerStreamingServer[AlertUpdate]) error {
+	return status.Error(codes.Unimplemented, "method WatchAlerts not implemented")
+}
+func (UnimplementedAlertServiceServer) mustEmbedUnimplementedAlertServiceServer() {}
+func (UnimplementedAlertServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAlertServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlertServiceServer will
+// result in compilation errors.
+type UnsafeAlertServiceServer interface {
+	mustEmbedUnimplementedAlertServiceServer()
+}
+
+func RegisterAlertServiceServer(s grpc.ServiceRegistrar, srv AlertServiceServer) {
+	// If the following call panics, it indicates UnimplementedAlertServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AlertService_ServiceDesc, srv)
+}
+
+func _AlertService_WatchAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAlertsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlertServiceServer).WatchAlerts(m, &grpc.GenericServerStream[WatchAlertsRequest, AlertUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AlertService_WatchAlertsServer = grpc.ServerStreamingServer[AlertUpdate]
+
+// AlertService_ServiceDesc is the grpc.ServiceDesc for AlertService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AlertService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "alert.AlertService",
+	HandlerType: (*AlertServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAlerts",
+			Handler:       _AlertService_WatchAlerts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/alert/alert.proto",
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/alert/alert.proto
+
+package alertconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	alert "github.com/shirloin/stockhub/proto/alert"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AlertServiceName is the fully-qualified name of the AlertService service.
+	AlertServiceName = "alert.AlertService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AlertServiceWatchAlertsProcedure is the fully-qualified name of the AlertService's WatchAlerts
+	// RPC.
+	AlertServiceWatchAlertsProcedure = "/alert.AlertService/WatchAlerts"
+)
+
+// AlertServiceClient is a client for the alert.AlertService service.
+type AlertServiceClient interface {
+	WatchAlerts(context.Context, *alert.WatchAlertsRequest) (*connect.ServerStreamForClient[alert.AlertUpdate], error)
+}
+
+// NewAlertServiceClient constructs a client for the alert.AlertService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAlertServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AlertServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	alertServiceMethods := alert.File_proto_alert_alert_proto.Services().ByName("AlertService").Methods()
+	return &alertServiceClient{
+		watchAlerts: connect.NewClient[alert.WatchAlertsRequest, alert.AlertUpdate](
+			httpClient,
+			baseURL+AlertServiceWatchAlertsProcedure,
+			connect.WithSchema(alertServiceMethods.ByName("WatchAlerts")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// alertServiceClient implements AlertServiceClient.
+type alertServiceClient struct {
+	watchAlerts *connect.Client[alert.WatchAlertsRequest, alert.AlertUpdate]
+}
+
+// WatchAlerts calls alert.AlertService.WatchAlerts.
+func (c *alertServiceClient) WatchAlerts(ctx context.Context, req *alert.WatchAlertsRequest) (*connect.ServerStreamForClient[alert.AlertUpdate], error) {
+	return c.watchAlerts.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// AlertServiceHandler is an implementation of the alert.AlertService service.
+type AlertServiceHandler interface {
+	WatchAlerts(context.Context, *alert.WatchAlertsRequest, *connect.ServerStream[alert.AlertUpdate]) error
+}
+
+// NewAlertServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAlertServiceHandler(svc AlertServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	alertServiceMethods := alert.File_proto_alert_alert_proto.Services().ByName("AlertService").Methods()
+	alertServiceWatchAlertsHandler := connect.NewServerStreamHandlerSimple(
+		AlertServiceWatchAlertsProcedure,
+		svc.WatchAlerts,
+		connect.WithSchema(alertServiceMethods.ByName("WatchAlerts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/alert.AlertService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AlertServiceWatchAlertsProcedure:
+			alertServiceWatchAlertsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAlertServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAlertServiceHandler struct{}
+
+func (UnimplementedAlertServiceHandler) WatchAlerts(context.Context, *alert.WatchAlertsRequest, *connect.ServerStream[alert.AlertUpdate]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("alert.AlertService.WatchAlerts is not implemented"))
+}
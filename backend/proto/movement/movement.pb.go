@@ -144,6 +144,7 @@ type StockMovement struct {
 	MovementDate     string                 `protobuf:"bytes,15,opt,name=movement_date,json=movementDate,proto3" json:"movement_date,omitempty"`
 	CreatedAt        string                 `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt        string                 `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DocumentUuid     string                 `protobuf:"bytes,18,opt,name=document_uuid,json=documentUuid,proto3" json:"document_uuid,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -297,28 +298,496 @@ func (x *StockMovement) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *StockMovement) GetDocumentUuid() string {
+	if x != nil {
+		return x.DocumentUuid
+	}
+	return ""
+}
+
 type WatchMovementsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // 0 means no limit
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // 0 means no limit
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMovementsRequest) Reset() {
+	*x = WatchMovementsRequest{}
+	mi := &file_proto_movement_movement_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMovementsRequest) ProtoMessage() {}
+
+func (x *WatchMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMovementsRequest.ProtoReflect.Descriptor instead.
+func (*WatchMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchMovementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MovementUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MovementUpdate) Reset() {
+	*x = MovementUpdate{}
+	mi := &file_proto_movement_movement_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MovementUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MovementUpdate) ProtoMessage() {}
+
+func (x *MovementUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MovementUpdate.ProtoReflect.Descriptor instead.
+func (*MovementUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MovementUpdate) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *MovementUpdate) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+type ListMovementsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseUuid    string                 `protobuf:"bytes,1,opt,name=warehouse_uuid,json=warehouseUuid,proto3" json:"warehouse_uuid,omitempty"`
+	ProductUuid      string                 `protobuf:"bytes,2,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
+	MovementTypes    []string               `protobuf:"bytes,3,rep,name=movement_types,json=movementTypes,proto3" json:"movement_types,omitempty"`
+	StartDate        string                 `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"` // YYYY-MM-DD, inclusive
+	EndDate          string                 `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`       // YYYY-MM-DD, inclusive
+	ReferenceNumber  string                 `protobuf:"bytes,6,opt,name=reference_number,json=referenceNumber,proto3" json:"reference_number,omitempty"`
+	AdjustmentReason string                 `protobuf:"bytes,7,opt,name=adjustment_reason,json=adjustmentReason,proto3" json:"adjustment_reason,omitempty"`
+	CreatedBy        string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	QuantitySign     string                 `protobuf:"bytes,9,opt,name=quantity_sign,json=quantitySign,proto3" json:"quantity_sign,omitempty"`   // "positive" or "negative"
+	Sort             string                 `protobuf:"bytes,10,opt,name=sort,proto3" json:"sort,omitempty"`                                      // e.g. "-quantity,movementDate"; not allowed with cursor
+	Page             int32                  `protobuf:"varint,11,opt,name=page,proto3" json:"page,omitempty"`                                     // Defaults to 1
+	Limit            int32                  `protobuf:"varint,12,opt,name=limit,proto3" json:"limit,omitempty"`                                   // Defaults to 10
+	Cursor           *string                `protobuf:"bytes,13,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`                            // Set (empty for the first page) to use cursor pagination instead of pages
+	IncludeTotal     bool                   `protobuf:"varint,14,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"` // Approximate total, cursor pagination only
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListMovementsRequest) Reset() {
+	*x = ListMovementsRequest{}
+	mi := &file_proto_movement_movement_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsRequest) ProtoMessage() {}
+
+func (x *ListMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMovementsRequest) GetWarehouseUuid() string {
+	if x != nil {
+		return x.WarehouseUuid
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetMovementTypes() []string {
+	if x != nil {
+		return x.MovementTypes
+	}
+	return nil
+}
+
+func (x *ListMovementsRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetReferenceNumber() string {
+	if x != nil {
+		return x.ReferenceNumber
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetAdjustmentReason() string {
+	if x != nil {
+		return x.AdjustmentReason
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetQuantitySign() string {
+	if x != nil {
+		return x.QuantitySign
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMovementsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListMovementsRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+type ListMovementsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Movements        []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	Page             int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit            int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Total            int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	TotalApproximate bool                   `protobuf:"varint,5,opt,name=total_approximate,json=totalApproximate,proto3" json:"total_approximate,omitempty"`
+	NextCursor       string                 `protobuf:"bytes,6,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor       string                 `protobuf:"bytes,7,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListMovementsResponse) Reset() {
+	*x = ListMovementsResponse{}
+	mi := &file_proto_movement_movement_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMovementsResponse) ProtoMessage() {}
+
+func (x *ListMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListMovementsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMovementsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMovementsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListMovementsResponse) GetTotalApproximate() bool {
+	if x != nil {
+		return x.TotalApproximate
+	}
+	return false
+}
+
+func (x *ListMovementsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListMovementsResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+type StockIn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Uuid            string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ProductUuid     string                 `protobuf:"bytes,2,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
+	WarehouseUuid   string                 `protobuf:"bytes,3,opt,name=warehouse_uuid,json=warehouseUuid,proto3" json:"warehouse_uuid,omitempty"`
+	Quantity        int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	PurchaseOrderNo string                 `protobuf:"bytes,5,opt,name=purchase_order_no,json=purchaseOrderNo,proto3" json:"purchase_order_no,omitempty"`
+	SupplierUuid    string                 `protobuf:"bytes,6,opt,name=supplier_uuid,json=supplierUuid,proto3" json:"supplier_uuid,omitempty"`
+	ReceivedDate    string                 `protobuf:"bytes,7,opt,name=received_date,json=receivedDate,proto3" json:"received_date,omitempty"`
+	ReceivedBy      string                 `protobuf:"bytes,8,opt,name=received_by,json=receivedBy,proto3" json:"received_by,omitempty"`
+	Notes           string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StockIn) Reset() {
+	*x = StockIn{}
+	mi := &file_proto_movement_movement_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockIn) ProtoMessage() {}
+
+func (x *StockIn) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockIn.ProtoReflect.Descriptor instead.
+func (*StockIn) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockIn) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *StockIn) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *StockIn) GetWarehouseUuid() string {
+	if x != nil {
+		return x.WarehouseUuid
+	}
+	return ""
+}
+
+func (x *StockIn) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockIn) GetPurchaseOrderNo() string {
+	if x != nil {
+		return x.PurchaseOrderNo
+	}
+	return ""
+}
+
+func (x *StockIn) GetSupplierUuid() string {
+	if x != nil {
+		return x.SupplierUuid
+	}
+	return ""
+}
+
+func (x *StockIn) GetReceivedDate() string {
+	if x != nil {
+		return x.ReceivedDate
+	}
+	return ""
+}
+
+func (x *StockIn) GetReceivedBy() string {
+	if x != nil {
+		return x.ReceivedBy
+	}
+	return ""
+}
+
+func (x *StockIn) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *StockIn) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type StockOut struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ProductUuid   string                 `protobuf:"bytes,2,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
+	WarehouseUuid string                 `protobuf:"bytes,3,opt,name=warehouse_uuid,json=warehouseUuid,proto3" json:"warehouse_uuid,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	SalesOrderNo  string                 `protobuf:"bytes,5,opt,name=sales_order_no,json=salesOrderNo,proto3" json:"sales_order_no,omitempty"`
+	CustomerName  string                 `protobuf:"bytes,6,opt,name=customer_name,json=customerName,proto3" json:"customer_name,omitempty"`
+	ShippedDate   string                 `protobuf:"bytes,7,opt,name=shipped_date,json=shippedDate,proto3" json:"shipped_date,omitempty"`
+	ShippedBy     string                 `protobuf:"bytes,8,opt,name=shipped_by,json=shippedBy,proto3" json:"shipped_by,omitempty"`
+	Notes         string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WatchMovementsRequest) Reset() {
-	*x = WatchMovementsRequest{}
-	mi := &file_proto_movement_movement_proto_msgTypes[3]
+func (x *StockOut) Reset() {
+	*x = StockOut{}
+	mi := &file_proto_movement_movement_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchMovementsRequest) String() string {
+func (x *StockOut) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchMovementsRequest) ProtoMessage() {}
+func (*StockOut) ProtoMessage() {}
 
-func (x *WatchMovementsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_movement_movement_proto_msgTypes[3]
+func (x *StockOut) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,41 +798,227 @@ func (x *WatchMovementsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WatchMovementsRequest.ProtoReflect.Descriptor instead.
-func (*WatchMovementsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_movement_movement_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use StockOut.ProtoReflect.Descriptor instead.
+func (*StockOut) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *WatchMovementsRequest) GetLimit() int32 {
+func (x *StockOut) GetUuid() string {
 	if x != nil {
-		return x.Limit
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *StockOut) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *StockOut) GetWarehouseUuid() string {
+	if x != nil {
+		return x.WarehouseUuid
+	}
+	return ""
+}
+
+func (x *StockOut) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
 	}
 	return 0
 }
 
-type MovementUpdate struct {
+func (x *StockOut) GetSalesOrderNo() string {
+	if x != nil {
+		return x.SalesOrderNo
+	}
+	return ""
+}
+
+func (x *StockOut) GetCustomerName() string {
+	if x != nil {
+		return x.CustomerName
+	}
+	return ""
+}
+
+func (x *StockOut) GetShippedDate() string {
+	if x != nil {
+		return x.ShippedDate
+	}
+	return ""
+}
+
+func (x *StockOut) GetShippedBy() string {
+	if x != nil {
+		return x.ShippedBy
+	}
+	return ""
+}
+
+func (x *StockOut) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *StockOut) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type StockAdjustment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ProductUuid    string                 `protobuf:"bytes,2,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
+	WarehouseUuid  string                 `protobuf:"bytes,3,opt,name=warehouse_uuid,json=warehouseUuid,proto3" json:"warehouse_uuid,omitempty"`
+	Quantity       int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // Positive to add, negative to subtract
+	PreviousQty    int32                  `protobuf:"varint,5,opt,name=previous_qty,json=previousQty,proto3" json:"previous_qty,omitempty"`
+	NewQty         int32                  `protobuf:"varint,6,opt,name=new_qty,json=newQty,proto3" json:"new_qty,omitempty"`
+	Reason         string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	AdjustedBy     string                 `protobuf:"bytes,8,opt,name=adjusted_by,json=adjustedBy,proto3" json:"adjusted_by,omitempty"`
+	AdjustmentDate string                 `protobuf:"bytes,9,opt,name=adjustment_date,json=adjustmentDate,proto3" json:"adjustment_date,omitempty"`
+	Notes          string                 `protobuf:"bytes,10,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StockAdjustment) Reset() {
+	*x = StockAdjustment{}
+	mi := &file_proto_movement_movement_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustment) ProtoMessage() {}
+
+func (x *StockAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustment.ProtoReflect.Descriptor instead.
+func (*StockAdjustment) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StockAdjustment) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetWarehouseUuid() string {
+	if x != nil {
+		return x.WarehouseUuid
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetPreviousQty() int32 {
+	if x != nil {
+		return x.PreviousQty
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetNewQty() int32 {
+	if x != nil {
+		return x.NewQty
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetAdjustedBy() string {
+	if x != nil {
+		return x.AdjustedBy
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetAdjustmentDate() string {
+	if x != nil {
+		return x.AdjustmentDate
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *StockAdjustment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateStockInRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Movements     []*StockMovement       `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
-	Timestamp     string                 `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	StockIn       *StockIn               `protobuf:"bytes,1,opt,name=stock_in,json=stockIn,proto3" json:"stock_in,omitempty"` // uuid and created_at are ignored; received_date defaults to now
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MovementUpdate) Reset() {
-	*x = MovementUpdate{}
-	mi := &file_proto_movement_movement_proto_msgTypes[4]
+func (x *CreateStockInRequest) Reset() {
+	*x = CreateStockInRequest{}
+	mi := &file_proto_movement_movement_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MovementUpdate) String() string {
+func (x *CreateStockInRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MovementUpdate) ProtoMessage() {}
+func (*CreateStockInRequest) ProtoMessage() {}
 
-func (x *MovementUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_movement_movement_proto_msgTypes[4]
+func (x *CreateStockInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,23 +1029,104 @@ func (x *MovementUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MovementUpdate.ProtoReflect.Descriptor instead.
-func (*MovementUpdate) Descriptor() ([]byte, []int) {
-	return file_proto_movement_movement_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateStockInRequest.ProtoReflect.Descriptor instead.
+func (*CreateStockInRequest) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *MovementUpdate) GetMovements() []*StockMovement {
+func (x *CreateStockInRequest) GetStockIn() *StockIn {
 	if x != nil {
-		return x.Movements
+		return x.StockIn
 	}
 	return nil
 }
 
-func (x *MovementUpdate) GetTimestamp() string {
+type CreateStockOutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StockOut      *StockOut              `protobuf:"bytes,1,opt,name=stock_out,json=stockOut,proto3" json:"stock_out,omitempty"` // uuid and created_at are ignored; shipped_date defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStockOutRequest) Reset() {
+	*x = CreateStockOutRequest{}
+	mi := &file_proto_movement_movement_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStockOutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStockOutRequest) ProtoMessage() {}
+
+func (x *CreateStockOutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[11]
 	if x != nil {
-		return x.Timestamp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStockOutRequest.ProtoReflect.Descriptor instead.
+func (*CreateStockOutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateStockOutRequest) GetStockOut() *StockOut {
+	if x != nil {
+		return x.StockOut
+	}
+	return nil
+}
+
+type CreateStockAdjustmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Adjustment    *StockAdjustment       `protobuf:"bytes,1,opt,name=adjustment,proto3" json:"adjustment,omitempty"` // uuid, quantities before/after and created_at are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateStockAdjustmentRequest) Reset() {
+	*x = CreateStockAdjustmentRequest{}
+	mi := &file_proto_movement_movement_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateStockAdjustmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStockAdjustmentRequest) ProtoMessage() {}
+
+func (x *CreateStockAdjustmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_movement_movement_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStockAdjustmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateStockAdjustmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_movement_movement_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateStockAdjustmentRequest) GetAdjustment() *StockAdjustment {
+	if x != nil {
+		return x.Adjustment
+	}
+	return nil
 }
 
 var File_proto_movement_movement_proto protoreflect.FileDescriptor
@@ -403,7 +1139,7 @@ const file_proto_movement_movement_proto_rawDesc = "" +
 	"\x05title\x18\x02 \x01(\tR\x05title\"3\n" +
 	"\tWarehouse\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xe6\x04\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x8b\x05\n" +
 	"\rStockMovement\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
 	"\fproduct_uuid\x18\x02 \x01(\tR\vproductUuid\x12%\n" +
@@ -425,14 +1161,99 @@ const file_proto_movement_movement_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x10 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x11 \x01(\tR\tupdatedAt\"-\n" +
+	"updated_at\x18\x11 \x01(\tR\tupdatedAt\x12#\n" +
+	"\rdocument_uuid\x18\x12 \x01(\tR\fdocumentUuid\"-\n" +
 	"\x15WatchMovementsRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\"e\n" +
 	"\x0eMovementUpdate\x125\n" +
 	"\tmovements\x18\x01 \x03(\v2\x17.movement.StockMovementR\tmovements\x12\x1c\n" +
-	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp2`\n" +
+	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\"\xe8\x03\n" +
+	"\x14ListMovementsRequest\x12%\n" +
+	"\x0ewarehouse_uuid\x18\x01 \x01(\tR\rwarehouseUuid\x12!\n" +
+	"\fproduct_uuid\x18\x02 \x01(\tR\vproductUuid\x12%\n" +
+	"\x0emovement_types\x18\x03 \x03(\tR\rmovementTypes\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x04 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x05 \x01(\tR\aendDate\x12)\n" +
+	"\x10reference_number\x18\x06 \x01(\tR\x0freferenceNumber\x12+\n" +
+	"\x11adjustment_reason\x18\a \x01(\tR\x10adjustmentReason\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x12#\n" +
+	"\rquantity_sign\x18\t \x01(\tR\fquantitySign\x12\x12\n" +
+	"\x04sort\x18\n" +
+	" \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\v \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\f \x01(\x05R\x05limit\x12\x1b\n" +
+	"\x06cursor\x18\r \x01(\tH\x00R\x06cursor\x88\x01\x01\x12#\n" +
+	"\rinclude_total\x18\x0e \x01(\bR\fincludeTotalB\t\n" +
+	"\a_cursor\"\xfd\x01\n" +
+	"\x15ListMovementsResponse\x125\n" +
+	"\tmovements\x18\x01 \x03(\v2\x17.movement.StockMovementR\tmovements\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12+\n" +
+	"\x11total_approximate\x18\x05 \x01(\bR\x10totalApproximate\x12\x1f\n" +
+	"\vnext_cursor\x18\x06 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\a \x01(\tR\n" +
+	"prevCursor\"\xcf\x02\n" +
+	"\aStockIn\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
+	"\fproduct_uuid\x18\x02 \x01(\tR\vproductUuid\x12%\n" +
+	"\x0ewarehouse_uuid\x18\x03 \x01(\tR\rwarehouseUuid\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12*\n" +
+	"\x11purchase_order_no\x18\x05 \x01(\tR\x0fpurchaseOrderNo\x12#\n" +
+	"\rsupplier_uuid\x18\x06 \x01(\tR\fsupplierUuid\x12#\n" +
+	"\rreceived_date\x18\a \x01(\tR\freceivedDate\x12\x1f\n" +
+	"\vreceived_by\x18\b \x01(\tR\n" +
+	"receivedBy\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\"\xc6\x02\n" +
+	"\bStockOut\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
+	"\fproduct_uuid\x18\x02 \x01(\tR\vproductUuid\x12%\n" +
+	"\x0ewarehouse_uuid\x18\x03 \x01(\tR\rwarehouseUuid\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12$\n" +
+	"\x0esales_order_no\x18\x05 \x01(\tR\fsalesOrderNo\x12#\n" +
+	"\rcustomer_name\x18\x06 \x01(\tR\fcustomerName\x12!\n" +
+	"\fshipped_date\x18\a \x01(\tR\vshippedDate\x12\x1d\n" +
+	"\n" +
+	"shipped_by\x18\b \x01(\tR\tshippedBy\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\"\xde\x02\n" +
+	"\x0fStockAdjustment\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
+	"\fproduct_uuid\x18\x02 \x01(\tR\vproductUuid\x12%\n" +
+	"\x0ewarehouse_uuid\x18\x03 \x01(\tR\rwarehouseUuid\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12!\n" +
+	"\fprevious_qty\x18\x05 \x01(\x05R\vpreviousQty\x12\x17\n" +
+	"\anew_qty\x18\x06 \x01(\x05R\x06newQty\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1f\n" +
+	"\vadjusted_by\x18\b \x01(\tR\n" +
+	"adjustedBy\x12'\n" +
+	"\x0fadjustment_date\x18\t \x01(\tR\x0eadjustmentDate\x12\x14\n" +
+	"\x05notes\x18\n" +
+	" \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\"D\n" +
+	"\x14CreateStockInRequest\x12,\n" +
+	"\bstock_in\x18\x01 \x01(\v2\x11.movement.StockInR\astockIn\"H\n" +
+	"\x15CreateStockOutRequest\x12/\n" +
+	"\tstock_out\x18\x01 \x01(\v2\x12.movement.StockOutR\bstockOut\"Y\n" +
+	"\x1cCreateStockAdjustmentRequest\x129\n" +
+	"\n" +
+	"adjustment\x18\x01 \x01(\v2\x19.movement.StockAdjustmentR\n" +
+	"adjustment2\x99\x03\n" +
 	"\x0fMovementService\x12M\n" +
-	"\x0eWatchMovements\x12\x1f.movement.WatchMovementsRequest\x1a\x18.movement.MovementUpdate0\x01B\x10Z\x0eproto/movementb\x06proto3"
+	"\x0eWatchMovements\x12\x1f.movement.WatchMovementsRequest\x1a\x18.movement.MovementUpdate0\x01\x12P\n" +
+	"\rListMovements\x12\x1e.movement.ListMovementsRequest\x1a\x1f.movement.ListMovementsResponse\x12B\n" +
+	"\rCreateStockIn\x12\x1e.movement.CreateStockInRequest\x1a\x11.movement.StockIn\x12E\n" +
+	"\x0eCreateStockOut\x12\x1f.movement.CreateStockOutRequest\x1a\x12.movement.StockOut\x12Z\n" +
+	"\x15CreateStockAdjustment\x12&.movement.CreateStockAdjustmentRequest\x1a\x19.movement.StockAdjustmentB\x10Z\x0eproto/movementb\x06proto3"
 
 var (
 	file_proto_movement_movement_proto_rawDescOnce sync.Once
@@ -446,25 +1267,45 @@ func file_proto_movement_movement_proto_rawDescGZIP() []byte {
 	return file_proto_movement_movement_proto_rawDescData
 }
 
-var file_proto_movement_movement_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_movement_movement_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_proto_movement_movement_proto_goTypes = []any{
-	(*Product)(nil),               // 0: movement.Product
-	(*Warehouse)(nil),             // 1: movement.Warehouse
-	(*StockMovement)(nil),         // 2: movement.StockMovement
-	(*WatchMovementsRequest)(nil), // 3: movement.WatchMovementsRequest
-	(*MovementUpdate)(nil),        // 4: movement.MovementUpdate
+	(*Product)(nil),                      // 0: movement.Product
+	(*Warehouse)(nil),                    // 1: movement.Warehouse
+	(*StockMovement)(nil),                // 2: movement.StockMovement
+	(*WatchMovementsRequest)(nil),        // 3: movement.WatchMovementsRequest
+	(*MovementUpdate)(nil),               // 4: movement.MovementUpdate
+	(*ListMovementsRequest)(nil),         // 5: movement.ListMovementsRequest
+	(*ListMovementsResponse)(nil),        // 6: movement.ListMovementsResponse
+	(*StockIn)(nil),                      // 7: movement.StockIn
+	(*StockOut)(nil),                     // 8: movement.StockOut
+	(*StockAdjustment)(nil),              // 9: movement.StockAdjustment
+	(*CreateStockInRequest)(nil),         // 10: movement.CreateStockInRequest
+	(*CreateStockOutRequest)(nil),        // 11: movement.CreateStockOutRequest
+	(*CreateStockAdjustmentRequest)(nil), // 12: movement.CreateStockAdjustmentRequest
 }
 var file_proto_movement_movement_proto_depIdxs = []int32{
-	0, // 0: movement.StockMovement.product:type_name -> movement.Product
-	1, // 1: movement.StockMovement.warehouse:type_name -> movement.Warehouse
-	2, // 2: movement.MovementUpdate.movements:type_name -> movement.StockMovement
-	3, // 3: movement.MovementService.WatchMovements:input_type -> movement.WatchMovementsRequest
-	4, // 4: movement.MovementService.WatchMovements:output_type -> movement.MovementUpdate
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: movement.StockMovement.product:type_name -> movement.Product
+	1,  // 1: movement.StockMovement.warehouse:type_name -> movement.Warehouse
+	2,  // 2: movement.MovementUpdate.movements:type_name -> movement.StockMovement
+	2,  // 3: movement.ListMovementsResponse.movements:type_name -> movement.StockMovement
+	7,  // 4: movement.CreateStockInRequest.stock_in:type_name -> movement.StockIn
+	8,  // 5: movement.CreateStockOutRequest.stock_out:type_name -> movement.StockOut
+	9,  // 6: movement.CreateStockAdjustmentRequest.adjustment:type_name -> movement.StockAdjustment
+	3,  // 7: movement.MovementService.WatchMovements:input_type -> movement.WatchMovementsRequest
+	5,  // 8: movement.MovementService.ListMovements:input_type -> movement.ListMovementsRequest
+	10, // 9: movement.MovementService.CreateStockIn:input_type -> movement.CreateStockInRequest
+	11, // 10: movement.MovementService.CreateStockOut:input_type -> movement.CreateStockOutRequest
+	12, // 11: movement.MovementService.CreateStockAdjustment:input_type -> movement.CreateStockAdjustmentRequest
+	4,  // 12: movement.MovementService.WatchMovements:output_type -> movement.MovementUpdate
+	6,  // 13: movement.MovementService.ListMovements:output_type -> movement.ListMovementsResponse
+	7,  // 14: movement.MovementService.CreateStockIn:output_type -> movement.StockIn
+	8,  // 15: movement.MovementService.CreateStockOut:output_type -> movement.StockOut
+	9,  // 16: movement.MovementService.CreateStockAdjustment:output_type -> movement.StockAdjustment
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_movement_movement_proto_init() }
@@ -472,13 +1313,14 @@ func file_proto_movement_movement_proto_init() {
 	if File_proto_movement_movement_proto != nil {
 		return
 	}
+	file_proto_movement_movement_proto_msgTypes[5].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_movement_movement_proto_rawDesc), len(file_proto_movement_movement_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MovementService_WatchMovements_FullMethodName = "/movement.MovementService/WatchMovements"
+	MovementService_WatchMovements_FullMethodName        = "/movement.MovementService/WatchMovements"
+	MovementService_ListMovements_FullMethodName         = "/movement.MovementService/ListMovements"
+	MovementService_CreateStockIn_FullMethodName         = "/movement.MovementService/CreateStockIn"
+	MovementService_CreateStockOut_FullMethodName        = "/movement.MovementService/CreateStockOut"
+	MovementService_CreateStockAdjustment_FullMethodName = "/movement.MovementService/CreateStockAdjustment"
 )
 
 // MovementServiceClient is the client API for MovementService service.
@@ -27,6 +31,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MovementServiceClient interface {
 	WatchMovements(ctx context.Context, in *WatchMovementsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MovementUpdate], error)
+	ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error)
+	CreateStockIn(ctx context.Context, in *CreateStockInRequest, opts ...grpc.CallOption) (*StockIn, error)
+	CreateStockOut(ctx context.Context, in *CreateStockOutRequest, opts ...grpc.CallOption) (*StockOut, error)
+	CreateStockAdjustment(ctx context.Context, in *CreateStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error)
 }
 
 type movementServiceClient struct {
@@ -56,11 +64,55 @@ func (c *movementServiceClient) WatchMovements(ctx context.Context, in *WatchMov
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MovementService_WatchMovementsClient = grpc.ServerStreamingClient[MovementUpdate]
 
+func (c *movementServiceClient) ListMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*ListMovementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMovementsResponse)
+	err := c.cc.Invoke(ctx, MovementService_ListMovements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movementServiceClient) CreateStockIn(ctx context.Context, in *CreateStockInRequest, opts ...grpc.CallOption) (*StockIn, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockIn)
+	err := c.cc.Invoke(ctx, MovementService_CreateStockIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movementServiceClient) CreateStockOut(ctx context.Context, in *CreateStockOutRequest, opts ...grpc.CallOption) (*StockOut, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockOut)
+	err := c.cc.Invoke(ctx, MovementService_CreateStockOut_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *movementServiceClient) CreateStockAdjustment(ctx context.Context, in *CreateStockAdjustmentRequest, opts ...grpc.CallOption) (*StockAdjustment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockAdjustment)
+	err := c.cc.Invoke(ctx, MovementService_CreateStockAdjustment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MovementServiceServer is the server API for MovementService service.
 // All implementations must embed UnimplementedMovementServiceServer
 // for forward compatibility.
 type MovementServiceServer interface {
 	WatchMovements(*WatchMovementsRequest, grpc.ServerStreamingServer[MovementUpdate]) error
+	ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error)
+	CreateStockIn(context.Context, *CreateStockInRequest) (*StockIn, error)
+	CreateStockOut(context.Context, *CreateStockOutRequest) (*StockOut, error)
+	CreateStockAdjustment(context.Context, *CreateStockAdjustmentRequest) (*StockAdjustment, error)
 	mustEmbedUnimplementedMovementServiceServer()
 }
 
@@ -74,6 +126,18 @@ type UnimplementedMovementServiceServer struct{}
 func (UnimplementedMovementServiceServer) WatchMovements(*WatchMovementsRequest, grpc.ServerStreamingServer[MovementUpdate]) error {
 	return status.Error(codes.Unimplemented, "method WatchMovements not implemented")
 }
+func (UnimplementedMovementServiceServer) ListMovements(context.Context, *ListMovementsRequest) (*ListMovementsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMovements not implemented")
+}
+func (UnimplementedMovementServiceServer) CreateStockIn(context.Context, *CreateStockInRequest) (*StockIn, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateStockIn not implemented")
+}
+func (UnimplementedMovementServiceServer) CreateStockOut(context.Context, *CreateStockOutRequest) (*StockOut, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateStockOut not implemented")
+}
+func (UnimplementedMovementServiceServer) CreateStockAdjustment(context.Context, *CreateStockAdjustmentRequest) (*StockAdjustment, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateStockAdjustment not implemented")
+}
 func (UnimplementedMovementServiceServer) mustEmbedUnimplementedMovementServiceServer() {}
 func (UnimplementedMovementServiceServer) testEmbeddedByValue()                         {}
 
@@ -106,13 +170,102 @@ func _MovementService_WatchMovements_Handler(srv interface{}, stream grpc.Server
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MovementService_WatchMovementsServer = grpc.ServerStreamingServer[MovementUpdate]
 
+func _MovementService_ListMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovementServiceServer).ListMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovementService_ListMovements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovementServiceServer).ListMovements(ctx, req.(*ListMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovementService_CreateStockIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStockInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovementServiceServer).CreateStockIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovementService_CreateStockIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovementServiceServer).CreateStockIn(ctx, req.(*CreateStockInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovementService_CreateStockOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStockOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovementServiceServer).CreateStockOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovementService_CreateStockOut_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovementServiceServer).CreateStockOut(ctx, req.(*CreateStockOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MovementService_CreateStockAdjustment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStockAdjustmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MovementServiceServer).CreateStockAdjustment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MovementService_CreateStockAdjustment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MovementServiceServer).CreateStockAdjustment(ctx, req.(*CreateStockAdjustmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MovementService_ServiceDesc is the grpc.ServiceDesc for MovementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var MovementService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "movement.MovementService",
 	HandlerType: (*MovementServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMovements",
+			Handler:    _MovementService_ListMovements_Handler,
+		},
+		{
+			MethodName: "CreateStockIn",
+			Handler:    _MovementService_CreateStockIn_Handler,
+		},
+		{
+			MethodName: "CreateStockOut",
+			Handler:    _MovementService_CreateStockOut_Handler,
+		},
+		{
+			MethodName: "CreateStockAdjustment",
+			Handler:    _MovementService_CreateStockAdjustment_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "WatchMovements",
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/movement/movement.proto
+
+package movementconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	movement "github.com/shirloin/stockhub/proto/movement"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// MovementServiceName is the fully-qualified name of the MovementService service.
+	MovementServiceName = "movement.MovementService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// MovementServiceWatchMovementsProcedure is the fully-qualified name of the MovementService's
+	// WatchMovements RPC.
+	MovementServiceWatchMovementsProcedure = "/movement.MovementService/WatchMovements"
+	// MovementServiceListMovementsProcedure is the fully-qualified name of the MovementService's
+	// ListMovements RPC.
+	MovementServiceListMovementsProcedure = "/movement.MovementService/ListMovements"
+	// MovementServiceCreateStockInProcedure is the fully-qualified name of the MovementService's
+	// CreateStockIn RPC.
+	MovementServiceCreateStockInProcedure = "/movement.MovementService/CreateStockIn"
+	// MovementServiceCreateStockOutProcedure is the fully-qualified name of the MovementService's
+	// CreateStockOut RPC.
+	MovementServiceCreateStockOutProcedure = "/movement.MovementService/CreateStockOut"
+	// MovementServiceCreateStockAdjustmentProcedure is the fully-qualified name of the
+	// MovementService's CreateStockAdjustment RPC.
+	MovementServiceCreateStockAdjustmentProcedure = "/movement.MovementService/CreateStockAdjustment"
+)
+
+// MovementServiceClient is a client for the movement.MovementService service.
+type MovementServiceClient interface {
+	WatchMovements(context.Context, *movement.WatchMovementsRequest) (*connect.ServerStreamForClient[movement.MovementUpdate], error)
+	ListMovements(context.Context, *movement.ListMovementsRequest) (*movement.ListMovementsResponse, error)
+	CreateStockIn(context.Context, *movement.CreateStockInRequest) (*movement.StockIn, error)
+	CreateStockOut(context.Context, *movement.CreateStockOutRequest) (*movement.StockOut, error)
+	CreateStockAdjustment(context.Context, *movement.CreateStockAdjustmentRequest) (*movement.StockAdjustment, error)
+}
+
+// NewMovementServiceClient constructs a client for the movement.MovementService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewMovementServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) MovementServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	movementServiceMethods := movement.File_proto_movement_movement_proto.Services().ByName("MovementService").Methods()
+	return &movementServiceClient{
+		watchMovements: connect.NewClient[movement.WatchMovementsRequest, movement.MovementUpdate](
+			httpClient,
+			baseURL+MovementServiceWatchMovementsProcedure,
+			connect.WithSchema(movementServiceMethods.ByName("WatchMovements")),
+			connect.WithClientOptions(opts...),
+		),
+		listMovements: connect.NewClient[movement.ListMovementsRequest, movement.ListMovementsResponse](
+			httpClient,
+			baseURL+MovementServiceListMovementsProcedure,
+			connect.WithSchema(movementServiceMethods.ByName("ListMovements")),
+			connect.WithClientOptions(opts...),
+		),
+		createStockIn: connect.NewClient[movement.CreateStockInRequest, movement.StockIn](
+			httpClient,
+			baseURL+MovementServiceCreateStockInProcedure,
+			connect.WithSchema(movementServiceMethods.ByName("CreateStockIn")),
+			connect.WithClientOptions(opts...),
+		),
+		createStockOut: connect.NewClient[movement.CreateStockOutRequest, movement.StockOut](
+			httpClient,
+			baseURL+MovementServiceCreateStockOutProcedure,
+			connect.WithSchema(movementServiceMethods.ByName("CreateStockOut")),
+			connect.WithClientOptions(opts...),
+		),
+		createStockAdjustment: connect.NewClient[movement.CreateStockAdjustmentRequest, movement.StockAdjustment](
+			httpClient,
+			baseURL+MovementServiceCreateStockAdjustmentProcedure,
+			connect.WithSchema(movementServiceMethods.ByName("CreateStockAdjustment")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// movementServiceClient implements MovementServiceClient.
+type movementServiceClient struct {
+	watchMovements        *connect.Client[movement.WatchMovementsRequest, movement.MovementUpdate]
+	listMovements         *connect.Client[movement.ListMovementsRequest, movement.ListMovementsResponse]
+	createStockIn         *connect.Client[movement.CreateStockInRequest, movement.StockIn]
+	createStockOut        *connect.Client[movement.CreateStockOutRequest, movement.StockOut]
+	createStockAdjustment *connect.Client[movement.CreateStockAdjustmentRequest, movement.StockAdjustment]
+}
+
+// WatchMovements calls movement.MovementService.WatchMovements.
+func (c *movementServiceClient) WatchMovements(ctx context.Context, req *movement.WatchMovementsRequest) (*connect.ServerStreamForClient[movement.MovementUpdate], error) {
+	return c.watchMovements.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// ListMovements calls movement.MovementService.ListMovements.
+func (c *movementServiceClient) ListMovements(ctx context.Context, req *movement.ListMovementsRequest) (*movement.ListMovementsResponse, error) {
+	response, err := c.listMovements.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CreateStockIn calls movement.MovementService.CreateStockIn.
+func (c *movementServiceClient) CreateStockIn(ctx context.Context, req *movement.CreateStockInRequest) (*movement.StockIn, error) {
+	response, err := c.createStockIn.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CreateStockOut calls movement.MovementService.CreateStockOut.
+func (c *movementServiceClient) CreateStockOut(ctx context.Context, req *movement.CreateStockOutRequest) (*movement.StockOut, error) {
+	response, err := c.createStockOut.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CreateStockAdjustment calls movement.MovementService.CreateStockAdjustment.
+func (c *movementServiceClient) CreateStockAdjustment(ctx context.Context, req *movement.CreateStockAdjustmentRequest) (*movement.StockAdjustment, error) {
+	response, err := c.createStockAdjustment.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// MovementServiceHandler is an implementation of the movement.MovementService service.
+type MovementServiceHandler interface {
+	WatchMovements(context.Context, *movement.WatchMovementsRequest, *connect.ServerStream[movement.MovementUpdate]) error
+	ListMovements(context.Context, *movement.ListMovementsRequest) (*movement.ListMovementsResponse, error)
+	CreateStockIn(context.Context, *movement.CreateStockInRequest) (*movement.StockIn, error)
+	CreateStockOut(context.Context, *movement.CreateStockOutRequest) (*movement.StockOut, error)
+	CreateStockAdjustment(context.Context, *movement.CreateStockAdjustmentRequest) (*movement.StockAdjustment, error)
+}
+
+// NewMovementServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewMovementServiceHandler(svc MovementServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	movementServiceMethods := movement.File_proto_movement_movement_proto.Services().ByName("MovementService").Methods()
+	movementServiceWatchMovementsHandler := connect.NewServerStreamHandlerSimple(
+		MovementServiceWatchMovementsProcedure,
+		svc.WatchMovements,
+		connect.WithSchema(movementServiceMethods.ByName("WatchMovements")),
+		connect.WithHandlerOptions(opts...),
+	)
+	movementServiceListMovementsHandler := connect.NewUnaryHandlerSimple(
+		MovementServiceListMovementsProcedure,
+		svc.ListMovements,
+		connect.WithSchema(movementServiceMethods.ByName("ListMovements")),
+		connect.WithHandlerOptions(opts...),
+	)
+	movementServiceCreateStockInHandler := connect.NewUnaryHandlerSimple(
+		MovementServiceCreateStockInProcedure,
+		svc.CreateStockIn,
+		connect.WithSchema(movementServiceMethods.ByName("CreateStockIn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	movementServiceCreateStockOutHandler := connect.NewUnaryHandlerSimple(
+		MovementServiceCreateStockOutProcedure,
+		svc.CreateStockOut,
+		connect.WithSchema(movementServiceMethods.ByName("CreateStockOut")),
+		connect.WithHandlerOptions(opts...),
+	)
+	movementServiceCreateStockAdjustmentHandler := connect.NewUnaryHandlerSimple(
+		MovementServiceCreateStockAdjustmentProcedure,
+		svc.CreateStockAdjustment,
+		connect.WithSchema(movementServiceMethods.ByName("CreateStockAdjustment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/movement.MovementService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case MovementServiceWatchMovementsProcedure:
+			movementServiceWatchMovementsHandler.ServeHTTP(w, r)
+		case MovementServiceListMovementsProcedure:
+			movementServiceListMovementsHandler.ServeHTTP(w, r)
+		case MovementServiceCreateStockInProcedure:
+			movementServiceCreateStockInHandler.ServeHTTP(w, r)
+		case MovementServiceCreateStockOutProcedure:
+			movementServiceCreateStockOutHandler.ServeHTTP(w, r)
+		case MovementServiceCreateStockAdjustmentProcedure:
+			movementServiceCreateStockAdjustmentHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedMovementServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedMovementServiceHandler struct{}
+
+func (UnimplementedMovementServiceHandler) WatchMovements(context.Context, *movement.WatchMovementsRequest, *connect.ServerStream[movement.MovementUpdate]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("movement.MovementService.WatchMovements is not implemented"))
+}
+
+func (UnimplementedMovementServiceHandler) ListMovements(context.Context, *movement.ListMovementsRequest) (*movement.ListMovementsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("movement.MovementService.ListMovements is not implemented"))
+}
+
+func (UnimplementedMovementServiceHandler) CreateStockIn(context.Context, *movement.CreateStockInRequest) (*movement.StockIn, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("movement.MovementService.CreateStockIn is not implemented"))
+}
+
+func (UnimplementedMovementServiceHandler) CreateStockOut(context.Context, *movement.CreateStockOutRequest) (*movement.StockOut, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("movement.MovementService.CreateStockOut is not implemented"))
+}
+
+func (UnimplementedMovementServiceHandler) CreateStockAdjustment(context.Context, *movement.CreateStockAdjustmentRequest) (*movement.StockAdjustment, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("movement.MovementService.CreateStockAdjustment is not implemented"))
+}
@@ -33,6 +33,9 @@ type Product struct {
 	Barcode           string                 `protobuf:"bytes,8,opt,name=barcode,proto3" json:"barcode,omitempty"`
 	UpdatedAt         string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	CreatedAt         string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ImageUrl          string                 `protobuf:"bytes,11,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	CategoryUuid      string                 `protobuf:"bytes,12,opt,name=category_uuid,json=categoryUuid,proto3" json:"category_uuid,omitempty"`
+	SupplierUuid      string                 `protobuf:"bytes,13,opt,name=supplier_uuid,json=supplierUuid,proto3" json:"supplier_uuid,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -137,6 +140,27 @@ func (x *Product) GetCreatedAt() string {
 	return ""
 }
 
+func (x *Product) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Product) GetCategoryUuid() string {
+	if x != nil {
+		return x.CategoryUuid
+	}
+	return ""
+}
+
+func (x *Product) GetSupplierUuid() string {
+	if x != nil {
+		return x.SupplierUuid
+	}
+	return ""
+}
+
 type StockAlert struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductUuid   string                 `protobuf:"bytes,1,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
@@ -405,11 +429,407 @@ func (x *WatchTopProductsByPriceRequest) GetLimit() int32 {
 	return 0
 }
 
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_proto_product_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProductRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ListProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`   // Defaults to 1
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10
+	Search        string                 `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	CategoryUuid  string                 `protobuf:"bytes,4,opt,name=category_uuid,json=categoryUuid,proto3" json:"category_uuid,omitempty"`
+	SupplierUuid  string                 `protobuf:"bytes,5,opt,name=supplier_uuid,json=supplierUuid,proto3" json:"supplier_uuid,omitempty"`
+	MinPrice      *int32                 `protobuf:"varint,6,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice      *int32                 `protobuf:"varint,7,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	StockStatus   string                 `protobuf:"bytes,8,opt,name=stock_status,json=stockStatus,proto3" json:"stock_status,omitempty"` // "in", "low" or "out"
+	Sort          string                 `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`                                  // e.g. "price,-createdAt"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_proto_product_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCategoryUuid() string {
+	if x != nil {
+		return x.CategoryUuid
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSupplierUuid() string {
+	if x != nil {
+		return x.SupplierUuid
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetMinPrice() int32 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPrice() int32 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetStockStatus() string {
+	if x != nil {
+		return x.StockStatus
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_proto_product_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"` // uuid and timestamps are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_proto_product_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type UpdateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"` // uuid and timestamps are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_proto_product_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateProductRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_proto_product_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteProductRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_proto_product_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_product_proto_rawDescGZIP(), []int{12}
+}
+
 var File_proto_product_product_proto protoreflect.FileDescriptor
 
 const file_proto_product_product_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/product/product.proto\x12\aproduct\"\x9b\x02\n" +
+	"\x1bproto/product/product.proto\x12\aproduct\"\x82\x03\n" +
 	"\aProduct\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -423,7 +843,10 @@ const file_proto_product_product_proto_rawDesc = "" +
 	"updated_at\x18\t \x01(\tR\tupdatedAt\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\n" +
-	" \x01(\tR\tcreatedAt\"\xd4\x01\n" +
+	" \x01(\tR\tcreatedAt\x12\x1b\n" +
+	"\timage_url\x18\v \x01(\tR\bimageUrl\x12#\n" +
+	"\rcategory_uuid\x18\f \x01(\tR\fcategoryUuid\x12#\n" +
+	"\rsupplier_uuid\x18\r \x01(\tR\fsupplierUuid\"\xd4\x01\n" +
 	"\n" +
 	"StockAlert\x12!\n" +
 	"\fproduct_uuid\x18\x01 \x01(\tR\vproductUuid\x12#\n" +
@@ -441,10 +864,45 @@ const file_proto_product_product_proto_rawDesc = "" +
 	"\x06alerts\x18\x01 \x03(\v2\x13.product.StockAlertR\x06alerts\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\"6\n" +
 	"\x1eWatchTopProductsByPriceRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit2\xbf\x01\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"'\n" +
+	"\x11GetProductRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\xb8\x02\n" +
+	"\x13ListProductsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12#\n" +
+	"\rcategory_uuid\x18\x04 \x01(\tR\fcategoryUuid\x12#\n" +
+	"\rsupplier_uuid\x18\x05 \x01(\tR\fsupplierUuid\x12 \n" +
+	"\tmin_price\x18\x06 \x01(\x05H\x00R\bminPrice\x88\x01\x01\x12 \n" +
+	"\tmax_price\x18\a \x01(\x05H\x01R\bmaxPrice\x88\x01\x01\x12!\n" +
+	"\fstock_status\x18\b \x01(\tR\vstockStatus\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sortB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_price\"\x84\x01\n" +
+	"\x14ListProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.product.ProductR\bproducts\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"B\n" +
+	"\x14CreateProductRequest\x12*\n" +
+	"\aproduct\x18\x01 \x01(\v2\x10.product.ProductR\aproduct\"V\n" +
+	"\x14UpdateProductRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12*\n" +
+	"\aproduct\x18\x02 \x01(\v2\x10.product.ProductR\aproduct\"*\n" +
+	"\x14DeleteProductRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x17\n" +
+	"\x15DeleteProductResponse2\x9c\x04\n" +
 	"\x0eProductService\x12Q\n" +
 	"\x10WatchStockAlerts\x12 .product.WatchStockAlertsRequest\x1a\x19.product.StockAlertUpdate0\x01\x12Z\n" +
-	"\x17WatchTopProductsByPrice\x12'.product.WatchTopProductsByPriceRequest\x1a\x14.product.PriceUpdate0\x01B\x0fZ\rproto/productb\x06proto3"
+	"\x17WatchTopProductsByPrice\x12'.product.WatchTopProductsByPriceRequest\x1a\x14.product.PriceUpdate0\x01\x12:\n" +
+	"\n" +
+	"GetProduct\x12\x1a.product.GetProductRequest\x1a\x10.product.Product\x12K\n" +
+	"\fListProducts\x12\x1c.product.ListProductsRequest\x1a\x1d.product.ListProductsResponse\x12@\n" +
+	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x10.product.Product\x12@\n" +
+	"\rUpdateProduct\x12\x1d.product.UpdateProductRequest\x1a\x10.product.Product\x12N\n" +
+	"\rDeleteProduct\x12\x1d.product.DeleteProductRequest\x1a\x1e.product.DeleteProductResponseB\x0fZ\rproto/productb\x06proto3"
 
 var (
 	file_proto_product_product_proto_rawDescOnce sync.Once
@@ -458,7 +916,7 @@ func file_proto_product_product_proto_rawDescGZIP() []byte {
 	return file_proto_product_product_proto_rawDescData
 }
 
-var file_proto_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_proto_product_product_proto_goTypes = []any{
 	(*Product)(nil),                        // 0: product.Product
 	(*StockAlert)(nil),                     // 1: product.StockAlert
@@ -466,19 +924,39 @@ var file_proto_product_product_proto_goTypes = []any{
 	(*WatchStockAlertsRequest)(nil),        // 3: product.WatchStockAlertsRequest
 	(*StockAlertUpdate)(nil),               // 4: product.StockAlertUpdate
 	(*WatchTopProductsByPriceRequest)(nil), // 5: product.WatchTopProductsByPriceRequest
+	(*GetProductRequest)(nil),              // 6: product.GetProductRequest
+	(*ListProductsRequest)(nil),            // 7: product.ListProductsRequest
+	(*ListProductsResponse)(nil),           // 8: product.ListProductsResponse
+	(*CreateProductRequest)(nil),           // 9: product.CreateProductRequest
+	(*UpdateProductRequest)(nil),           // 10: product.UpdateProductRequest
+	(*DeleteProductRequest)(nil),           // 11: product.DeleteProductRequest
+	(*DeleteProductResponse)(nil),          // 12: product.DeleteProductResponse
 }
 var file_proto_product_product_proto_depIdxs = []int32{
-	0, // 0: product.PriceUpdate.products:type_name -> product.Product
-	1, // 1: product.StockAlertUpdate.alerts:type_name -> product.StockAlert
-	3, // 2: product.ProductService.WatchStockAlerts:input_type -> product.WatchStockAlertsRequest
-	5, // 3: product.ProductService.WatchTopProductsByPrice:input_type -> product.WatchTopProductsByPriceRequest
-	4, // 4: product.ProductService.WatchStockAlerts:output_type -> product.StockAlertUpdate
-	2, // 5: product.ProductService.WatchTopProductsByPrice:output_type -> product.PriceUpdate
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: product.PriceUpdate.products:type_name -> product.Product
+	1,  // 1: product.StockAlertUpdate.alerts:type_name -> product.StockAlert
+	0,  // 2: product.ListProductsResponse.products:type_name -> product.Product
+	0,  // 3: product.CreateProductRequest.product:type_name -> product.Product
+	0,  // 4: product.UpdateProductRequest.product:type_name -> product.Product
+	3,  // 5: product.ProductService.WatchStockAlerts:input_type -> product.WatchStockAlertsRequest
+	5,  // 6: product.ProductService.WatchTopProductsByPrice:input_type -> product.WatchTopProductsByPriceRequest
+	6,  // 7: product.ProductService.GetProduct:input_type -> product.GetProductRequest
+	7,  // 8: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	9,  // 9: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
+	10, // 10: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
+	11, // 11: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
+	4,  // 12: product.ProductService.WatchStockAlerts:output_type -> product.StockAlertUpdate
+	2,  // 13: product.ProductService.WatchTopProductsByPrice:output_type -> product.PriceUpdate
+	0,  // 14: product.ProductService.GetProduct:output_type -> product.Product
+	8,  // 15: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	0,  // 16: product.ProductService.CreateProduct:output_type -> product.Product
+	0,  // 17: product.ProductService.UpdateProduct:output_type -> product.Product
+	12, // 18: product.ProductService.DeleteProduct:output_type -> product.DeleteProductResponse
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_product_product_proto_init() }
@@ -486,13 +964,14 @@ func file_proto_product_product_proto_init() {
 	if File_proto_product_product_proto != nil {
 		return
 	}
+	file_proto_product_product_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_product_product_proto_rawDesc), len(file_proto_product_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/product/product.proto
+
+package productconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	product "github.com/shirloin/stockhub/proto/product"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// ProductServiceName is the fully-qualified name of the ProductService service.
+	ProductServiceName = "product.ProductService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// ProductServiceWatchStockAlertsProcedure is the fully-qualified name of the ProductService's
+	// WatchStockAlerts RPC.
+	ProductServiceWatchStockAlertsProcedure = "/product.ProductService/WatchStockAlerts"
+	// ProductServiceWatchTopProductsByPriceProcedure is the fully-qualified name of the
+	// ProductService's WatchTopProductsByPrice RPC.
+	ProductServiceWatchTopProductsByPriceProcedure = "/product.ProductService/WatchTopProductsByPrice"
+	// ProductServiceGetProductProcedure is the fully-qualified name of the ProductService's GetProduct
+	// RPC.
+	ProductServiceGetProductProcedure = "/product.ProductService/GetProduct"
+	// ProductServiceListProductsProcedure is the fully-qualified name of the ProductService's
+	// ListProducts RPC.
+	ProductServiceListProductsProcedure = "/product.ProductService/ListProducts"
+	// ProductServiceCreateProductProcedure is the fully-qualified name of the ProductService's
+	// CreateProduct RPC.
+	ProductServiceCreateProductProcedure = "/product.ProductService/CreateProduct"
+	// ProductServiceUpdateProductProcedure is the fully-qualified name of the ProductService's
+	// UpdateProduct RPC.
+	ProductServiceUpdateProductProcedure = "/product.ProductService/UpdateProduct"
+	// ProductServiceDeleteProductProcedure is the fully-qualified name of the ProductService's
+	// DeleteProduct RPC.
+	ProductServiceDeleteProductProcedure = "/product.ProductService/DeleteProduct"
+)
+
+// ProductServiceClient is a client for the product.ProductService service.
+type ProductServiceClient interface {
+	WatchStockAlerts(context.Context, *product.WatchStockAlertsRequest) (*connect.ServerStreamForClient[product.StockAlertUpdate], error)
+	WatchTopProductsByPrice(context.Context, *product.WatchTopProductsByPriceRequest) (*connect.ServerStreamForClient[product.PriceUpdate], error)
+	GetProduct(context.Context, *product.GetProductRequest) (*product.Product, error)
+	ListProducts(context.Context, *product.ListProductsRequest) (*product.ListProductsResponse, error)
+	CreateProduct(context.Context, *product.CreateProductRequest) (*product.Product, error)
+	UpdateProduct(context.Context, *product.UpdateProductRequest) (*product.Product, error)
+	DeleteProduct(context.Context, *product.DeleteProductRequest) (*product.DeleteProductResponse, error)
+}
+
+// NewProductServiceClient constructs a client for the product.ProductService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewProductServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) ProductServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	productServiceMethods := product.File_proto_product_product_proto.Services().ByName("ProductService").Methods()
+	return &productServiceClient{
+		watchStockAlerts: connect.NewClient[product.WatchStockAlertsRequest, product.StockAlertUpdate](
+			httpClient,
+			baseURL+ProductServiceWatchStockAlertsProcedure,
+			connect.WithSchema(productServiceMethods.ByName("WatchStockAlerts")),
+			connect.WithClientOptions(opts...),
+		),
+		watchTopProductsByPrice: connect.NewClient[product.WatchTopProductsByPriceRequest, product.PriceUpdate](
+			httpClient,
+			baseURL+ProductServiceWatchTopProductsByPriceProcedure,
+			connect.WithSchema(productServiceMethods.ByName("WatchTopProductsByPrice")),
+			connect.WithClientOptions(opts...),
+		),
+		getProduct: connect.NewClient[product.GetProductRequest, product.Product](
+			httpClient,
+			baseURL+ProductServiceGetProductProcedure,
+			connect.WithSchema(productServiceMethods.ByName("GetProduct")),
+			connect.WithClientOptions(opts...),
+		),
+		listProducts: connect.NewClient[product.ListProductsRequest, product.ListProductsResponse](
+			httpClient,
+			baseURL+ProductServiceListProductsProcedure,
+			connect.WithSchema(productServiceMethods.ByName("ListProducts")),
+			connect.WithClientOptions(opts...),
+		),
+		createProduct: connect.NewClient[product.CreateProductRequest, product.Product](
+			httpClient,
+			baseURL+ProductServiceCreateProductProcedure,
+			connect.WithSchema(productServiceMethods.ByName("CreateProduct")),
+			connect.WithClientOptions(opts...),
+		),
+		updateProduct: connect.NewClient[product.UpdateProductRequest, product.Product](
+			httpClient,
+			baseURL+ProductServiceUpdateProductProcedure,
+			connect.WithSchema(productServiceMethods.ByName("UpdateProduct")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteProduct: connect.NewClient[product.DeleteProductRequest, product.DeleteProductResponse](
+			httpClient,
+			baseURL+ProductServiceDeleteProductProcedure,
+			connect.WithSchema(productServiceMethods.ByName("DeleteProduct")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// productServiceClient implements ProductServiceClient.
+type productServiceClient struct {
+	watchStockAlerts        *connect.Client[product.WatchStockAlertsRequest, product.StockAlertUpdate]
+	watchTopProductsByPrice *connect.Client[product.WatchTopProductsByPriceRequest, product.PriceUpdate]
+	getProduct              *connect.Client[product.GetProductRequest, product.Product]
+	listProducts            *connect.Client[product.ListProductsRequest, product.ListProductsResponse]
+	createProduct           *connect.Client[product.CreateProductRequest, product.Product]
+	updateProduct           *connect.Client[product.UpdateProductRequest, product.Product]
+	deleteProduct           *connect.Client[product.DeleteProductRequest, product.DeleteProductResponse]
+}
+
+// WatchStockAlerts calls product.ProductService.WatchStockAlerts.
+func (c *productServiceClient) WatchStockAlerts(ctx context.Context, req *product.WatchStockAlertsRequest) (*connect.ServerStreamForClient[product.StockAlertUpdate], error) {
+	return c.watchStockAlerts.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// WatchTopProductsByPrice calls product.ProductService.WatchTopProductsByPrice.
+func (c *productServiceClient) WatchTopProductsByPrice(ctx context.Context, req *product.WatchTopProductsByPriceRequest) (*connect.ServerStreamForClient[product.PriceUpdate], error) {
+	return c.watchTopProductsByPrice.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// GetProduct calls product.ProductService.GetProduct.
+func (c *productServiceClient) GetProduct(ctx context.Context, req *product.GetProductRequest) (*product.Product, error) {
+	response, err := c.getProduct.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListProducts calls product.ProductService.ListProducts.
+func (c *productServiceClient) ListProducts(ctx context.Context, req *product.ListProductsRequest) (*product.ListProductsResponse, error) {
+	response, err := c.listProducts.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CreateProduct calls product.ProductService.CreateProduct.
+func (c *productServiceClient) CreateProduct(ctx context.Context, req *product.CreateProductRequest) (*product.Product, error) {
+	response, err := c.createProduct.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// UpdateProduct calls product.ProductService.UpdateProduct.
+func (c *productServiceClient) UpdateProduct(ctx context.Context, req *product.UpdateProductRequest) (*product.Product, error) {
+	response, err := c.updateProduct.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// DeleteProduct calls product.ProductService.DeleteProduct.
+func (c *productServiceClient) DeleteProduct(ctx context.Context, req *product.DeleteProductRequest) (*product.DeleteProductResponse, error) {
+	response, err := c.deleteProduct.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ProductServiceHandler is an implementation of the product.ProductService service.
+type ProductServiceHandler interface {
+	WatchStockAlerts(context.Context, *product.WatchStockAlertsRequest, *connect.ServerStream[product.StockAlertUpdate]) error
+	WatchTopProductsByPrice(context.Context, *product.WatchTopProductsByPriceRequest, *connect.ServerStream[product.PriceUpdate]) error
+	GetProduct(context.Context, *product.GetProductRequest) (*product.Product, error)
+	ListProducts(context.Context, *product.ListProductsRequest) (*product.ListProductsResponse, error)
+	CreateProduct(context.Context, *product.CreateProductRequest) (*product.Product, error)
+	UpdateProduct(context.Context, *product.UpdateProductRequest) (*product.Product, error)
+	DeleteProduct(context.Context, *product.DeleteProductRequest) (*product.DeleteProductResponse, error)
+}
+
+// NewProductServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewProductServiceHandler(svc ProductServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	productServiceMethods := product.File_proto_product_product_proto.Services().ByName("ProductService").Methods()
+	productServiceWatchStockAlertsHandler := connect.NewServerStreamHandlerSimple(
+		ProductServiceWatchStockAlertsProcedure,
+		svc.WatchStockAlerts,
+		connect.WithSchema(productServiceMethods.ByName("WatchStockAlerts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceWatchTopProductsByPriceHandler := connect.NewServerStreamHandlerSimple(
+		ProductServiceWatchTopProductsByPriceProcedure,
+		svc.WatchTopProductsByPrice,
+		connect.WithSchema(productServiceMethods.ByName("WatchTopProductsByPrice")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceGetProductHandler := connect.NewUnaryHandlerSimple(
+		ProductServiceGetProductProcedure,
+		svc.GetProduct,
+		connect.WithSchema(productServiceMethods.ByName("GetProduct")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceListProductsHandler := connect.NewUnaryHandlerSimple(
+		ProductServiceListProductsProcedure,
+		svc.ListProducts,
+		connect.WithSchema(productServiceMethods.ByName("ListProducts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceCreateProductHandler := connect.NewUnaryHandlerSimple(
+		ProductServiceCreateProductProcedure,
+		svc.CreateProduct,
+		connect.WithSchema(productServiceMethods.ByName("CreateProduct")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceUpdateProductHandler := connect.NewUnaryHandlerSimple(
+		ProductServiceUpdateProductProcedure,
+		svc.UpdateProduct,
+		connect.WithSchema(productServiceMethods.ByName("UpdateProduct")),
+		connect.WithHandlerOptions(opts...),
+	)
+	productServiceDeleteProductHandler := connect.NewUnaryHandlerSimple(
+		ProductServiceDeleteProductProcedure,
+		svc.DeleteProduct,
+		connect.WithSchema(productServiceMethods.ByName("DeleteProduct")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/product.ProductService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ProductServiceWatchStockAlertsProcedure:
+			productServiceWatchStockAlertsHandler.ServeHTTP(w, r)
+		case ProductServiceWatchTopProductsByPriceProcedure:
+			productServiceWatchTopProductsByPriceHandler.ServeHTTP(w, r)
+		case ProductServiceGetProductProcedure:
+			productServiceGetProductHandler.ServeHTTP(w, r)
+		case ProductServiceListProductsProcedure:
+			productServiceListProductsHandler.ServeHTTP(w, r)
+		case ProductServiceCreateProductProcedure:
+			productServiceCreateProductHandler.ServeHTTP(w, r)
+		case ProductServiceUpdateProductProcedure:
+			productServiceUpdateProductHandler.ServeHTTP(w, r)
+		case ProductServiceDeleteProductProcedure:
+			productServiceDeleteProductHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedProductServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedProductServiceHandler struct{}
+
+func (UnimplementedProductServiceHandler) WatchStockAlerts(context.Context, *product.WatchStockAlertsRequest, *connect.ServerStream[product.StockAlertUpdate]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.WatchStockAlerts is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) WatchTopProductsByPrice(context.Context, *product.WatchTopProductsByPriceRequest, *connect.ServerStream[product.PriceUpdate]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.WatchTopProductsByPrice is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) GetProduct(context.Context, *product.GetProductRequest) (*product.Product, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.GetProduct is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) ListProducts(context.Context, *product.ListProductsRequest) (*product.ListProductsResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.ListProducts is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) CreateProduct(context.Context, *product.CreateProductRequest) (*product.Product, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.CreateProduct is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) UpdateProduct(context.Context, *product.UpdateProductRequest) (*product.Product, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.UpdateProduct is not implemented"))
+}
+
+func (UnimplementedProductServiceHandler) DeleteProduct(context.Context, *product.DeleteProductRequest) (*product.DeleteProductResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("product.ProductService.DeleteProduct is not implemented"))
+}
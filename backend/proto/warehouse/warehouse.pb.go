@@ -333,6 +333,538 @@ func (x *WarehouseUpdate) GetTimestamp() string {
 	return ""
 }
 
+type StockTransfer struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Uuid              string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ProductUuid       string                 `protobuf:"bytes,2,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
+	FromWarehouseUuid string                 `protobuf:"bytes,3,opt,name=from_warehouse_uuid,json=fromWarehouseUuid,proto3" json:"from_warehouse_uuid,omitempty"`
+	ToWarehouseUuid   string                 `protobuf:"bytes,4,opt,name=to_warehouse_uuid,json=toWarehouseUuid,proto3" json:"to_warehouse_uuid,omitempty"`
+	Quantity          int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TransferDate      string                 `protobuf:"bytes,6,opt,name=transfer_date,json=transferDate,proto3" json:"transfer_date,omitempty"`
+	Notes             string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StockTransfer) Reset() {
+	*x = StockTransfer{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockTransfer) ProtoMessage() {}
+
+func (x *StockTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockTransfer.ProtoReflect.Descriptor instead.
+func (*StockTransfer) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StockTransfer) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetFromWarehouseUuid() string {
+	if x != nil {
+		return x.FromWarehouseUuid
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetToWarehouseUuid() string {
+	if x != nil {
+		return x.ToWarehouseUuid
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockTransfer) GetTransferDate() string {
+	if x != nil {
+		return x.TransferDate
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *StockTransfer) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWarehouseRequest) Reset() {
+	*x = GetWarehouseRequest{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWarehouseRequest) ProtoMessage() {}
+
+func (x *GetWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*GetWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWarehouseRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ListWarehousesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`   // Defaults to 1
+	Limit          int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 10
+	IncludeMetrics bool                   `protobuf:"varint,3,opt,name=include_metrics,json=includeMetrics,proto3" json:"include_metrics,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListWarehousesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWarehousesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWarehousesRequest) GetIncludeMetrics() bool {
+	if x != nil {
+		return x.IncludeMetrics
+	}
+	return false
+}
+
+type ListWarehousesResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Warehouses    []*WarehouseWithMetrics `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"` // Metrics are zero unless include_metrics is set
+	Page          int32                   `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Total         int64                   `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*WarehouseWithMetrics {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+func (x *ListWarehousesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListWarehousesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWarehousesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"` // uuid and timestamps are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseRequest) Reset() {
+	*x = CreateWarehouseRequest{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseRequest) ProtoMessage() {}
+
+func (x *CreateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateWarehouseRequest) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type UpdateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Warehouse     *Warehouse             `protobuf:"bytes,2,opt,name=warehouse,proto3" json:"warehouse,omitempty"` // uuid and timestamps are ignored
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseRequest) Reset() {
+	*x = UpdateWarehouseRequest{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseRequest) ProtoMessage() {}
+
+func (x *UpdateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateWarehouseRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type DeleteWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWarehouseRequest) Reset() {
+	*x = DeleteWarehouseRequest{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWarehouseRequest) ProtoMessage() {}
+
+func (x *DeleteWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteWarehouseRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeleteWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWarehouseResponse) Reset() {
+	*x = DeleteWarehouseResponse{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWarehouseResponse) ProtoMessage() {}
+
+func (x *DeleteWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{11}
+}
+
+type TransferStockRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductUuid       string                 `protobuf:"bytes,1,opt,name=product_uuid,json=productUuid,proto3" json:"product_uuid,omitempty"`
+	FromWarehouseUuid string                 `protobuf:"bytes,2,opt,name=from_warehouse_uuid,json=fromWarehouseUuid,proto3" json:"from_warehouse_uuid,omitempty"`
+	ToWarehouseUuid   string                 `protobuf:"bytes,3,opt,name=to_warehouse_uuid,json=toWarehouseUuid,proto3" json:"to_warehouse_uuid,omitempty"`
+	Quantity          int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TransferDate      string                 `protobuf:"bytes,5,opt,name=transfer_date,json=transferDate,proto3" json:"transfer_date,omitempty"` // RFC 3339, defaults to now
+	Notes             string                 `protobuf:"bytes,6,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TransferStockRequest) Reset() {
+	*x = TransferStockRequest{}
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStockRequest) ProtoMessage() {}
+
+func (x *TransferStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_warehouse_warehouse_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStockRequest.ProtoReflect.Descriptor instead.
+func (*TransferStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_warehouse_warehouse_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TransferStockRequest) GetProductUuid() string {
+	if x != nil {
+		return x.ProductUuid
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetFromWarehouseUuid() string {
+	if x != nil {
+		return x.FromWarehouseUuid
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetToWarehouseUuid() string {
+	if x != nil {
+		return x.ToWarehouseUuid
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransferStockRequest) GetTransferDate() string {
+	if x != nil {
+		return x.TransferDate
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
 var File_proto_warehouse_warehouse_proto protoreflect.FileDescriptor
 
 const file_proto_warehouse_warehouse_proto_rawDesc = "" +
@@ -369,9 +901,53 @@ const file_proto_warehouse_warehouse_proto_rawDesc = "" +
 	"\n" +
 	"warehouses\x18\x01 \x03(\v2\x1f.warehouse.WarehouseWithMetricsR\n" +
 	"warehouses\x12\x1c\n" +
-	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp2f\n" +
+	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\"\x98\x02\n" +
+	"\rStockTransfer\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
+	"\fproduct_uuid\x18\x02 \x01(\tR\vproductUuid\x12.\n" +
+	"\x13from_warehouse_uuid\x18\x03 \x01(\tR\x11fromWarehouseUuid\x12*\n" +
+	"\x11to_warehouse_uuid\x18\x04 \x01(\tR\x0ftoWarehouseUuid\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12#\n" +
+	"\rtransfer_date\x18\x06 \x01(\tR\ftransferDate\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\")\n" +
+	"\x13GetWarehouseRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"j\n" +
+	"\x15ListWarehousesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12'\n" +
+	"\x0finclude_metrics\x18\x03 \x01(\bR\x0eincludeMetrics\"\x99\x01\n" +
+	"\x16ListWarehousesResponse\x12?\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x1f.warehouse.WarehouseWithMetricsR\n" +
+	"warehouses\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"L\n" +
+	"\x16CreateWarehouseRequest\x122\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x14.warehouse.WarehouseR\twarehouse\"`\n" +
+	"\x16UpdateWarehouseRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x122\n" +
+	"\twarehouse\x18\x02 \x01(\v2\x14.warehouse.WarehouseR\twarehouse\",\n" +
+	"\x16DeleteWarehouseRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x19\n" +
+	"\x17DeleteWarehouseResponse\"\xec\x01\n" +
+	"\x14TransferStockRequest\x12!\n" +
+	"\fproduct_uuid\x18\x01 \x01(\tR\vproductUuid\x12.\n" +
+	"\x13from_warehouse_uuid\x18\x02 \x01(\tR\x11fromWarehouseUuid\x12*\n" +
+	"\x11to_warehouse_uuid\x18\x03 \x01(\tR\x0ftoWarehouseUuid\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12#\n" +
+	"\rtransfer_date\x18\x05 \x01(\tR\ftransferDate\x12\x14\n" +
+	"\x05notes\x18\x06 \x01(\tR\x05notes2\xc1\x04\n" +
 	"\x10WarehouseService\x12R\n" +
-	"\x0fWatchWarehouses\x12!.warehouse.WatchWarehousesRequest\x1a\x1a.warehouse.WarehouseUpdate0\x01B\x11Z\x0fproto/warehouseb\x06proto3"
+	"\x0fWatchWarehouses\x12!.warehouse.WatchWarehousesRequest\x1a\x1a.warehouse.WarehouseUpdate0\x01\x12D\n" +
+	"\fGetWarehouse\x12\x1e.warehouse.GetWarehouseRequest\x1a\x14.warehouse.Warehouse\x12U\n" +
+	"\x0eListWarehouses\x12 .warehouse.ListWarehousesRequest\x1a!.warehouse.ListWarehousesResponse\x12J\n" +
+	"\x0fCreateWarehouse\x12!.warehouse.CreateWarehouseRequest\x1a\x14.warehouse.Warehouse\x12J\n" +
+	"\x0fUpdateWarehouse\x12!.warehouse.UpdateWarehouseRequest\x1a\x14.warehouse.Warehouse\x12X\n" +
+	"\x0fDeleteWarehouse\x12!.warehouse.DeleteWarehouseRequest\x1a\".warehouse.DeleteWarehouseResponse\x12J\n" +
+	"\rTransferStock\x12\x1f.warehouse.TransferStockRequest\x1a\x18.warehouse.StockTransferB\x11Z\x0fproto/warehouseb\x06proto3"
 
 var (
 	file_proto_warehouse_warehouse_proto_rawDescOnce sync.Once
@@ -385,23 +961,47 @@ func file_proto_warehouse_warehouse_proto_rawDescGZIP() []byte {
 	return file_proto_warehouse_warehouse_proto_rawDescData
 }
 
-var file_proto_warehouse_warehouse_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_warehouse_warehouse_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_proto_warehouse_warehouse_proto_goTypes = []any{
-	(*Warehouse)(nil),              // 0: warehouse.Warehouse
-	(*WarehouseWithMetrics)(nil),   // 1: warehouse.WarehouseWithMetrics
-	(*WatchWarehousesRequest)(nil), // 2: warehouse.WatchWarehousesRequest
-	(*WarehouseUpdate)(nil),        // 3: warehouse.WarehouseUpdate
+	(*Warehouse)(nil),               // 0: warehouse.Warehouse
+	(*WarehouseWithMetrics)(nil),    // 1: warehouse.WarehouseWithMetrics
+	(*WatchWarehousesRequest)(nil),  // 2: warehouse.WatchWarehousesRequest
+	(*WarehouseUpdate)(nil),         // 3: warehouse.WarehouseUpdate
+	(*StockTransfer)(nil),           // 4: warehouse.StockTransfer
+	(*GetWarehouseRequest)(nil),     // 5: warehouse.GetWarehouseRequest
+	(*ListWarehousesRequest)(nil),   // 6: warehouse.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),  // 7: warehouse.ListWarehousesResponse
+	(*CreateWarehouseRequest)(nil),  // 8: warehouse.CreateWarehouseRequest
+	(*UpdateWarehouseRequest)(nil),  // 9: warehouse.UpdateWarehouseRequest
+	(*DeleteWarehouseRequest)(nil),  // 10: warehouse.DeleteWarehouseRequest
+	(*DeleteWarehouseResponse)(nil), // 11: warehouse.DeleteWarehouseResponse
+	(*TransferStockRequest)(nil),    // 12: warehouse.TransferStockRequest
 }
 var file_proto_warehouse_warehouse_proto_depIdxs = []int32{
-	0, // 0: warehouse.WarehouseWithMetrics.warehouse:type_name -> warehouse.Warehouse
-	1, // 1: warehouse.WarehouseUpdate.warehouses:type_name -> warehouse.WarehouseWithMetrics
-	2, // 2: warehouse.WarehouseService.WatchWarehouses:input_type -> warehouse.WatchWarehousesRequest
-	3, // 3: warehouse.WarehouseService.WatchWarehouses:output_type -> warehouse.WarehouseUpdate
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: warehouse.WarehouseWithMetrics.warehouse:type_name -> warehouse.Warehouse
+	1,  // 1: warehouse.WarehouseUpdate.warehouses:type_name -> warehouse.WarehouseWithMetrics
+	1,  // 2: warehouse.ListWarehousesResponse.warehouses:type_name -> warehouse.WarehouseWithMetrics
+	0,  // 3: warehouse.CreateWarehouseRequest.warehouse:type_name -> warehouse.Warehouse
+	0,  // 4: warehouse.UpdateWarehouseRequest.warehouse:type_name -> warehouse.Warehouse
+	2,  // 5: warehouse.WarehouseService.WatchWarehouses:input_type -> warehouse.WatchWarehousesRequest
+	5,  // 6: warehouse.WarehouseService.GetWarehouse:input_type -> warehouse.GetWarehouseRequest
+	6,  // 7: warehouse.WarehouseService.ListWarehouses:input_type -> warehouse.ListWarehousesRequest
+	8,  // 8: warehouse.WarehouseService.CreateWarehouse:input_type -> warehouse.CreateWarehouseRequest
+	9,  // 9: warehouse.WarehouseService.UpdateWarehouse:input_type -> warehouse.UpdateWarehouseRequest
+	10, // 10: warehouse.WarehouseService.DeleteWarehouse:input_type -> warehouse.DeleteWarehouseRequest
+	12, // 11: warehouse.WarehouseService.TransferStock:input_type -> warehouse.TransferStockRequest
+	3,  // 12: warehouse.WarehouseService.WatchWarehouses:output_type -> warehouse.WarehouseUpdate
+	0,  // 13: warehouse.WarehouseService.GetWarehouse:output_type -> warehouse.Warehouse
+	7,  // 14: warehouse.WarehouseService.ListWarehouses:output_type -> warehouse.ListWarehousesResponse
+	0,  // 15: warehouse.WarehouseService.CreateWarehouse:output_type -> warehouse.Warehouse
+	0,  // 16: warehouse.WarehouseService.UpdateWarehouse:output_type -> warehouse.Warehouse
+	11, // 17: warehouse.WarehouseService.DeleteWarehouse:output_type -> warehouse.DeleteWarehouseResponse
+	4,  // 18: warehouse.WarehouseService.TransferStock:output_type -> warehouse.StockTransfer
+	12, // [12:19] is the sub-list for method output_type
+	5,  // [5:12] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_proto_warehouse_warehouse_proto_init() }
@@ -415,7 +1015,7 @@ func file_proto_warehouse_warehouse_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_warehouse_warehouse_proto_rawDesc), len(file_proto_warehouse_warehouse_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,12 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	WarehouseService_WatchWarehouses_FullMethodName = "/warehouse.WarehouseService/WatchWarehouses"
+	WarehouseService_GetWarehouse_FullMethodName    = "/warehouse.WarehouseService/GetWarehouse"
+	WarehouseService_ListWarehouses_FullMethodName  = "/warehouse.WarehouseService/ListWarehouses"
+	WarehouseService_CreateWarehouse_FullMethodName = "/warehouse.WarehouseService/CreateWarehouse"
+	WarehouseService_UpdateWarehouse_FullMethodName = "/warehouse.WarehouseService/UpdateWarehouse"
+	WarehouseService_DeleteWarehouse_FullMethodName = "/warehouse.WarehouseService/DeleteWarehouse"
+	WarehouseService_TransferStock_FullMethodName   = "/warehouse.WarehouseService/TransferStock"
 )
 
 // WarehouseServiceClient is the client API for WarehouseService service.
@@ -27,6 +33,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type WarehouseServiceClient interface {
 	WatchWarehouses(ctx context.Context, in *WatchWarehousesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WarehouseUpdate], error)
+	GetWarehouse(ctx context.Context, in *GetWarehouseRequest, opts ...grpc.CallOption) (*Warehouse, error)
+	ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error)
+	CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*Warehouse, error)
+	UpdateWarehouse(ctx context.Context, in *UpdateWarehouseRequest, opts ...grpc.CallOption) (*Warehouse, error)
+	DeleteWarehouse(ctx context.Context, in *DeleteWarehouseRequest, opts ...grpc.CallOption) (*DeleteWarehouseResponse, error)
+	TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*StockTransfer, error)
 }
 
 type warehouseServiceClient struct {
@@ -56,11 +68,77 @@ func (c *warehouseServiceClient) WatchWarehouses(ctx context.Context, in *WatchW
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WarehouseService_WatchWarehousesClient = grpc.ServerStreamingClient[WarehouseUpdate]
 
+func (c *warehouseServiceClient) GetWarehouse(ctx context.Context, in *GetWarehouseRequest, opts ...grpc.CallOption) (*Warehouse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Warehouse)
+	err := c.cc.Invoke(ctx, WarehouseService_GetWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) ListWarehouses(ctx context.Context, in *ListWarehousesRequest, opts ...grpc.CallOption) (*ListWarehousesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWarehousesResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_ListWarehouses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) CreateWarehouse(ctx context.Context, in *CreateWarehouseRequest, opts ...grpc.CallOption) (*Warehouse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Warehouse)
+	err := c.cc.Invoke(ctx, WarehouseService_CreateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) UpdateWarehouse(ctx context.Context, in *UpdateWarehouseRequest, opts ...grpc.CallOption) (*Warehouse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Warehouse)
+	err := c.cc.Invoke(ctx, WarehouseService_UpdateWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) DeleteWarehouse(ctx context.Context, in *DeleteWarehouseRequest, opts ...grpc.CallOption) (*DeleteWarehouseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWarehouseResponse)
+	err := c.cc.Invoke(ctx, WarehouseService_DeleteWarehouse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehouseServiceClient) TransferStock(ctx context.Context, in *TransferStockRequest, opts ...grpc.CallOption) (*StockTransfer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockTransfer)
+	err := c.cc.Invoke(ctx, WarehouseService_TransferStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WarehouseServiceServer is the server API for WarehouseService service.
 // All implementations must embed UnimplementedWarehouseServiceServer
 // for forward compatibility.
 type WarehouseServiceServer interface {
 	WatchWarehouses(*WatchWarehousesRequest, grpc.ServerStreamingServer[WarehouseUpdate]) error
+	GetWarehouse(context.Context, *GetWarehouseRequest) (*Warehouse, error)
+	ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error)
+	CreateWarehouse(context.Context, *CreateWarehouseRequest) (*Warehouse, error)
+	UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*Warehouse, error)
+	DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*DeleteWarehouseResponse, error)
+	TransferStock(context.Context, *TransferStockRequest) (*StockTransfer, error)
 	mustEmbedUnimplementedWarehouseServiceServer()
 }
 
@@ -74,6 +152,24 @@ type UnimplementedWarehouseServiceServer struct{}
 func (UnimplementedWarehouseServiceServer) WatchWarehouses(*WatchWarehousesRequest, grpc.ServerStreamingServer[WarehouseUpdate]) error {
 	return status.Error(codes.Unimplemented, "method WatchWarehouses not implemented")
 }
+func (UnimplementedWarehouseServiceServer) GetWarehouse(context.Context, *GetWarehouseRequest) (*Warehouse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWarehouse not implemented")
+}
+func (UnimplementedWarehouseServiceServer) ListWarehouses(context.Context, *ListWarehousesRequest) (*ListWarehousesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWarehouses not implemented")
+}
+func (UnimplementedWarehouseServiceServer) CreateWarehouse(context.Context, *CreateWarehouseRequest) (*Warehouse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateWarehouse not implemented")
+}
+func (UnimplementedWarehouseServiceServer) UpdateWarehouse(context.Context, *UpdateWarehouseRequest) (*Warehouse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateWarehouse not implemented")
+}
+func (UnimplementedWarehouseServiceServer) DeleteWarehouse(context.Context, *DeleteWarehouseRequest) (*DeleteWarehouseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteWarehouse not implemented")
+}
+func (UnimplementedWarehouseServiceServer) TransferStock(context.Context, *TransferStockRequest) (*StockTransfer, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferStock not implemented")
+}
 func (UnimplementedWarehouseServiceServer) mustEmbedUnimplementedWarehouseServiceServer() {}
 func (UnimplementedWarehouseServiceServer) testEmbeddedByValue()                          {}
 
@@ -106,13 +202,146 @@ func _WarehouseService_WatchWarehouses_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WarehouseService_WatchWarehousesServer = grpc.ServerStreamingServer[WarehouseUpdate]
 
+func _WarehouseService_GetWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).GetWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_GetWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).GetWarehouse(ctx, req.(*GetWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_ListWarehouses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWarehousesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).ListWarehouses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_ListWarehouses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).ListWarehouses(ctx, req.(*ListWarehousesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_CreateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).CreateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_CreateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).CreateWarehouse(ctx, req.(*CreateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_UpdateWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).UpdateWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_UpdateWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).UpdateWarehouse(ctx, req.(*UpdateWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_DeleteWarehouse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWarehouseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).DeleteWarehouse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_DeleteWarehouse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).DeleteWarehouse(ctx, req.(*DeleteWarehouseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehouseService_TransferStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehouseServiceServer).TransferStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehouseService_TransferStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehouseServiceServer).TransferStock(ctx, req.(*TransferStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WarehouseService_ServiceDesc is the grpc.ServiceDesc for WarehouseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var WarehouseService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "warehouse.WarehouseService",
 	HandlerType: (*WarehouseServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWarehouse",
+			Handler:    _WarehouseService_GetWarehouse_Handler,
+		},
+		{
+			MethodName: "ListWarehouses",
+			Handler:    _WarehouseService_ListWarehouses_Handler,
+		},
+		{
+			MethodName: "CreateWarehouse",
+			Handler:    _WarehouseService_CreateWarehouse_Handler,
+		},
+		{
+			MethodName: "UpdateWarehouse",
+			Handler:    _WarehouseService_UpdateWarehouse_Handler,
+		},
+		{
+			MethodName: "DeleteWarehouse",
+			Handler:    _WarehouseService_DeleteWarehouse_Handler,
+		},
+		{
+			MethodName: "TransferStock",
+			Handler:    _WarehouseService_TransferStock_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "WatchWarehouses",
@@ -0,0 +1,307 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/warehouse/warehouse.proto
+
+package warehouseconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	warehouse "github.com/shirloin/stockhub/proto/warehouse"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// WarehouseServiceName is the fully-qualified name of the WarehouseService service.
+	WarehouseServiceName = "warehouse.WarehouseService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// WarehouseServiceWatchWarehousesProcedure is the fully-qualified name of the WarehouseService's
+	// WatchWarehouses RPC.
+	WarehouseServiceWatchWarehousesProcedure = "/warehouse.WarehouseService/WatchWarehouses"
+	// WarehouseServiceGetWarehouseProcedure is the fully-qualified name of the WarehouseService's
+	// GetWarehouse RPC.
+	WarehouseServiceGetWarehouseProcedure = "/warehouse.WarehouseService/GetWarehouse"
+	// WarehouseServiceListWarehousesProcedure is the fully-qualified name of the WarehouseService's
+	// ListWarehouses RPC.
+	WarehouseServiceListWarehousesProcedure = "/warehouse.WarehouseService/ListWarehouses"
+	// WarehouseServiceCreateWarehouseProcedure is the fully-qualified name of the WarehouseService's
+	// CreateWarehouse RPC.
+	WarehouseServiceCreateWarehouseProcedure = "/warehouse.WarehouseService/CreateWarehouse"
+	// WarehouseServiceUpdateWarehouseProcedure is the fully-qualified name of the WarehouseService's
+	// UpdateWarehouse RPC.
+	WarehouseServiceUpdateWarehouseProcedure = "/warehouse.WarehouseService/UpdateWarehouse"
+	// WarehouseServiceDeleteWarehouseProcedure is the fully-qualified name of the WarehouseService's
+	// DeleteWarehouse RPC.
+	WarehouseServiceDeleteWarehouseProcedure = "/warehouse.WarehouseService/DeleteWarehouse"
+	// WarehouseServiceTransferStockProcedure is the fully-qualified name of the WarehouseService's
+	// TransferStock RPC.
+	WarehouseServiceTransferStockProcedure = "/warehouse.WarehouseService/TransferStock"
+)
+
+// WarehouseServiceClient is a client for the warehouse.WarehouseService service.
+type WarehouseServiceClient interface {
+	WatchWarehouses(context.Context, *warehouse.WatchWarehousesRequest) (*connect.ServerStreamForClient[warehouse.WarehouseUpdate], error)
+	GetWarehouse(context.Context, *warehouse.GetWarehouseRequest) (*warehouse.Warehouse, error)
+	ListWarehouses(context.Context, *warehouse.ListWarehousesRequest) (*warehouse.ListWarehousesResponse, error)
+	CreateWarehouse(context.Context, *warehouse.CreateWarehouseRequest) (*warehouse.Warehouse, error)
+	UpdateWarehouse(context.Context, *warehouse.UpdateWarehouseRequest) (*warehouse.Warehouse, error)
+	DeleteWarehouse(context.Context, *warehouse.DeleteWarehouseRequest) (*warehouse.DeleteWarehouseResponse, error)
+	TransferStock(context.Context, *warehouse.TransferStockRequest) (*warehouse.StockTransfer, error)
+}
+
+// NewWarehouseServiceClient constructs a client for the warehouse.WarehouseService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWarehouseServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) WarehouseServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	warehouseServiceMethods := warehouse.File_proto_warehouse_warehouse_proto.Services().ByName("WarehouseService").Methods()
+	return &warehouseServiceClient{
+		watchWarehouses: connect.NewClient[warehouse.WatchWarehousesRequest, warehouse.WarehouseUpdate](
+			httpClient,
+			baseURL+WarehouseServiceWatchWarehousesProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("WatchWarehouses")),
+			connect.WithClientOptions(opts...),
+		),
+		getWarehouse: connect.NewClient[warehouse.GetWarehouseRequest, warehouse.Warehouse](
+			httpClient,
+			baseURL+WarehouseServiceGetWarehouseProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("GetWarehouse")),
+			connect.WithClientOptions(opts...),
+		),
+		listWarehouses: connect.NewClient[warehouse.ListWarehousesRequest, warehouse.ListWarehousesResponse](
+			httpClient,
+			baseURL+WarehouseServiceListWarehousesProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("ListWarehouses")),
+			connect.WithClientOptions(opts...),
+		),
+		createWarehouse: connect.NewClient[warehouse.CreateWarehouseRequest, warehouse.Warehouse](
+			httpClient,
+			baseURL+WarehouseServiceCreateWarehouseProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("CreateWarehouse")),
+			connect.WithClientOptions(opts...),
+		),
+		updateWarehouse: connect.NewClient[warehouse.UpdateWarehouseRequest, warehouse.Warehouse](
+			httpClient,
+			baseURL+WarehouseServiceUpdateWarehouseProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("UpdateWarehouse")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWarehouse: connect.NewClient[warehouse.DeleteWarehouseRequest, warehouse.DeleteWarehouseResponse](
+			httpClient,
+			baseURL+WarehouseServiceDeleteWarehouseProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("DeleteWarehouse")),
+			connect.WithClientOptions(opts...),
+		),
+		transferStock: connect.NewClient[warehouse.TransferStockRequest, warehouse.StockTransfer](
+			httpClient,
+			baseURL+WarehouseServiceTransferStockProcedure,
+			connect.WithSchema(warehouseServiceMethods.ByName("TransferStock")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// warehouseServiceClient implements WarehouseServiceClient.
+type warehouseServiceClient struct {
+	watchWarehouses *connect.Client[warehouse.WatchWarehousesRequest, warehouse.WarehouseUpdate]
+	getWarehouse    *connect.Client[warehouse.GetWarehouseRequest, warehouse.Warehouse]
+	listWarehouses  *connect.Client[warehouse.ListWarehousesRequest, warehouse.ListWarehousesResponse]
+	createWarehouse *connect.Client[warehouse.CreateWarehouseRequest, warehouse.Warehouse]
+	updateWarehouse *connect.Client[warehouse.UpdateWarehouseRequest, warehouse.Warehouse]
+	deleteWarehouse *connect.Client[warehouse.DeleteWarehouseRequest, warehouse.DeleteWarehouseResponse]
+	transferStock   *connect.Client[warehouse.TransferStockRequest, warehouse.StockTransfer]
+}
+
+// WatchWarehouses calls warehouse.WarehouseService.WatchWarehouses.
+func (c *warehouseServiceClient) WatchWarehouses(ctx context.Context, req *warehouse.WatchWarehousesRequest) (*connect.ServerStreamForClient[warehouse.WarehouseUpdate], error) {
+	return c.watchWarehouses.CallServerStream(ctx, connect.NewRequest(req))
+}
+
+// GetWarehouse calls warehouse.WarehouseService.GetWarehouse.
+func (c *warehouseServiceClient) GetWarehouse(ctx context.Context, req *warehouse.GetWarehouseRequest) (*warehouse.Warehouse, error) {
+	response, err := c.getWarehouse.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// ListWarehouses calls warehouse.WarehouseService.ListWarehouses.
+func (c *warehouseServiceClient) ListWarehouses(ctx context.Context, req *warehouse.ListWarehousesRequest) (*warehouse.ListWarehousesResponse, error) {
+	response, err := c.listWarehouses.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// CreateWarehouse calls warehouse.WarehouseService.CreateWarehouse.
+func (c *warehouseServiceClient) CreateWarehouse(ctx context.Context, req *warehouse.CreateWarehouseRequest) (*warehouse.Warehouse, error) {
+	response, err := c.createWarehouse.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// UpdateWarehouse calls warehouse.WarehouseService.UpdateWarehouse.
+func (c *warehouseServiceClient) UpdateWarehouse(ctx context.Context, req *warehouse.UpdateWarehouseRequest) (*warehouse.Warehouse, error) {
+	response, err := c.updateWarehouse.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// DeleteWarehouse calls warehouse.WarehouseService.DeleteWarehouse.
+func (c *warehouseServiceClient) DeleteWarehouse(ctx context.Context, req *warehouse.DeleteWarehouseRequest) (*warehouse.DeleteWarehouseResponse, error) {
+	response, err := c.deleteWarehouse.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// TransferStock calls warehouse.WarehouseService.TransferStock.
+func (c *warehouseServiceClient) TransferStock(ctx context.Context, req *warehouse.TransferStockRequest) (*warehouse.StockTransfer, error) {
+	response, err := c.transferStock.CallUnary(ctx, connect.NewRequest(req))
+	if response != nil {
+		return response.Msg, err
+	}
+	return nil, err
+}
+
+// WarehouseServiceHandler is an implementation of the warehouse.WarehouseService service.
+type WarehouseServiceHandler interface {
+	WatchWarehouses(context.Context, *warehouse.WatchWarehousesRequest, *connect.ServerStream[warehouse.WarehouseUpdate]) error
+	GetWarehouse(context.Context, *warehouse.GetWarehouseRequest) (*warehouse.Warehouse, error)
+	ListWarehouses(context.Context, *warehouse.ListWarehousesRequest) (*warehouse.ListWarehousesResponse, error)
+	CreateWarehouse(context.Context, *warehouse.CreateWarehouseRequest) (*warehouse.Warehouse, error)
+	UpdateWarehouse(context.Context, *warehouse.UpdateWarehouseRequest) (*warehouse.Warehouse, error)
+	DeleteWarehouse(context.Context, *warehouse.DeleteWarehouseRequest) (*warehouse.DeleteWarehouseResponse, error)
+	TransferStock(context.Context, *warehouse.TransferStockRequest) (*warehouse.StockTransfer, error)
+}
+
+// NewWarehouseServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWarehouseServiceHandler(svc WarehouseServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	warehouseServiceMethods := warehouse.File_proto_warehouse_warehouse_proto.Services().ByName("WarehouseService").Methods()
+	warehouseServiceWatchWarehousesHandler := connect.NewServerStreamHandlerSimple(
+		WarehouseServiceWatchWarehousesProcedure,
+		svc.WatchWarehouses,
+		connect.WithSchema(warehouseServiceMethods.ByName("WatchWarehouses")),
+		connect.WithHandlerOptions(opts...),
+	)
+	warehouseServiceGetWarehouseHandler := connect.NewUnaryHandlerSimple(
+		WarehouseServiceGetWarehouseProcedure,
+		svc.GetWarehouse,
+		connect.WithSchema(warehouseServiceMethods.ByName("GetWarehouse")),
+		connect.WithHandlerOptions(opts...),
+	)
+	warehouseServiceListWarehousesHandler := connect.NewUnaryHandlerSimple(
+		WarehouseServiceListWarehousesProcedure,
+		svc.ListWarehouses,
+		connect.WithSchema(warehouseServiceMethods.ByName("ListWarehouses")),
+		connect.WithHandlerOptions(opts...),
+	)
+	warehouseServiceCreateWarehouseHandler := connect.NewUnaryHandlerSimple(
+		WarehouseServiceCreateWarehouseProcedure,
+		svc.CreateWarehouse,
+		connect.WithSchema(warehouseServiceMethods.ByName("CreateWarehouse")),
+		connect.WithHandlerOptions(opts...),
+	)
+	warehouseServiceUpdateWarehouseHandler := connect.NewUnaryHandlerSimple(
+		WarehouseServiceUpdateWarehouseProcedure,
+		svc.UpdateWarehouse,
+		connect.WithSchema(warehouseServiceMethods.ByName("UpdateWarehouse")),
+		connect.WithHandlerOptions(opts...),
+	)
+	warehouseServiceDeleteWarehouseHandler := connect.NewUnaryHandlerSimple(
+		WarehouseServiceDeleteWarehouseProcedure,
+		svc.DeleteWarehouse,
+		connect.WithSchema(warehouseServiceMethods.ByName("DeleteWarehouse")),
+		connect.WithHandlerOptions(opts...),
+	)
+	warehouseServiceTransferStockHandler := connect.NewUnaryHandlerSimple(
+		WarehouseServiceTransferStockProcedure,
+		svc.TransferStock,
+		connect.WithSchema(warehouseServiceMethods.ByName("TransferStock")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/warehouse.WarehouseService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WarehouseServiceWatchWarehousesProcedure:
+			warehouseServiceWatchWarehousesHandler.ServeHTTP(w, r)
+		case WarehouseServiceGetWarehouseProcedure:
+			warehouseServiceGetWarehouseHandler.ServeHTTP(w, r)
+		case WarehouseServiceListWarehousesProcedure:
+			warehouseServiceListWarehousesHandler.ServeHTTP(w, r)
+		case WarehouseServiceCreateWarehouseProcedure:
+			warehouseServiceCreateWarehouseHandler.ServeHTTP(w, r)
+		case WarehouseServiceUpdateWarehouseProcedure:
+			warehouseServiceUpdateWarehouseHandler.ServeHTTP(w, r)
+		case WarehouseServiceDeleteWarehouseProcedure:
+			warehouseServiceDeleteWarehouseHandler.ServeHTTP(w, r)
+		case WarehouseServiceTransferStockProcedure:
+			warehouseServiceTransferStockHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWarehouseServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWarehouseServiceHandler struct{}
+
+func (UnimplementedWarehouseServiceHandler) WatchWarehouses(context.Context, *warehouse.WatchWarehousesRequest, *connect.ServerStream[warehouse.WarehouseUpdate]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.WatchWarehouses is not implemented"))
+}
+
+func (UnimplementedWarehouseServiceHandler) GetWarehouse(context.Context, *warehouse.GetWarehouseRequest) (*warehouse.Warehouse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.GetWarehouse is not implemented"))
+}
+
+func (UnimplementedWarehouseServiceHandler) ListWarehouses(context.Context, *warehouse.ListWarehousesRequest) (*warehouse.ListWarehousesResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.ListWarehouses is not implemented"))
+}
+
+func (UnimplementedWarehouseServiceHandler) CreateWarehouse(context.Context, *warehouse.CreateWarehouseRequest) (*warehouse.Warehouse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.CreateWarehouse is not implemented"))
+}
+
+func (UnimplementedWarehouseServiceHandler) UpdateWarehouse(context.Context, *warehouse.UpdateWarehouseRequest) (*warehouse.Warehouse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.UpdateWarehouse is not implemented"))
+}
+
+func (UnimplementedWarehouseServiceHandler) DeleteWarehouse(context.Context, *warehouse.DeleteWarehouseRequest) (*warehouse.DeleteWarehouseResponse, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.DeleteWarehouse is not implemented"))
+}
+
+func (UnimplementedWarehouseServiceHandler) TransferStock(context.Context, *warehouse.TransferStockRequest) (*warehouse.StockTransfer, error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("warehouse.WarehouseService.TransferStock is not implemented"))
+}